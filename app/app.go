@@ -8,13 +8,20 @@ import (
 	"github.com/QOSGroup/qbase/context"
 	btypes "github.com/QOSGroup/qbase/types"
 	"github.com/QOSGroup/qos/module/approve"
+	approvetypes "github.com/QOSGroup/qos/module/approve/types"
 	"github.com/QOSGroup/qos/module/distribution"
 	ecomapper "github.com/QOSGroup/qos/module/eco/mapper"
 	ecotypes "github.com/QOSGroup/qos/module/eco/types"
+	"github.com/QOSGroup/qos/module/gov"
+	govtypes "github.com/QOSGroup/qos/module/gov/types"
 	"github.com/QOSGroup/qos/module/mint"
 	"github.com/QOSGroup/qos/module/qcp"
+	qcptypes "github.com/QOSGroup/qos/module/qcp/types"
 	"github.com/QOSGroup/qos/module/qsc"
+	qsctypes "github.com/QOSGroup/qos/module/qsc/types"
 	"github.com/QOSGroup/qos/module/stake"
+	"github.com/QOSGroup/qos/module/supply"
+	supplytypes "github.com/QOSGroup/qos/module/supply/types"
 	"github.com/QOSGroup/qos/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 	cmn "github.com/tendermint/tendermint/libs/common"
@@ -29,6 +36,8 @@ const (
 
 type QOSApp struct {
 	*baseabci.BaseApp
+
+	minGasPrice types.Dec // 节点接受交易的最低gas价格, QOS/gas
 }
 
 func NewApp(logger log.Logger, db dbm.DB, traceStore io.Writer) *QOSApp {
@@ -55,7 +64,8 @@ func NewApp(logger log.Logger, db dbm.DB, traceStore io.Writer) *QOSApp {
 	// 1. delegator收益发放: 计算下一发放周期(distribution)
 	// 2. unbond QOS 返还 (stake)
 	// 3. validator period 旧数据删除(distribution) //TODO
-	// 4. close inactive  validator(stake),统计新的validator (stake)
+	// 4. 提议抵押期/投票期结束处理(gov)
+	// 5. close inactive  validator(stake),统计新的validator (stake)
 
 	app.SetBeginBlocker(func(ctx context.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
 		distribution.BeginBlocker(ctx, req)
@@ -68,6 +78,8 @@ func NewApp(logger log.Logger, db dbm.DB, traceStore io.Writer) *QOSApp {
 	app.SetEndBlocker(func(ctx context.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
 		distribution.EndBlocker(ctx, req)
 		stake.EndBlockerByReturnUnbondTokens(ctx)
+		gov.EndBlocker(ctx)
+		approve.EndBlocker(ctx)
 		return stake.EndBlocker(ctx)
 	})
 
@@ -77,6 +89,9 @@ func NewApp(logger log.Logger, db dbm.DB, traceStore io.Writer) *QOSApp {
 	// QCP mapper
 	// qbase 默认已注入
 
+	// 跨链交易结果处理
+	app.RegisterTxQcpResultHandler(qcp.HandleQcpTxResult)
+
 	// QSC mapper
 	app.RegisterMapper(qsc.NewQSCMapper())
 
@@ -98,6 +113,12 @@ func NewApp(logger log.Logger, db dbm.DB, traceStore io.Writer) *QOSApp {
 	//delegationMapper
 	app.RegisterMapper(ecomapper.NewDelegationMapper())
 
+	//govMapper
+	app.RegisterMapper(gov.NewGovMapper())
+
+	//supplyMapper
+	app.RegisterMapper(supply.NewSupplyMapper())
+
 	app.RegisterCustomQueryHandler(func(ctx context.Context, route []string, req abci.RequestQuery) (res []byte, err btypes.Error) {
 
 		if len(route) == 0 {
@@ -112,6 +133,26 @@ func NewApp(logger log.Logger, db dbm.DB, traceStore io.Writer) *QOSApp {
 			return distribution.Query(ctx, route[1:], req)
 		}
 
+		if route[0] == govtypes.Gov {
+			return gov.Query(ctx, route[1:], req)
+		}
+
+		if route[0] == approvetypes.ApproveRoute {
+			return approve.Query(ctx, route[1:], req)
+		}
+
+		if route[0] == qsctypes.QSCRoute {
+			return qsc.Query(ctx, route[1:], req)
+		}
+
+		if route[0] == supplytypes.SupplyRoute {
+			return supply.Query(ctx, route[1:], req)
+		}
+
+		if route[0] == qcptypes.QCPRoute {
+			return qcp.Query(ctx, route[1:], req)
+		}
+
 		return nil, nil
 	})
 
@@ -150,20 +191,33 @@ func (app *QOSApp) ExportAppStates(forZeroHeight bool) (appState json.RawMessage
 	}
 
 	accounts := []*types.QOSAccount{}
+	continuousVestingAccounts := []*types.ContinuousVestingAccount{}
+	delayedVestingAccounts := []*types.DelayedVestingAccount{}
 	appendAccount := func(acc account.Account) (stop bool) {
-		accounts = append(accounts, acc.(*types.QOSAccount))
+		switch acc := acc.(type) {
+		case *types.QOSAccount:
+			accounts = append(accounts, acc)
+		case *types.ContinuousVestingAccount:
+			continuousVestingAccounts = append(continuousVestingAccounts, acc)
+		case *types.DelayedVestingAccount:
+			delayedVestingAccounts = append(delayedVestingAccounts, acc)
+		}
 		return false
 	}
 	ctx.Mapper(account.AccountMapperName).(*account.AccountMapper).IterateAccounts(appendAccount)
 
 	genState := NewGenesisState(
 		accounts,
+		continuousVestingAccounts,
+		delayedVestingAccounts,
 		mint.ExportGenesis(ctx),
 		stake.ExportGenesis(ctx, forZeroHeight),
 		qcp.ExportGenesis(ctx),
 		qsc.ExportGenesis(ctx),
 		approve.ExportGenesis(ctx),
 		distribution.ExportGenesis(ctx, forZeroHeight),
+		gov.ExportGenesis(ctx),
+		supply.ExportGenesis(ctx),
 	)
 	appState, err = app.GetCdc().MarshalJSONIndent(genState, "", " ")
 	if err != nil {
@@ -188,22 +242,46 @@ func (app *QOSApp) prepForZeroHeightGenesis(ctx context.Context) {
 // gas
 func (app *QOSApp) gasHandler(ctx context.Context, payer btypes.Address) btypes.Error {
 	distributionMapper := ecomapper.GetDistributionMapper(ctx)
-	gasFeeUsed := btypes.NewInt(int64(ctx.GasMeter().GasConsumed() / distributionMapper.GetParams().GasPerUnitCost))
+	params := distributionMapper.GetParams()
+
+	txStd := app.getTxStd(ctx.TxBytes())
+	if txStd == nil {
+		return btypes.ErrTxDecode("decode tx error")
+	}
+
+	// 联盟链发来的TxQcp无gas支付账户, gas由源链中继方负责, 不扣除
+	if len(payer) == 0 {
+		if app.isTxQcp(ctx.TxBytes()) {
+			return nil
+		}
+		return btypes.ErrInternal("no gas payer")
+	}
+
+	// 按gas参数表计算交易gas
+	if gasTx, ok := txStd.ITx.(ecotypes.GasTx); ok {
+		ctx.GasMeter().ConsumeGas(params.GasSchedule.CalcTxGas(gasTx), "tx gas schedule")
+	}
+
+	// 按出价扣除实际消耗gas对应的QOS, max-gas中未使用的部分不扣除
+	gasFeeUsed := calcGasFee(params, txStd, btypes.NewInt(int64(ctx.GasMeter().GasConsumed())))
 
 	if gasFeeUsed.GT(btypes.ZeroInt()) {
 		accountMapper := ctx.Mapper(account.AccountMapperName).(*account.AccountMapper)
-		account := accountMapper.GetAccount(payer).(*types.QOSAccount)
+		acc := accountMapper.GetAccount(payer)
+		account, _ := types.ToQOSAccount(acc)
 
-		if !account.EnoughOfQOS(gasFeeUsed) {
+		// 锁仓账户未释放的QOS不可支付gas
+		if !account.EnoughOfQOS(gasFeeUsed) || types.SpendableQOS(acc, ctx.BlockHeader().Time.UTC()).LT(gasFeeUsed) {
 			log := fmt.Sprintf("%s no enough coins to pay the gas after this tx done", payer)
 			return btypes.ErrInternal(log)
 		}
 
 		account.MustMinusQOS(gasFeeUsed)
 		app.Logger.Info(fmt.Sprintf("cost %d QOS from %s for gas", gasFeeUsed.Int64(), payer))
-		accountMapper.SetAccount(account)
+		accountMapper.SetAccount(acc)
 
 		distributionMapper.AddPreDistributionQOS(gasFeeUsed)
+		supply.GetSupplyMapper(ctx).LockQOS(supplytypes.PreDistribution, gasFeeUsed)
 	}
 
 	return nil
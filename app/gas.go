@@ -0,0 +1,122 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/QOSGroup/qbase/account"
+	"github.com/QOSGroup/qbase/txs"
+	btypes "github.com/QOSGroup/qbase/types"
+	ecomapper "github.com/QOSGroup/qos/module/eco/mapper"
+	ecotypes "github.com/QOSGroup/qos/module/eco/types"
+	"github.com/QOSGroup/qos/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// 设置节点接受交易的最低gas价格, 仅影响本节点CheckTx
+func (app *QOSApp) SetMinGasPrice(price types.Dec) {
+	app.minGasPrice = price
+}
+
+// CheckTx 交易进入mempool前校验gas:
+// 1. max-gas不小于按gas参数表计算的交易gas
+// 2. 交易出价(max-gas对应的QOS / 交易gas)不低于节点最低gas价格
+// 3. gas付费账户QOS足够按出价支付max-gas
+// 同时拒绝已暂停联盟链发来的TxQcp
+func (app *QOSApp) CheckTx(txBytes []byte) abci.ResponseCheckTx {
+	err := app.checkTxGas(txBytes)
+	if err == nil {
+		err = app.checkTxQcpStatus(true, txBytes)
+	}
+	if err != nil {
+		result := err.Result()
+		return abci.ResponseCheckTx{
+			Code: uint32(result.Code),
+			Log:  result.Log,
+		}
+	}
+
+	return app.BaseApp.CheckTx(txBytes)
+}
+
+func (app *QOSApp) checkTxGas(txBytes []byte) btypes.Error {
+	// 交易格式错误由BaseApp处理, 跨链交易gas由源链中继方负责
+	tx, err := btypes.DecoderTx(app.GetCdc(), txBytes)
+	if err != nil {
+		return nil
+	}
+	txStd, ok := tx.(*txs.TxStd)
+	if !ok || txStd.ITx == nil || txStd.MaxGas.IsNil() {
+		return nil
+	}
+
+	ctx := app.NewContext(true, abci.Header{})
+	params := ecomapper.GetDistributionMapper(ctx).GetParams()
+
+	if gasTx, ok := txStd.ITx.(ecotypes.GasTx); ok {
+		gas := params.GasSchedule.CalcTxGas(gasTx)
+		if txStd.MaxGas.LT(btypes.NewInt(int64(gas))) {
+			return btypes.ErrOutOfGas(fmt.Sprintf("max-gas %s is less than tx gas %d", txStd.MaxGas, gas))
+		}
+
+		// 出价为每gas支付的QOS, 节点负载高时可提高最低价格优先接受出价高的交易
+		if !app.minGasPrice.IsNil() && gas > 0 {
+			price := types.NewDecFromInt(txStd.MaxGas).QuoInt(btypes.NewInt(int64(params.GasPerUnitCost * gas)))
+			if price.LT(app.minGasPrice) {
+				return btypes.ErrInsufficientFee(fmt.Sprintf("gas price %s(max-gas %s / tx gas %d) is lower than node min-gas-price %s", price, txStd.MaxGas, gas, app.minGasPrice))
+			}
+		}
+	}
+
+	maxFee := calcGasFee(params, txStd, txStd.MaxGas)
+	if maxFee.GT(btypes.ZeroInt()) {
+		payer := txStd.ITx.GetGasPayer()
+		accountMapper := ctx.Mapper(account.AccountMapperName).(*account.AccountMapper)
+		// CheckTx前无区块时间, 锁仓账户按当前时间计算可支出的QOS
+		acc := accountMapper.GetAccount(payer)
+		if _, ok := types.ToQOSAccount(acc); !ok || types.SpendableQOS(acc, time.Now().UTC()).LT(maxFee) {
+			return btypes.ErrInsufficientFee(fmt.Sprintf("%s has no enough QOS to pay max-gas %s, need %s QOS", payer, txStd.MaxGas, maxFee))
+		}
+	}
+
+	return nil
+}
+
+// 按交易出价计算gas对应的QOS, 出价 = max-gas对应的QOS / 交易gas.
+// max-gas越大出价越高, 非GasTx交易按基础价格(gas_per_unit_cost gas = 1 QOS)计算
+func calcGasFee(params ecotypes.DistributionParams, txStd *txs.TxStd, gas btypes.BigInt) btypes.BigInt {
+	if gasTx, ok := txStd.ITx.(ecotypes.GasTx); ok && !txStd.MaxGas.IsNil() {
+		if txGas := params.GasSchedule.CalcTxGas(gasTx); txGas > 0 {
+			return gas.Mul(txStd.MaxGas).Div(btypes.NewInt(int64(params.GasPerUnitCost * txGas)))
+		}
+	}
+
+	return gas.Div(btypes.NewInt(int64(params.GasPerUnitCost)))
+}
+
+// 解析交易, 跨链交易返回其中的TxStd
+func (app *QOSApp) getTxStd(txBytes []byte) *txs.TxStd {
+	tx, err := btypes.DecoderTx(app.GetCdc(), txBytes)
+	if err != nil {
+		return nil
+	}
+
+	switch implTx := tx.(type) {
+	case *txs.TxStd:
+		return implTx
+	case *txs.TxQcp:
+		return implTx.TxStd
+	}
+
+	return nil
+}
+
+// 是否为联盟链发来的TxQcp
+func (app *QOSApp) isTxQcp(txBytes []byte) bool {
+	tx, err := btypes.DecoderTx(app.GetCdc(), txBytes)
+	if err != nil {
+		return false
+	}
+	_, ok := tx.(*txs.TxQcp)
+	return ok
+}
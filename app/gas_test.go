@@ -0,0 +1,98 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/QOSGroup/qbase/account"
+	"github.com/QOSGroup/qbase/txs"
+	btypes "github.com/QOSGroup/qbase/types"
+	ecomapper "github.com/QOSGroup/qos/module/eco/mapper"
+	"github.com/QOSGroup/qos/module/transfer"
+	transfertypes "github.com/QOSGroup/qos/module/transfer/types"
+	"github.com/QOSGroup/qos/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestCheckTxGas(t *testing.T) {
+	app := NewApp(log.NewNopLogger(), dbm.NewMemDB(), nil)
+
+	payer := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	ctx := app.NewContext(true, abci.Header{})
+	accountMapper := ctx.Mapper(account.AccountMapperName).(*account.AccountMapper)
+	acc := accountMapper.NewAccountWithAddress(payer).(*types.QOSAccount)
+	acc.QOS = btypes.NewInt(100)
+	accountMapper.SetAccount(acc)
+
+	itx := transfer.TxTransfer{
+		Senders:   transfertypes.TransItems{{Address: payer, QOS: btypes.NewInt(10)}},
+		Receivers: transfertypes.TransItems{{Address: ed25519.GenPrivKey().PubKey().Address().Bytes(), QOS: btypes.NewInt(10)}},
+	}
+	txBytes := func(maxGas int64) []byte {
+		return app.GetCdc().MustMarshalBinaryBare(txs.NewTxStd(itx, "qos", btypes.NewInt(maxGas)))
+	}
+
+	//出价 = max-gas对应的QOS / 交易gas
+	gas := int64(ecomapper.GetDistributionMapper(ctx).GetParams().GasSchedule.CalcTxGas(itx))
+	require.True(t, gas > 250 && gas <= 1000)
+
+	//max-gas小于交易gas
+	require.NotNil(t, app.checkTxGas(txBytes(gas-1)))
+	//基础价格出价需支付 gas/10 QOS
+	require.Nil(t, app.checkTxGas(txBytes(gas)))
+	//2倍基础价格出价, QOS不足以支付max-gas: 2gas * 2gas/(10 * gas) > 100
+	require.NotNil(t, app.checkTxGas(txBytes(2*gas)))
+
+	app.SetMinGasPrice(types.NewDecWithPrec(11, 2))
+	//出价低于节点最低价格
+	require.NotNil(t, app.checkTxGas(txBytes(gas)))
+	require.Nil(t, app.checkTxGas(txBytes(gas*6/5)))
+	app.SetMinGasPrice(types.MustNewDecFromStr("1"))
+	require.NotNil(t, app.checkTxGas(txBytes(gas*6/5)))
+}
+
+func TestGasFeeByPrice(t *testing.T) {
+	app := NewApp(log.NewNopLogger(), dbm.NewMemDB(), nil)
+	senderKey := ed25519.GenPrivKey()
+	sender := btypes.Address(senderKey.PubKey().Address())
+	receiver := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+
+	genesis := NewDefaultGenesisState()
+	genesis.Accounts = []*types.QOSAccount{types.NewQOSAccount(sender, btypes.NewInt(100000), nil)}
+	app.InitChain(abci.RequestInitChain{ChainId: "qos", AppStateBytes: app.GetCdc().MustMarshalJSON(genesis)})
+	app.Commit()
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{ChainID: "qos", Height: 1, Time: time.Now().UTC()}})
+
+	itx := transfer.TxTransfer{
+		Senders:   transfertypes.TransItems{{Address: sender, QOS: btypes.NewInt(10)}},
+		Receivers: transfertypes.TransItems{{Address: receiver, QOS: btypes.NewInt(10)}},
+	}
+	ctx := app.NewContext(false, abci.Header{})
+	gas := int64(ecomapper.GetDistributionMapper(ctx).GetParams().GasSchedule.CalcTxGas(itx))
+
+	//返回扣除的gas费用
+	deliverTx := func(nonce, maxGas int64) int64 {
+		accountMapper := app.NewContext(false, abci.Header{}).Mapper(account.AccountMapperName).(*account.AccountMapper)
+		before := accountMapper.GetAccount(sender).(*types.QOSAccount).QOS
+
+		tx := txs.NewTxStd(itx, "qos", btypes.NewInt(maxGas))
+		signature, _ := tx.SignTx(senderKey, nonce, "qos", "qos")
+		tx.Signature = []txs.Signature{{Pubkey: senderKey.PubKey(), Signature: signature, Nonce: nonce}}
+		res := app.DeliverTx(app.GetCdc().MustMarshalBinaryBare(tx))
+		require.Equal(t, uint32(btypes.CodeOK), res.Code, res.Log)
+
+		after := accountMapper.GetAccount(sender).(*types.QOSAccount).QOS
+		fee := before.Sub(after).Int64() - 10
+		//实际消耗gas × 出价, 至少为交易gas × 出价, 不超过max-gas × 出价
+		require.True(t, fee >= maxGas/10 && fee < maxGas*maxGas/(10*gas))
+		return fee
+	}
+
+	//出价高的交易支付更多gas费用
+	lowFee := deliverTx(1, 20*gas)
+	require.True(t, deliverTx(2, 40*gas) > lowFee)
+}
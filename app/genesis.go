@@ -6,57 +6,108 @@ import (
 	"github.com/QOSGroup/qbase/context"
 	"github.com/QOSGroup/qos/module/approve"
 	"github.com/QOSGroup/qos/module/distribution"
+	"github.com/QOSGroup/qos/module/gov"
 	"github.com/QOSGroup/qos/module/mint"
 	"github.com/QOSGroup/qos/module/qcp"
 	"github.com/QOSGroup/qos/module/qsc"
 	"github.com/QOSGroup/qos/module/stake"
+	"github.com/QOSGroup/qos/module/supply"
 	"github.com/QOSGroup/qos/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
 // QOS初始状态
 type GenesisState struct {
-	Accounts         []*types.QOSAccount       `json:"accounts"`
-	MintData         mint.GenesisState         `json:"mint"`
-	StakeData        stake.GenesisState        `json:"stake"`
-	QCPData          qcp.GenesisState          `json:"qcp"`
-	QSCData          qsc.GenesisState          `json:"qsc"`
-	ApproveData      approve.GenesisState      `json:"approve"`
-	DistributionData distribution.GenesisState `json:"distribution"`
+	Accounts                  []*types.QOSAccount               `json:"accounts"`
+	ContinuousVestingAccounts []*types.ContinuousVestingAccount `json:"continuous_vesting_accounts"`
+	DelayedVestingAccounts    []*types.DelayedVestingAccount    `json:"delayed_vesting_accounts"`
+	MintData                  mint.GenesisState                 `json:"mint"`
+	StakeData                 stake.GenesisState                `json:"stake"`
+	QCPData                   qcp.GenesisState                  `json:"qcp"`
+	QSCData                   qsc.GenesisState                  `json:"qsc"`
+	ApproveData               approve.GenesisState              `json:"approve"`
+	DistributionData          distribution.GenesisState         `json:"distribution"`
+	GovData                   gov.GenesisState                  `json:"gov"`
+	SupplyData                supply.GenesisState               `json:"supply"`
 }
 
 func NewGenesisState(accounts []*types.QOSAccount,
+	continuousVestingAccounts []*types.ContinuousVestingAccount,
+	delayedVestingAccounts []*types.DelayedVestingAccount,
 	mintData mint.GenesisState,
 	stakeData stake.GenesisState,
 	qcpData qcp.GenesisState,
 	qscData qsc.GenesisState,
 	approveData approve.GenesisState,
 	distributionData distribution.GenesisState,
+	govData gov.GenesisState,
+	supplyData supply.GenesisState,
 ) GenesisState {
 	return GenesisState{
-		Accounts:         accounts,
-		MintData:         mintData,
-		StakeData:        stakeData,
-		QCPData:          qcpData,
-		QSCData:          qscData,
-		ApproveData:      approveData,
-		DistributionData: distributionData,
+		Accounts:                  accounts,
+		ContinuousVestingAccounts: continuousVestingAccounts,
+		DelayedVestingAccounts:    delayedVestingAccounts,
+		MintData:                  mintData,
+		StakeData:                 stakeData,
+		QCPData:                   qcpData,
+		QSCData:                   qscData,
+		ApproveData:               approveData,
+		DistributionData:          distributionData,
+		GovData:                   govData,
+		SupplyData:                supplyData,
 	}
 }
 func NewDefaultGenesisState() GenesisState {
 	return GenesisState{
 		MintData:         mint.DefaultGenesisState(),
 		StakeData:        stake.DefaultGenesisState(),
+		QSCData:          qsc.DefaultGenesisState(),
 		DistributionData: distribution.DefaultGenesisState(),
+		GovData:          gov.DefaultGenesisState(),
 	}
 }
 
+// 全部创世账户, 锁仓账户返回其内嵌的QOSAccount
+func (state GenesisState) AllAccounts() []*types.QOSAccount {
+	accounts := make([]*types.QOSAccount, 0, len(state.Accounts)+len(state.ContinuousVestingAccounts)+len(state.DelayedVestingAccounts))
+	accounts = append(accounts, state.Accounts...)
+	for _, acc := range state.vestingAccounts() {
+		accounts = append(accounts, acc.GetQOSAccount())
+	}
+	return accounts
+}
+
+func (state GenesisState) vestingAccounts() []types.VestingAccount {
+	accounts := make([]types.VestingAccount, 0, len(state.ContinuousVestingAccounts)+len(state.DelayedVestingAccounts))
+	for _, acc := range state.ContinuousVestingAccounts {
+		accounts = append(accounts, acc)
+	}
+	for _, acc := range state.DelayedVestingAccounts {
+		accounts = append(accounts, acc)
+	}
+	return accounts
+}
+
 func ValidGenesis(state GenesisState) error {
-	if err := validateAccounts(state.Accounts); err != nil {
+	if err := validateAccounts(state.AllAccounts()); err != nil {
+		return err
+	}
+
+	for _, acc := range state.vestingAccounts() {
+		if err := acc.Validate(); err != nil {
+			return fmt.Errorf("invalid vesting account %s: %s", acc.GetAddress(), err.Error())
+		}
+	}
+
+	if err := stake.ValidateGenesis(state.AllAccounts(), state.StakeData); err != nil {
+		return err
+	}
+
+	if err := qsc.ValidateGenesis(state.QSCData); err != nil {
 		return err
 	}
 
-	if err := stake.ValidateGenesis(state.Accounts, state.StakeData); err != nil {
+	if err := gov.ValidateGenesis(state.GovData); err != nil {
 		return err
 	}
 
@@ -66,12 +117,16 @@ func ValidGenesis(state GenesisState) error {
 func InitGenesis(ctx context.Context, state GenesisState) []abci.ValidatorUpdate {
 	// accounts init should in the first
 	initAccounts(ctx, state.Accounts)
+	initVestingAccounts(ctx, state.vestingAccounts())
 	mint.InitGenesis(ctx, state.MintData)
 	stake.InitGenesis(ctx, state.StakeData)
 	qcp.InitGenesis(ctx, state.QCPData)
 	qsc.InitGenesis(ctx, state.QSCData)
 	approve.InitGenesis(ctx, state.ApproveData)
 	distribution.InitGenesis(ctx, state.DistributionData)
+	gov.InitGenesis(ctx, state.GovData)
+	// supply init should in the last
+	supply.InitGenesis(ctx, state.SupplyData)
 
 	return stake.GetUpdatedValidators(ctx, uint64(state.StakeData.Params.MaxValidatorCnt))
 }
@@ -86,6 +141,13 @@ func initAccounts(ctx context.Context, accounts []*types.QOSAccount) {
 	}
 }
 
+func initVestingAccounts(ctx context.Context, accounts []types.VestingAccount) {
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	for _, acc := range accounts {
+		accountMapper.SetAccount(acc)
+	}
+}
+
 func validateAccounts(accs []*types.QOSAccount) error {
 	addrMap := make(map[string]bool, len(accs))
 	for i := 0; i < len(accs); i++ {
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/QOSGroup/qbase/txs"
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/module/qcp"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// DeliverTx 拒绝已暂停联盟链发来的TxQcp
+func (app *QOSApp) DeliverTx(txBytes []byte) abci.ResponseDeliverTx {
+	if err := app.checkTxQcpStatus(false, txBytes); err != nil {
+		result := err.Result()
+		return abci.ResponseDeliverTx{
+			Code: uint32(result.Code),
+			Log:  result.Log,
+		}
+	}
+
+	return app.BaseApp.DeliverTx(txBytes)
+}
+
+func (app *QOSApp) checkTxQcpStatus(isCheckTx bool, txBytes []byte) btypes.Error {
+	// 交易格式错误由BaseApp处理
+	tx, err := btypes.DecoderTx(app.GetCdc(), txBytes)
+	if err != nil {
+		return nil
+	}
+	txQcp, ok := tx.(*txs.TxQcp)
+	if !ok {
+		return nil
+	}
+
+	ctx := app.NewContext(isCheckTx, abci.Header{})
+	if qcp.IsQCPPaused(ctx, txQcp.From) {
+		return qcp.ErrWrongQCPStatus(qcp.DefaultCodeSpace, fmt.Sprintf("qcp %s paused", txQcp.From))
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/QOSGroup/qbase/account"
+	"github.com/QOSGroup/qbase/txs"
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/module/qcp"
+	qcptypes "github.com/QOSGroup/qos/module/qcp/types"
+	"github.com/QOSGroup/qos/module/supply"
+	supplytypes "github.com/QOSGroup/qos/module/supply/types"
+	"github.com/QOSGroup/qos/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestDeliverTxQcp(t *testing.T) {
+	app := NewApp(log.NewNopLogger(), dbm.NewMemDB(), nil)
+	chain := "aoe-1000"
+	chainKey := ed25519.GenPrivKey()
+
+	genesis := NewDefaultGenesisState()
+	genesis.QCPData.QCPs = []qcptypes.QCPInfo{{ChainId: chain, PubKey: chainKey.PubKey()}}
+	genesis.QCPData.LockedQOS = []qcptypes.LockedQOS{{ChainId: chain, Amount: btypes.NewInt(10)}}
+	app.InitChain(abci.RequestInitChain{ChainId: "qos", AppStateBytes: app.GetCdc().MustMarshalJSON(genesis)})
+	app.Commit()
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{ChainID: "qos", Height: 1, Time: time.Now().UTC()}})
+
+	sender := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	receiver := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	txQcpBytes := func(sequence int64, qos int64) []byte {
+		receiveTx := qcp.NewCrossChainReceiveTx(sender, receiver, btypes.NewInt(qos), nil)
+		txQcp := txs.NewTxQCP(txs.NewTxStd(receiveTx, "qos", btypes.NewInt(qcp.CrossChainReceiveMaxGas)), chain, "qos", sequence, 1, 0, false, "")
+		signature, _ := txQcp.SignTx(chainKey)
+		txQcp.Sig = txs.Signature{Pubkey: chainKey.PubKey(), Signature: signature}
+		return app.GetCdc().MustMarshalBinaryBare(txQcp)
+	}
+
+	//无gas支付账户, 不扣除gas
+	res := app.DeliverTx(txQcpBytes(1, 5))
+	require.Equal(t, uint32(btypes.CodeOK), res.Code, res.Log)
+	require.True(t, res.GasUsed > 0)
+
+	//超过锁定的QOS
+	res = app.DeliverTx(txQcpBytes(2, 6))
+	require.NotEqual(t, uint32(btypes.CodeOK), res.Code)
+
+	app.EndBlock(abci.RequestEndBlock{Height: 1})
+	app.Commit()
+
+	ctx := app.NewContext(true, abci.Header{})
+	acc, _ := types.ToQOSAccount(ctx.Mapper(account.AccountMapperName).(*account.AccountMapper).GetAccount(receiver))
+	require.Equal(t, int64(5), acc.QOS.Int64())
+	require.Equal(t, int64(5), qcp.GetLockedQOS(ctx, chain).Int64())
+	require.Equal(t, int64(5), supply.GetSupplyMapper(ctx).GetLockedQOS(supplytypes.QCPLocked).Int64())
+	require.Equal(t, int64(2), qcp.GetQCPMapper(ctx).GetMaxChainInSequence(chain))
+}
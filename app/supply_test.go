@@ -0,0 +1,89 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/QOSGroup/qbase/account"
+	"github.com/QOSGroup/qbase/txs"
+	btypes "github.com/QOSGroup/qbase/types"
+	ecotypes "github.com/QOSGroup/qos/module/eco/types"
+	"github.com/QOSGroup/qos/module/stake"
+	"github.com/QOSGroup/qos/module/supply"
+	supplytypes "github.com/QOSGroup/qos/module/supply/types"
+	"github.com/QOSGroup/qos/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestSupplyCirculating(t *testing.T) {
+	app := NewApp(log.NewNopLogger(), dbm.NewMemDB(), nil)
+	ownerKey, delegatorKey, valKey := ed25519.GenPrivKey(), ed25519.GenPrivKey(), ed25519.GenPrivKey()
+	owner, delegator := btypes.Address(ownerKey.PubKey().Address()), btypes.Address(delegatorKey.PubKey().Address())
+
+	genesis := NewDefaultGenesisState()
+	genesis.Accounts = []*types.QOSAccount{
+		types.NewQOSAccount(owner, btypes.NewInt(100000000), nil),
+		types.NewQOSAccount(delegator, btypes.NewInt(100000000), nil),
+	}
+	genesis.StakeData.Params.DelegatorUnbondReturnHeight = 3
+	app.InitChain(abci.RequestInitChain{ChainId: "qos", AppStateBytes: app.GetCdc().MustMarshalJSON(genesis)})
+	app.Commit()
+
+	nonces := make(map[string]int64)
+	deliverTx := func(itx txs.ITx, key crypto.PrivKey) {
+		addr := btypes.Address(key.PubKey().Address()).String()
+		nonces[addr]++
+		tx := txs.NewTxStd(itx, "qos", btypes.NewInt(1000000))
+		signature, _ := tx.SignTx(key, nonces[addr], "qos", "qos")
+		tx.Signature = []txs.Signature{{Pubkey: key.PubKey(), Signature: signature, Nonce: nonces[addr]}}
+		res := app.DeliverTx(app.GetCdc().MustMarshalBinaryBare(tx))
+		require.Equal(t, uint32(btypes.CodeOK), res.Code, res.Log)
+	}
+
+	//流通量 = 账户QOS之和
+	requireCirculating := func() {
+		ctx := app.NewContext(true, abci.Header{})
+		accounts := btypes.ZeroInt()
+		ctx.Mapper(account.AccountMapperName).(*account.AccountMapper).IterateAccounts(func(acc account.Account) bool {
+			if qosAcc, ok := types.ToQOSAccount(acc); ok {
+				accounts = accounts.Add(qosAcc.GetQOS().NilToZero())
+			}
+			return false
+		})
+		supplyMapper := supply.GetSupplyMapper(ctx)
+		require.Equal(t, accounts, supplyMapper.GetSupply().TotalQOS.Sub(supplyMapper.GetTotalLockedQOS()))
+	}
+
+	//绑定, 挖矿, gas, 收益发放及复投, 解除绑定及返还
+	startTime := time.Now().UTC()
+	valAddr := valKey.PubKey().Address()
+	for height := int64(1); height <= 25; height++ {
+		req := abci.RequestBeginBlock{Header: abci.Header{ChainID: "qos", Height: height,
+			Time: startTime.Add(time.Duration(height) * 5 * time.Second), ProposerAddress: valAddr}}
+		if height > 1 {
+			req.LastCommitInfo = abci.LastCommitInfo{Votes: []abci.VoteInfo{{Validator: abci.Validator{Address: valAddr, Power: 1}, SignedLastBlock: true}}}
+		}
+		app.BeginBlock(req)
+		switch height {
+		case 1:
+			deliverTx(stake.NewCreateValidatorTx("test", owner, valKey.PubKey(), 1000000, true, "", ecotypes.DefaultCommission(time.Time{})), ownerKey)
+			deliverTx(&stake.TxCreateDelegation{Delegator: delegator, ValidatorOwner: owner, Amount: 500000}, delegatorKey)
+		case 15:
+			deliverTx(&stake.TxUnbondDelegation{Delegator: delegator, ValidatorOwner: owner, UnbondAmount: 100000}, delegatorKey)
+		}
+		app.EndBlock(abci.RequestEndBlock{Height: height})
+		app.Commit()
+		requireCirculating()
+	}
+
+	ctx := app.NewContext(true, abci.Header{})
+	supplyMapper := supply.GetSupplyMapper(ctx)
+	require.True(t, supplyMapper.GetLockedQOS(supplytypes.Bonded).GT(btypes.NewInt(1400000)))
+	require.True(t, supplyMapper.GetLockedQOS(supplytypes.Unbonding).IsZero())
+	require.True(t, supplyMapper.GetLockedQOS(supplytypes.CommunityPool).GT(btypes.ZeroInt()))
+}
@@ -7,9 +7,11 @@ import (
 	"github.com/QOSGroup/qos/app"
 	"github.com/QOSGroup/qos/module/approve/client"
 	"github.com/QOSGroup/qos/module/distribution/client"
+	"github.com/QOSGroup/qos/module/gov/client"
 	"github.com/QOSGroup/qos/module/qcp/client"
 	"github.com/QOSGroup/qos/module/qsc/client"
 	"github.com/QOSGroup/qos/module/stake/client"
+	"github.com/QOSGroup/qos/module/supply/client"
 	"github.com/QOSGroup/qos/module/transfer/client"
 	"github.com/QOSGroup/qos/types"
 	"github.com/QOSGroup/qos/version"
@@ -40,6 +42,13 @@ func main() {
 	queryCommands.AddCommand(qsc.QueryCommands(cdc)...)
 	queryCommands.AddCommand(staking.QueryCommands(cdc)...)
 	queryCommands.AddCommand(distribution.QueryCommands(cdc)...)
+	queryCommands.AddCommand(gov.QueryCommands(cdc)...)
+	queryCommands.AddCommand(supply.QueryCommands(cdc)...)
+	for _, cmd := range queryCommands.Commands() {
+		if cmd.Name() == "qcp" {
+			qcp.AddQueryCommands(cdc, cmd)
+		}
+	}
 
 	// txs commands
 	txsCommands := bcli.TxCommand()
@@ -54,6 +63,10 @@ func main() {
 	txsCommands.AddCommand(staking.TxValidatorCommands(cdc)...)
 	txsCommands.AddCommand(bctypes.LineBreak)
 	txsCommands.AddCommand(staking.TxDelegationCommands(cdc)...)
+	txsCommands.AddCommand(bctypes.LineBreak)
+	txsCommands.AddCommand(gov.TxCommands(cdc)...)
+	txsCommands.AddCommand(bctypes.LineBreak)
+	txsCommands.AddCommand(distribution.TxCommands(cdc)...)
 
 	rootCmd.AddCommand(
 		bcli.KeysCommand(cdc),
@@ -1,11 +1,14 @@
 package init
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 
+	btypes "github.com/QOSGroup/qbase/types"
 	"github.com/QOSGroup/qos/app"
 	"github.com/QOSGroup/qos/types"
 	"github.com/spf13/cobra"
@@ -14,6 +17,12 @@ import (
 	"github.com/tendermint/tendermint/libs/common"
 )
 
+const (
+	flagVestingAmount    = "vesting-amount"
+	flagVestingStartTime = "vesting-start-time"
+	flagVestingEndTime   = "vesting-end-time"
+)
+
 func AddGenesisAccount(cdc *amino.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "add-genesis-accounts [accounts]",
@@ -24,6 +33,12 @@ Multiple accounts separated by ';'.
 Example:
 
 	qosd add-genesis-accounts "address1lly0audg7yem8jt77x2jc6wtrh7v96hgve8fh8,1000000qos;address1auhqphrnk74jx2c5n80m9pdgl0ln79tyz32xlc,100000qos"
+
+Vesting accounts: with --vesting-end-time, QOS of each account is locked and released at end time,
+with --vesting-start-time also set, QOS is released linearly between start time and end time.
+--vesting-amount sets the locked QOS of each account, defaults to all QOS of the account.
+
+	qosd add-genesis-accounts "address1lly0audg7yem8jt77x2jc6wtrh7v96hgve8fh8,1000000qos" --vesting-start-time 2019-06-01T00:00:00Z --vesting-end-time 2020-06-01T00:00:00Z
 	`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(_ *cobra.Command, args []string) error {
@@ -36,6 +51,9 @@ Example:
 			}
 
 			accounts, err := types.ParseAccounts(args[0])
+			if err != nil {
+				return err
+			}
 
 			genDoc, err := loadGenesisDoc(cdc, genFile)
 			if err != nil {
@@ -47,7 +65,7 @@ Example:
 				return err
 			}
 
-			for _, v := range appState.Accounts {
+			for _, v := range appState.AllAccounts() {
 				for _, acc := range accounts {
 					if acc.AccountAddress.EqualsTo(v.GetAddress()) {
 						return fmt.Errorf("addr: %s has already exsits", v.AccountAddress.String())
@@ -55,7 +73,11 @@ Example:
 				}
 			}
 
-			appState.Accounts = append(appState.Accounts, accounts...)
+			if viper.GetString(flagVestingEndTime) == "" {
+				appState.Accounts = append(appState.Accounts, accounts...)
+			} else if err = addVestingAccounts(&appState, accounts); err != nil {
+				return err
+			}
 			for _, acc := range accounts {
 				appState.MintData.AppliedQOSAmount = appState.MintData.AppliedQOSAmount + uint64(acc.QOS.Int64())
 			}
@@ -77,6 +99,52 @@ Example:
 	}
 
 	cmd.Flags().String(cli.HomeFlag, types.DefaultNodeHome, "node's home directory")
+	cmd.Flags().Int64(flagVestingAmount, 0, "locked QOS amount of each vesting account, defaults to all QOS of the account")
+	cmd.Flags().String(flagVestingStartTime, "", "vesting start time in RFC3339 format, for continuous vesting accounts")
+	cmd.Flags().String(flagVestingEndTime, "", "vesting end time in RFC3339 format, accounts are vesting accounts if set")
 
 	return cmd
 }
+
+func addVestingAccounts(appState *app.GenesisState, accounts []*types.QOSAccount) error {
+	endTime, err := time.Parse(time.RFC3339, viper.GetString(flagVestingEndTime))
+	if err != nil {
+		return fmt.Errorf("invalid %s: %s", flagVestingEndTime, err.Error())
+	}
+
+	var startTime time.Time
+	if str := viper.GetString(flagVestingStartTime); str != "" {
+		if startTime, err = time.Parse(time.RFC3339, str); err != nil {
+			return fmt.Errorf("invalid %s: %s", flagVestingStartTime, err.Error())
+		}
+	}
+
+	amount := viper.GetInt64(flagVestingAmount)
+	if amount < 0 {
+		return errors.New("vesting amount must not be negative")
+	}
+
+	for _, acc := range accounts {
+		originalVesting := acc.GetQOS()
+		if amount > 0 {
+			originalVesting = btypes.NewInt(amount)
+		}
+
+		var vacc types.VestingAccount
+		if startTime.IsZero() {
+			dva := types.NewDelayedVestingAccount(acc, originalVesting, endTime)
+			appState.DelayedVestingAccounts = append(appState.DelayedVestingAccounts, dva)
+			vacc = dva
+		} else {
+			cva := types.NewContinuousVestingAccount(acc, originalVesting, startTime, endTime)
+			appState.ContinuousVestingAccounts = append(appState.ContinuousVestingAccounts, cva)
+			vacc = cva
+		}
+
+		if err := vacc.Validate(); err != nil {
+			return fmt.Errorf("invalid vesting account %s: %s", acc.AccountAddress, err.Error())
+		}
+	}
+
+	return nil
+}
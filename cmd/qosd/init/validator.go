@@ -19,6 +19,7 @@ import (
 	"github.com/tendermint/tendermint/privval"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 const (
@@ -27,6 +28,10 @@ const (
 	flagBondTokens  = "tokens"
 	flagDescription = "description"
 	flagCompound    = "compound"
+
+	flagCommissionRate          = "commission-rate"
+	flagCommissionMaxRate       = "commission-max-rate"
+	flagCommissionMaxChangeRate = "commission-max-change-rate"
 )
 
 func AddGenesisValidator(cdc *amino.Codec) *cobra.Command {
@@ -72,6 +77,11 @@ example:
 			}
 			desc := viper.GetString(flagDescription)
 
+			commission, err := getCommission()
+			if err != nil {
+				return err
+			}
+
 			privValidator := privval.LoadOrGenFilePV(filepath.Join(viper.GetString(cli.HomeFlag), cfg.DefaultConfig().PrivValidatorFile()))
 
 			val := ecotypes.Validator{
@@ -82,6 +92,7 @@ example:
 				Status:          ecotypes.Active,
 				BondHeight:      1,
 				Description:     desc,
+				Commission:      commission,
 			}
 
 			genDoc, err := loadGenesisDoc(cdc, genFile)
@@ -128,6 +139,9 @@ example:
 	cmd.Flags().String(flagDescription, "", "description")
 	cmd.Flags().String(cli.HomeFlag, types.DefaultNodeHome, "node's home directory")
 	cmd.Flags().Bool(flagCompound, false, "whether the income is calculated as compound interest")
+	cmd.Flags().String(flagCommissionRate, "0.01", "initial commission rate")
+	cmd.Flags().String(flagCommissionMaxRate, "0.2", "maximum commission rate")
+	cmd.Flags().String(flagCommissionMaxChangeRate, "0.01", "maximum commission rate change per day")
 
 	cmd.MarkFlagRequired(flagName)
 	cmd.MarkFlagRequired(flagOwner)
@@ -136,6 +150,20 @@ example:
 	return cmd
 }
 
+func getCommission() (commission ecotypes.Commission, err error) {
+	var rates [3]types.Fraction
+	for i, flag := range []string{flagCommissionRate, flagCommissionMaxRate, flagCommissionMaxChangeRate} {
+		dec, err := types.NewDecFromStr(viper.GetString(flag))
+		if err != nil {
+			return commission, fmt.Errorf("invalid %s: %s", flag, err.Error())
+		}
+		rates[i] = types.Fraction{Value: dec}
+	}
+
+	commission = ecotypes.NewCommission(rates[0], rates[1], rates[2], time.Time{})
+	return commission, commission.Validate()
+}
+
 func AddValidator(appState *app.GenesisState, validator ecotypes.Validator, isCompound bool) {
 	accIndex := -1
 	var acc *types.QOSAccount
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"github.com/QOSGroup/qbase/server"
 	"github.com/QOSGroup/qos/app"
 	"github.com/QOSGroup/qos/cmd/qosd/export"
@@ -9,6 +10,7 @@ import (
 	"github.com/QOSGroup/qos/types"
 	"github.com/QOSGroup/qos/version"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/cli"
 	dbm "github.com/tendermint/tendermint/libs/db"
@@ -16,6 +18,8 @@ import (
 	"io"
 )
 
+const flagMinGasPrice = "min-gas-price"
+
 func main() {
 	cdc := app.MakeCodec()
 	ctx := server.NewDefaultContext()
@@ -39,6 +43,11 @@ func main() {
 	rootCmd.AddCommand(qosdinit.AddGenesisValidator(cdc))
 
 	server.AddCommands(ctx, cdc, rootCmd, newApp)
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "start" {
+			cmd.Flags().String(flagMinGasPrice, "", "Minimum gas price(QOS per gas) validator will accept for transactions, e.g. 0.1")
+		}
+	}
 
 	executor := cli.PrepareBaseCmd(rootCmd, "qos", types.DefaultNodeHome)
 
@@ -49,5 +58,16 @@ func main() {
 }
 
 func newApp(logger log.Logger, db dbm.DB, storeTracer io.Writer) abci.Application {
-	return app.NewApp(logger, db, storeTracer)
+	qosApp := app.NewApp(logger, db, storeTracer)
+
+	// 节点最低gas价格, 可通过--min-gas-price或config.toml中min-gas-price设置
+	if minGasPrice := viper.GetString(flagMinGasPrice); minGasPrice != "" {
+		price, err := types.NewDecFromStr(minGasPrice)
+		if err != nil || price.IsNegative() {
+			panic(fmt.Sprintf("invalid min-gas-price: %s", minGasPrice))
+		}
+		qosApp.SetMinGasPrice(price)
+	}
+
+	return qosApp
 }
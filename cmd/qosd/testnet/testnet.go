@@ -7,6 +7,7 @@ import (
 	qosinit "github.com/QOSGroup/qos/cmd/qosd/init"
 	"github.com/QOSGroup/qos/module/distribution"
 	staketypes "github.com/QOSGroup/qos/module/eco/types"
+	"github.com/QOSGroup/qos/module/gov"
 	"github.com/QOSGroup/qos/module/mint"
 	"github.com/QOSGroup/qos/module/qcp"
 	"github.com/QOSGroup/qos/module/qsc"
@@ -114,10 +115,11 @@ Example:
 			appState := app.GenesisState{
 				Accounts:         genesisAccounts,
 				MintData:         mint.DefaultGenesisState(),
-				StakeData:        stake.NewGenesisState(staketypes.DefaultStakeParams(), nil, nil, nil, nil, nil, nil),
-				QCPData:          qcp.NewGenesisState(qcpPubKey, nil),
+				StakeData:        stake.NewGenesisState(staketypes.DefaultStakeParams(), nil, nil, nil, nil, nil, nil, nil),
+				QCPData:          qcp.NewGenesisState(qcpPubKey, nil, nil, nil),
 				QSCData:          qsc.NewGenesisState(qscPubKey, nil),
 				DistributionData: distribution.DefaultGenesisState(),
+				GovData:          gov.DefaultGenesisState(),
 			}
 
 			// validators
@@ -147,6 +149,7 @@ Example:
 					Status:          staketypes.Active,
 					BondTokens:      validatorBondTokens,
 					BondHeight:      1,
+					Commission:      staketypes.DefaultCommission(time.Time{}),
 				}
 
 				genesisAccounts = append(genesisAccounts, types.NewQOSAccount(owner.PubKey().Address().Bytes(), btypes.NewInt(validatorOwnerInitQOS), nil))
@@ -0,0 +1,16 @@
+package approve
+
+import (
+	"github.com/QOSGroup/qbase/context"
+)
+
+// 删除已过期的预授权
+func EndBlocker(ctx context.Context) {
+	log := ctx.Logger()
+	approveMapper := ctx.Mapper(ApproveMapperName).(*ApproveMapper)
+
+	for _, approve := range approveMapper.GetExpiredApproves(ctx.BlockHeader().Time.UTC(), uint64(ctx.BlockHeight())) {
+		approveMapper.DeleteApprove(approve.From, approve.To)
+		log.Info("approve expired", "from", approve.From.String(), "to", approve.To.String())
+	}
+}
@@ -0,0 +1,46 @@
+package approve
+
+import (
+	btypes "github.com/QOSGroup/qbase/types"
+	approvetype "github.com/QOSGroup/qos/module/approve/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"testing"
+	"time"
+)
+
+func TestEndBlocker(t *testing.T) {
+	blockTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := defaultContext().WithBlockHeight(10).WithBlockHeader(abci.Header{Height: 10, Time: blockTime})
+	approveMapper := ctx.Mapper(ApproveMapperName).(*ApproveMapper)
+
+	byTime := approvetype.NewApprove(testFromAddr, testToAddr, btypes.NewInt(100), nil)
+	byTime.ExpireTime = blockTime.Add(time.Hour)
+	approveMapper.SaveApprove(byTime)
+
+	otherAddr := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	byHeight := approvetype.NewApprove(testFromAddr, otherAddr, btypes.NewInt(100), nil)
+	byHeight.ExpireHeight = 20
+	approveMapper.SaveApprove(byHeight)
+
+	noExpiry := approvetype.NewApprove(testToAddr, otherAddr, btypes.NewInt(100), nil)
+	approveMapper.SaveApprove(noExpiry)
+
+	EndBlocker(ctx)
+	require.Equal(t, 3, len(approveMapper.GetApproves()))
+
+	ctx = ctx.WithBlockHeight(20).WithBlockHeader(abci.Header{Height: 20, Time: blockTime.Add(time.Minute)})
+	EndBlocker(ctx)
+	_, exists := approveMapper.GetApprove(testFromAddr, otherAddr)
+	require.False(t, exists)
+	require.Equal(t, 2, len(approveMapper.GetApproves()))
+
+	ctx = ctx.WithBlockHeight(21).WithBlockHeader(abci.Header{Height: 21, Time: blockTime.Add(time.Hour)})
+	EndBlocker(ctx)
+	_, exists = approveMapper.GetApprove(testFromAddr, testToAddr)
+	require.False(t, exists)
+	_, exists = approveMapper.GetApprove(testToAddr, otherAddr)
+	require.True(t, exists)
+	require.Equal(t, 0, len(approveMapper.GetExpiredApproves(blockTime.Add(100*time.Hour), 100)))
+}
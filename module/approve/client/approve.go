@@ -2,17 +2,20 @@ package approve
 
 import (
 	"errors"
+	"fmt"
 	qcliacc "github.com/QOSGroup/qbase/client/account"
 	"github.com/QOSGroup/qbase/client/context"
-	qclitx "github.com/QOSGroup/qbase/client/tx"
 	"github.com/QOSGroup/qbase/txs"
 	btypes "github.com/QOSGroup/qbase/types"
 	"github.com/QOSGroup/qos/module/approve"
 	approvetypes "github.com/QOSGroup/qos/module/approve/types"
+	distrcli "github.com/QOSGroup/qos/module/distribution/client"
+	transfercli "github.com/QOSGroup/qos/module/transfer/client"
 	"github.com/QOSGroup/qos/types"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/tendermint/go-amino"
+	"time"
 )
 
 type operateType int
@@ -24,9 +27,16 @@ const (
 	useType
 	cancleType
 
-	flagFrom  = "from"
-	flagTo    = "to"
-	flagCoins = "coins"
+	flagFrom         = "from"
+	flagTo           = "to"
+	flagCoins        = "coins"
+	flagExpireTime   = "expire-time"
+	flagExpireHeight = "expire-height"
+	flagPeriodLimit  = "period-limit"
+	flagPeriod       = "period"
+	flagPage         = "page"
+	flagLimit        = "limit"
+	flagReceivers    = "receivers"
 )
 
 func QueryApproveCmd(cdc *amino.Codec) *cobra.Command {
@@ -72,6 +82,57 @@ func QueryApproveCmd(cdc *amino.Codec) *cobra.Command {
 	return cmd
 }
 
+func QueryApprovesCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "approves",
+		Short: "Query approves created by from or granted to to",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			fromStr, toStr := viper.GetString(flagFrom), viper.GetString(flagTo)
+			if (fromStr == "") == (toStr == "") {
+				return errors.New("one and only one of --from and --to is required")
+			}
+
+			page, limit := viper.GetInt64(flagPage), viper.GetInt64(flagLimit)
+			if page <= 0 || limit <= 0 {
+				return errors.New("page and limit must be positive")
+			}
+
+			var queryPath string
+			if fromStr != "" {
+				fromAddr, err := qcliacc.GetAddrFromValue(cliCtx, fromStr)
+				if err != nil {
+					return err
+				}
+				queryPath = approvetypes.BuildQueryApprovesByFromCustomQueryPath(fromAddr, uint64(page), uint64(limit))
+			} else {
+				toAddr, err := qcliacc.GetAddrFromValue(cliCtx, toStr)
+				if err != nil {
+					return err
+				}
+				queryPath = approvetypes.BuildQueryApprovesByToCustomQueryPath(toAddr, uint64(page), uint64(limit))
+			}
+
+			res, err := cliCtx.Query(queryPath, []byte(""))
+			if err != nil {
+				return err
+			}
+
+			var result approvetypes.ApprovesQueryResult
+			cliCtx.Codec.UnmarshalJSON(res, &result)
+			return cliCtx.PrintResult(result)
+		},
+	}
+
+	cmd.Flags().String(flagFrom, "", "Name or Address of approve creator")
+	cmd.Flags().String(flagTo, "", "Name or Address of approve receiver")
+	cmd.Flags().Int64(flagPage, 1, "Page number, start from 1")
+	cmd.Flags().Int64(flagLimit, approvetypes.DefaultQueryLimit, "Number of approves per page")
+
+	return cmd
+}
+
 func CreateApproveCmd(cdc *amino.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "create-approve",
@@ -84,6 +145,10 @@ func CreateApproveCmd(cdc *amino.Codec) *cobra.Command {
 	cmd.Flags().String(flagFrom, "", "Name or Address of approve creator")
 	cmd.Flags().String(flagTo, "", "Name or Address of approve receiver")
 	cmd.Flags().String(flagCoins, "", "Coins to approve. ex: 10qos,100qstars,50qsc")
+	cmd.Flags().String(flagExpireTime, "", "Optional expire time of approve, RFC3339 format. ex: 2019-01-02T15:04:05Z")
+	cmd.Flags().Int64(flagExpireHeight, 0, "Optional expire block height of approve")
+	cmd.Flags().Int64(flagPeriodLimit, 0, "Optional max QOS can be used in each period")
+	cmd.Flags().Duration(flagPeriod, 24*time.Hour, "Period of period-limit. ex: 24h")
 	cmd.MarkFlagRequired(flagFrom)
 	cmd.MarkFlagRequired(flagTo)
 	cmd.MarkFlagRequired(flagCoins)
@@ -148,6 +213,36 @@ func UseApproveCmd(cdc *amino.Codec) *cobra.Command {
 	return cmd
 }
 
+func TransferFromApproveCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transfer-from-approve",
+		Short: "Use approve to transfer coins of approve creator to receivers",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+				if err := handleOperateFlag(ctx); err != nil {
+					return nil, err
+				}
+
+				receivers, err := transfercli.ParseTransItems(ctx, viper.GetString(flagReceivers))
+				if err != nil {
+					return nil, err
+				}
+
+				return approve.NewTransferFromApproveTx(viper.Get(flagFrom).(btypes.Address), viper.Get(flagTo).(btypes.Address), receivers), nil
+			})
+		},
+	}
+
+	cmd.Flags().String(flagFrom, "", "Name or Address of approve creator")
+	cmd.Flags().String(flagTo, "", "Name or Address of approve receiver")
+	cmd.Flags().String(flagReceivers, "", "Receivers, eg: address1vkl6nc6eedkxwjr5rsy2s5jr7qfqm487wu95w7,10qos,100qstar. multiple users separated by ';'")
+	cmd.MarkFlagRequired(flagFrom)
+	cmd.MarkFlagRequired(flagTo)
+	cmd.MarkFlagRequired(flagReceivers)
+
+	return cmd
+}
+
 func CancelApproveCmd(cdc *amino.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "cancel-approve",
@@ -189,6 +284,9 @@ func applyApprove(cdc *amino.Codec, operType operateType) error {
 
 		switch operType {
 		case createType:
+			if err := setApproveLimits(&appr); err != nil {
+				return nil, err
+			}
 			return approve.TxCreateApprove{Approve: appr}, nil
 		case increaseType:
 			return approve.TxIncreaseApprove{Approve: appr}, nil
@@ -201,7 +299,37 @@ func applyApprove(cdc *amino.Codec, operType operateType) error {
 		}
 	}
 
-	return qclitx.BroadcastTxAndPrintResult(cdc, iTxBuilder)
+	return distrcli.BroadcastTxAndPrintResult(cdc, iTxBuilder)
+}
+
+// 过期时间、高度及周期限额
+func setApproveLimits(appr *approvetypes.Approve) error {
+	if str := viper.GetString(flagExpireTime); str != "" {
+		expireTime, err := time.Parse(time.RFC3339, str)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %v", flagExpireTime, err)
+		}
+		appr.ExpireTime = expireTime.UTC()
+	}
+	expireHeight := viper.GetInt64(flagExpireHeight)
+	if expireHeight < 0 {
+		return errors.New("expire height must not be negative")
+	}
+	appr.ExpireHeight = uint64(expireHeight)
+
+	if limit := viper.GetInt64(flagPeriodLimit); limit != 0 {
+		if limit < 0 {
+			return errors.New("period limit must be positive")
+		}
+		period := viper.GetDuration(flagPeriod)
+		if period < time.Second {
+			return errors.New("period must be at least 1s")
+		}
+		appr.PeriodLimit = btypes.NewInt(limit)
+		appr.PeriodSecs = uint64(period / time.Second)
+	}
+
+	return nil
 }
 
 func handleOperateFlag(ctx context.CLIContext) error {
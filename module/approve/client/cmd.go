@@ -7,7 +7,10 @@ import (
 )
 
 func QueryCommands(cdc *amino.Codec) []*cobra.Command {
-	return bctypes.GetCommands(QueryApproveCmd(cdc))
+	return bctypes.GetCommands(
+		QueryApproveCmd(cdc),
+		QueryApprovesCmd(cdc),
+	)
 }
 
 func TxCommands(cdc *amino.Codec) []*cobra.Command {
@@ -16,6 +19,7 @@ func TxCommands(cdc *amino.Codec) []*cobra.Command {
 		IncreaseApproveCmd(cdc),
 		DecreaseApproveCmd(cdc),
 		UseApproveCmd(cdc),
+		TransferFromApproveCmd(cdc),
 		CancelApproveCmd(cdc),
 	)
 }
@@ -19,4 +19,5 @@ func RegisterCodec(cdc *amino.Codec) {
 	cdc.RegisterConcrete(&TxDecreaseApprove{}, "qos/txs/TxDecreaseApprove", nil)
 	cdc.RegisterConcrete(&TxUseApprove{}, "qos/txs/TxUseApprove", nil)
 	cdc.RegisterConcrete(&TxCancelApprove{}, "qos/txs/TxCancelApprove", nil)
+	cdc.RegisterConcrete(&TxTransferFromApprove{}, "qos/txs/TxTransferFromApprove", nil)
 }
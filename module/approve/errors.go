@@ -15,6 +15,8 @@ const (
 	CodeFromAccountNotExists      btypes.CodeType = 105 // 授权账户不存在
 	CodeApproveNotEnough          btypes.CodeType = 106 // 授权不足
 	CodeFromAccountCoinsNotEnough btypes.CodeType = 107 // 授权账户余额不足
+	CodeApproveExpired            btypes.CodeType = 108 // 预授权已过期
+	CodeApprovePeriodLimit        btypes.CodeType = 109 // 超出周期使用限额
 )
 
 func msgOrDefaultMsg(msg string, code btypes.CodeType) string {
@@ -46,6 +48,10 @@ func codeToDefaultMsg(code btypes.CodeType) string {
 		return "approve not enough"
 	case CodeFromAccountCoinsNotEnough:
 		return "from account has no enough coins"
+	case CodeApproveExpired:
+		return "approve expired"
+	case CodeApprovePeriodLimit:
+		return "approve period limit exceeded"
 	default:
 		return btypes.CodeToDefaultMsg(code)
 	}
@@ -78,3 +84,11 @@ func ErrApproveNotEnough(codeSpace btypes.CodespaceType, msg string) btypes.Erro
 func ErrFromAccountCoinsNotEnough(codeSpace btypes.CodespaceType, msg string) btypes.Error {
 	return newError(codeSpace, CodeFromAccountCoinsNotEnough, msg)
 }
+
+func ErrApproveExpired(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeApproveExpired, msg)
+}
+
+func ErrApprovePeriodLimit(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeApprovePeriodLimit, msg)
+}
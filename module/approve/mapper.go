@@ -5,11 +5,23 @@ import (
 	"github.com/QOSGroup/qbase/mapper"
 	btypes "github.com/QOSGroup/qbase/types"
 	"github.com/QOSGroup/qos/module/approve/types"
+	"time"
 )
 
 const (
 	ApproveMapperName = "approve"
 	approveKey        = "from:[%s]/to:[%s]"
+	fromPrefixKey     = "from:[%s]/"
+
+	// To索引, value为From
+	toIndexKey  = "to:[%s]/from:[%s]"
+	toPrefixKey = "to:[%s]/"
+
+	// 过期队列，按过期时间、高度排序
+	expireTimePrefix   = "expire_time:"
+	expireTimeKey      = "expire_time:[%020d]/from:[%s]/to:[%s]"
+	expireHeightPrefix = "expire_height:"
+	expireHeightKey    = "expire_height:[%020d]/from:[%s]/to:[%s]"
 )
 
 type ApproveMapper struct {
@@ -27,6 +39,18 @@ func BuildApproveKey(from string, to string) []byte {
 	return []byte(key)
 }
 
+func buildToIndexKey(to string, from string) []byte {
+	return []byte(fmt.Sprintf(toIndexKey, to, from))
+}
+
+func buildExpireTimeKey(expireTime time.Time, from string, to string) []byte {
+	return []byte(fmt.Sprintf(expireTimeKey, expireTime.UnixNano(), from, to))
+}
+
+func buildExpireHeightKey(expireHeight uint64, from string, to string) []byte {
+	return []byte(fmt.Sprintf(expireHeightKey, expireHeight, from, to))
+}
+
 func (mapper *ApproveMapper) Copy() mapper.IMapper {
 	approveMapper := &ApproveMapper{}
 	approveMapper.BaseMapper = mapper.BaseMapper.Copy()
@@ -41,16 +65,62 @@ func (mapper *ApproveMapper) GetApprove(from btypes.Address, to btypes.Address)
 	return approve, exists
 }
 
-// 保存授权
+// 保存授权，设置过期时间或高度的授权同时加入过期队列
 func (mapper *ApproveMapper) SaveApprove(approve types.Approve) {
-	key := BuildApproveKey(approve.From.String(), approve.To.String())
+	from, to := approve.From.String(), approve.To.String()
+	key := BuildApproveKey(from, to)
 	mapper.BaseMapper.Set(key, approve)
+	mapper.BaseMapper.Set(buildToIndexKey(to, from), approve.From)
+
+	if !approve.ExpireTime.IsZero() {
+		mapper.BaseMapper.Set(buildExpireTimeKey(approve.ExpireTime, from, to), []btypes.Address{approve.From, approve.To})
+	}
+	if approve.ExpireHeight > 0 {
+		mapper.BaseMapper.Set(buildExpireHeightKey(approve.ExpireHeight, from, to), []btypes.Address{approve.From, approve.To})
+	}
 }
 
-// 删除授权
+// 删除授权，同时删除To索引并移出过期队列
 func (mapper *ApproveMapper) DeleteApprove(from btypes.Address, to btypes.Address) {
+	approve, exists := mapper.GetApprove(from, to)
+	if !exists {
+		return
+	}
+
 	key := BuildApproveKey(from.String(), to.String())
 	mapper.BaseMapper.Del(key)
+	mapper.BaseMapper.Del(buildToIndexKey(to.String(), from.String()))
+
+	if !approve.ExpireTime.IsZero() {
+		mapper.BaseMapper.Del(buildExpireTimeKey(approve.ExpireTime, from.String(), to.String()))
+	}
+	if approve.ExpireHeight > 0 {
+		mapper.BaseMapper.Del(buildExpireHeightKey(approve.ExpireHeight, from.String(), to.String()))
+	}
+}
+
+// blockTime、height时已过期的授权
+func (mapper *ApproveMapper) GetExpiredApproves(blockTime time.Time, height uint64) []types.Approve {
+	approves := make([]types.Approve, 0)
+	found := make(map[string]bool)
+	process := func(bz []byte) (stop bool) {
+		var addrs []btypes.Address
+		mapper.DecodeObject(bz, &addrs)
+		key := string(BuildApproveKey(addrs[0].String(), addrs[1].String()))
+		if found[key] {
+			return false
+		}
+		if approve, exists := mapper.GetApprove(addrs[0], addrs[1]); exists && approve.IsExpired(blockTime, height) {
+			found[key] = true
+			approves = append(approves, approve)
+		}
+		return false
+	}
+
+	mapper.IteratorWithEnd([]byte(expireTimePrefix), []byte(fmt.Sprintf("expire_time:[%020d]", blockTime.UnixNano()+1)), process)
+	mapper.IteratorWithEnd([]byte(expireHeightPrefix), []byte(fmt.Sprintf("expire_height:[%020d]", height+1)), process)
+
+	return approves
 }
 
 // 所有预授权
@@ -65,3 +135,37 @@ func (mapper *ApproveMapper) GetApproves() []types.Approve {
 
 	return approves
 }
+
+// from创建的授权，跳过前offset个，limit为0时返回全部
+func (mapper *ApproveMapper) GetApprovesByFrom(from btypes.Address, offset, limit uint64) (approves []types.Approve, total uint64) {
+	approves = make([]types.Approve, 0)
+	mapper.Iterator([]byte(fmt.Sprintf(fromPrefixKey, from.String())), func(bz []byte) (stop bool) {
+		if total >= offset && (limit == 0 || uint64(len(approves)) < limit) {
+			approve := types.Approve{}
+			mapper.DecodeObject(bz, &approve)
+			approves = append(approves, approve)
+		}
+		total++
+		return false
+	})
+
+	return
+}
+
+// to获得的授权，跳过前offset个，limit为0时返回全部
+func (mapper *ApproveMapper) GetApprovesByTo(to btypes.Address, offset, limit uint64) (approves []types.Approve, total uint64) {
+	approves = make([]types.Approve, 0)
+	mapper.Iterator([]byte(fmt.Sprintf(toPrefixKey, to.String())), func(bz []byte) (stop bool) {
+		if total >= offset && (limit == 0 || uint64(len(approves)) < limit) {
+			var from btypes.Address
+			mapper.DecodeObject(bz, &from)
+			if approve, exists := mapper.GetApprove(from, to); exists {
+				approves = append(approves, approve)
+			}
+		}
+		total++
+		return false
+	})
+
+	return
+}
@@ -1,7 +1,10 @@
 package approve
 
 import (
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/module/approve/types"
 	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"testing"
 )
 
@@ -34,3 +37,41 @@ func TestDeleteApprove(t *testing.T) {
 	require.False(t, exists)
 
 }
+
+func TestGetApprovesByFromAndTo(t *testing.T) {
+	ctx := defaultContext()
+	approveMapper, _ := ctx.Mapper(ApproveMapperName).(*ApproveMapper)
+
+	from := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	to := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	for i := 0; i < 3; i++ {
+		approveMapper.SaveApprove(types.NewApprove(from, btypes.Address(ed25519.GenPrivKey().PubKey().Address()), btypes.NewInt(10), nil))
+		approveMapper.SaveApprove(types.NewApprove(btypes.Address(ed25519.GenPrivKey().PubKey().Address()), to, btypes.NewInt(10), nil))
+	}
+	approveMapper.SaveApprove(types.NewApprove(from, to, btypes.NewInt(10), nil))
+
+	approves, total := approveMapper.GetApprovesByFrom(from, 0, 0)
+	require.Equal(t, uint64(4), total)
+	require.Equal(t, 4, len(approves))
+	for _, approve := range approves {
+		require.Equal(t, from, approve.From)
+	}
+
+	approves, total = approveMapper.GetApprovesByTo(to, 2, 2)
+	require.Equal(t, uint64(4), total)
+	require.Equal(t, 2, len(approves))
+	for _, approve := range approves {
+		require.Equal(t, to, approve.To)
+	}
+
+	approves, _ = approveMapper.GetApprovesByTo(to, 4, 2)
+	require.Equal(t, 0, len(approves))
+
+	//删除授权同时删除To索引
+	approveMapper.DeleteApprove(from, to)
+	_, total = approveMapper.GetApprovesByTo(to, 0, 0)
+	require.Equal(t, uint64(3), total)
+	_, total = approveMapper.GetApprovesByFrom(from, 0, 0)
+	require.Equal(t, uint64(3), total)
+	require.Equal(t, 6, len(approveMapper.GetApproves()))
+}
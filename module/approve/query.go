@@ -0,0 +1,124 @@
+package approve
+
+import (
+	"errors"
+	"runtime/debug"
+	"strconv"
+
+	"github.com/QOSGroup/qbase/context"
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/module/approve/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+/*
+
+custom path:
+/custom/approve/$query path
+
+query path:
+	/approve/:fromAddr/:toAddr : 查询授权
+	/approves/from/:fromAddr/:page/:limit : 分页查询fromAddr创建的授权
+	/approves/to/:toAddr/:page/:limit : 分页查询toAddr获得的授权
+
+	page从1开始, page、limit可省略, 默认返回第一页, 每页100条
+
+return:
+  json字节数组
+*/
+
+func Query(ctx context.Context, route []string, req abci.RequestQuery) (res []byte, err btypes.Error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = btypes.ErrInternal(string(debug.Stack()))
+			return
+		}
+	}()
+
+	if len(route) < 1 {
+		return nil, btypes.ErrInternal("custom query miss parameters")
+	}
+
+	approveMapper := ctx.Mapper(ApproveMapperName).(*ApproveMapper)
+
+	var result interface{}
+	var e error
+
+	switch route[0] {
+	case types.QueryApprove:
+		if len(route) < 3 {
+			return nil, btypes.ErrInternal("custom query miss parameters")
+		}
+		result, e = queryApprove(approveMapper, route[1], route[2])
+	case types.QueryApproves:
+		if len(route) < 3 {
+			return nil, btypes.ErrInternal("custom query miss parameters")
+		}
+		result, e = queryApproves(approveMapper, route[1], route[2], route[3:])
+	default:
+		e = errors.New("not found match path")
+	}
+
+	if e != nil {
+		return nil, btypes.ErrInternal(e.Error())
+	}
+
+	data, e := approveMapper.GetCodec().MarshalJSON(result)
+	if e != nil {
+		return nil, btypes.ErrInternal(e.Error())
+	}
+
+	return data, nil
+}
+
+func queryApprove(approveMapper *ApproveMapper, fromBech32, toBech32 string) (interface{}, error) {
+	from, err := btypes.GetAddrFromBech32(fromBech32)
+	if err != nil {
+		return nil, err
+	}
+	to, err := btypes.GetAddrFromBech32(toBech32)
+	if err != nil {
+		return nil, err
+	}
+
+	approve, exists := approveMapper.GetApprove(from, to)
+	if !exists {
+		return nil, errors.New("approve does not exist")
+	}
+	return approve, nil
+}
+
+func queryApproves(approveMapper *ApproveMapper, direction, addrBech32 string, pagination []string) (interface{}, error) {
+	addr, err := btypes.GetAddrFromBech32(addrBech32)
+	if err != nil {
+		return nil, err
+	}
+
+	page, limit := uint64(1), uint64(types.DefaultQueryLimit)
+	if len(pagination) > 0 {
+		if page, err = strconv.ParseUint(pagination[0], 10, 64); err != nil {
+			return nil, err
+		}
+	}
+	if len(pagination) > 1 {
+		if limit, err = strconv.ParseUint(pagination[1], 10, 64); err != nil {
+			return nil, err
+		}
+	}
+	if page == 0 || limit == 0 {
+		return nil, errors.New("page and limit must be positive")
+	}
+
+	var result types.ApprovesQueryResult
+	switch direction {
+	case types.QueryFrom:
+		result.Approves, result.Total = approveMapper.GetApprovesByFrom(addr, (page-1)*limit, limit)
+	case types.QueryTo:
+		result.Approves, result.Total = approveMapper.GetApprovesByTo(addr, (page-1)*limit, limit)
+	default:
+		return nil, errors.New("not found match path")
+	}
+
+	return result, nil
+}
@@ -1,13 +1,16 @@
 package approve
 
 import (
+	"fmt"
 	bacc "github.com/QOSGroup/qbase/account"
 	"github.com/QOSGroup/qbase/context"
 	"github.com/QOSGroup/qbase/txs"
 	btypes "github.com/QOSGroup/qbase/types"
 	approvetypes "github.com/QOSGroup/qos/module/approve/types"
 	"github.com/QOSGroup/qos/module/qsc"
+	transfertypes "github.com/QOSGroup/qos/module/transfer/types"
 	"github.com/QOSGroup/qos/types"
+	"time"
 )
 
 // 创建授权
@@ -28,6 +31,11 @@ func (tx TxCreateApprove) ValidateData(ctx context.Context) error {
 		return ErrApproveExists(DefaultCodeSpace, "")
 	}
 
+	// 过期时间、高度必须晚于当前区块
+	if tx.IsExpired(ctx.BlockHeader().Time.UTC(), uint64(ctx.BlockHeight())) {
+		return ErrInvalidInput(DefaultCodeSpace, "expire time or expire height has passed")
+	}
+
 	return nil
 }
 
@@ -48,9 +56,15 @@ func (tx TxCreateApprove) Exec(ctx context.Context) (result btypes.Result, cross
 		accountMapper.SetAccount(toAcc)
 	}
 
-	// 创建授权
+	// 创建授权，周期使用信息由链上记录
+	approve := tx.Approve
+	approve.PeriodStart = time.Time{}
+	approve.PeriodSpent = btypes.ZeroInt()
+	if !approve.ExpireTime.IsZero() {
+		approve.ExpireTime = approve.ExpireTime.UTC()
+	}
 	mapper := ctx.Mapper(ApproveMapperName).(*ApproveMapper)
-	mapper.SaveApprove(tx.Approve)
+	mapper.SaveApprove(approve)
 
 	return
 }
@@ -60,9 +74,13 @@ func (tx TxCreateApprove) GetSigner() []btypes.Address {
 	return []btypes.Address{tx.From}
 }
 
-// Gas TODO
+// 交易gas由gasHandler按gas参数表计算
 func (tx TxCreateApprove) CalcGas() btypes.BigInt {
-	return btypes.NewInt(0)
+	return btypes.ZeroInt()
+}
+
+func (tx TxCreateApprove) GasItems() uint64 {
+	return uint64(len(tx.QSCs))
 }
 
 // Gas Payer：授权账号
@@ -81,6 +99,11 @@ func (tx TxIncreaseApprove) ValidateData(ctx context.Context) error {
 		return err
 	}
 
+	// 只修改授权数量, 过期及周期限额信息需取消后重新创建授权
+	if tx.HasLimits() {
+		return ErrInvalidInput(DefaultCodeSpace, "can not change expire or period limit when increasing approve")
+	}
+
 	// 授权必须存在
 	mapper := ctx.Mapper(ApproveMapperName).(*ApproveMapper)
 	_, exists := mapper.GetApprove(tx.From, tx.To)
@@ -111,9 +134,13 @@ func (tx TxIncreaseApprove) GetSigner() []btypes.Address {
 	return []btypes.Address{tx.From}
 }
 
-// Gas TODO
+// 交易gas由gasHandler按gas参数表计算
 func (tx TxIncreaseApprove) CalcGas() btypes.BigInt {
-	return btypes.NewInt(0)
+	return btypes.ZeroInt()
+}
+
+func (tx TxIncreaseApprove) GasItems() uint64 {
+	return uint64(len(tx.QSCs))
 }
 
 // Gas Payer：授权账号
@@ -132,6 +159,11 @@ func (tx TxDecreaseApprove) ValidateData(ctx context.Context) error {
 		return err
 	}
 
+	// 只修改授权数量, 过期及周期限额信息需取消后重新创建授权
+	if tx.HasLimits() {
+		return ErrInvalidInput(DefaultCodeSpace, "can not change expire or period limit when decreasing approve")
+	}
+
 	// 授权必须存在
 	mapper := ctx.Mapper(ApproveMapperName).(*ApproveMapper)
 	approve, exists := mapper.GetApprove(tx.From, tx.To)
@@ -166,9 +198,13 @@ func (tx TxDecreaseApprove) GetSigner() []btypes.Address {
 	return []btypes.Address{tx.From}
 }
 
-// Gas TODO
+// 交易gas由gasHandler按gas参数表计算
 func (tx TxDecreaseApprove) CalcGas() btypes.BigInt {
-	return btypes.NewInt(0)
+	return btypes.ZeroInt()
+}
+
+func (tx TxDecreaseApprove) GasItems() uint64 {
+	return uint64(len(tx.QSCs))
 }
 
 // Gas Payer：授权账号
@@ -187,71 +223,141 @@ func (tx TxUseApprove) ValidateData(ctx context.Context) error {
 		return err
 	}
 
-	// 校验授权信息
-	approveMapper := ctx.Mapper(ApproveMapperName).(*ApproveMapper)
-	approve, exisit := approveMapper.GetApprove(tx.From, tx.To)
-	if !exisit {
-		return ErrApproveNotExists(DefaultCodeSpace, "")
-	}
-	if !approve.IsGTE(tx.QOS, tx.QSCs) {
-		return ErrApproveNotEnough(DefaultCodeSpace, "")
+	return validateUseApprove(ctx, tx.Approve)
+}
+
+func (tx TxUseApprove) Exec(ctx context.Context) (result btypes.Result, crossTxQcps *txs.TxQcp) {
+	result = btypes.Result{
+		Code: btypes.CodeOK,
 	}
 
-	// 校验授权用户状态
+	// 扣减授权及授权账户
+	useApprove(ctx, tx.Approve)
+
+	// 更新被授权账户
 	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
-	iAcc := accountMapper.GetAccount(tx.From)
-	if iAcc == nil {
-		return ErrFromAccountNotExists(DefaultCodeSpace, "")
+	toAcc := accountMapper.GetAccount(tx.To)
+	to, _ := types.ToQOSAccount(toAcc)
+	to.MustPlus(tx.QOS, tx.QSCs)
+	accountMapper.SetAccount(toAcc)
+
+	return
+}
+
+// 签名账号：被授权账户
+func (tx TxUseApprove) GetSigner() []btypes.Address {
+	return []btypes.Address{tx.To}
+}
+
+// 交易gas由gasHandler按gas参数表计算
+func (tx TxUseApprove) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx TxUseApprove) GasItems() uint64 {
+	return uint64(len(tx.QSCs))
+}
+
+// Gas Payer：被授权账户
+func (tx TxUseApprove) GetGasPayer() btypes.Address {
+	return tx.To
+}
+
+// 使用授权向第三方支付：被授权账户使用From账户的预授权，直接向Receivers转账
+type TxTransferFromApprove struct {
+	From      btypes.Address           `json:"from"`      // 授权账号
+	To        btypes.Address           `json:"to"`        // 被授权账号
+	Receivers transfertypes.TransItems `json:"receivers"` // 接收集合
+}
+
+func NewTransferFromApproveTx(from, to btypes.Address, receivers transfertypes.TransItems) *TxTransferFromApprove {
+	return &TxTransferFromApprove{
+		From:      from,
+		To:        to,
+		Receivers: receivers,
 	}
-	from := iAcc.(*types.QOSAccount)
-	if tx.IsGT(from.QOS, from.QSCs) {
-		return ErrFromAccountCoinsNotEnough(DefaultCodeSpace, "")
+}
+
+// 接收总量，即使用的授权
+func (tx TxTransferFromApprove) totalApprove() approvetypes.Approve {
+	qos := btypes.ZeroInt()
+	qscs := types.QSCs{}
+	for _, receiver := range tx.Receivers {
+		qos = qos.Add(receiver.QOS.NilToZero())
+		qscs = qscs.Plus(receiver.QSCs)
 	}
 
-	return nil
+	return approvetypes.NewApprove(tx.From, tx.To, qos, qscs)
 }
 
-func (tx TxUseApprove) Exec(ctx context.Context) (result btypes.Result, crossTxQcps *txs.TxQcp) {
-	result = btypes.Result{
-		Code: btypes.CodeOK,
+func (tx TxTransferFromApprove) ValidateData(ctx context.Context) error {
+	if valid, err := tx.Receivers.IsValid(); !valid {
+		return ErrInvalidInput(DefaultCodeSpace, err.Error())
 	}
 
-	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
-	from := accountMapper.GetAccount(tx.From).(*types.QOSAccount)
-	to := accountMapper.GetAccount(tx.To).(*types.QOSAccount)
+	use := tx.totalApprove()
+	err := validateData(ctx, use)
+	if err != nil {
+		return err
+	}
 
-	approveMapper := ctx.Mapper(ApproveMapperName).(*ApproveMapper)
-	approve, _ := approveMapper.GetApprove(tx.From, tx.To)
+	return validateUseApprove(ctx, use)
+}
 
-	// 更新授权用户状态
-	from.MustMinus(tx.QOS, tx.QSCs)
-	accountMapper.SetAccount(from)
+func (tx TxTransferFromApprove) Exec(ctx context.Context) (result btypes.Result, crossTxQcps *txs.TxQcp) {
+	result = btypes.Result{
+		Code: btypes.CodeOK,
+	}
 
-	// 更新被授权账户
-	to.MustPlus(tx.QOS, tx.QSCs)
-	accountMapper.SetAccount(to)
+	// 扣减授权及授权账户
+	useApprove(ctx, tx.totalApprove())
 
-	// 保存更新
-	approveMapper.SaveApprove(approve.Minus(tx.QOS, tx.QSCs))
+	// 更新接收账户
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	for _, receiver := range tx.Receivers {
+		a := accountMapper.GetAccount(receiver.Address)
+		if a == nil {
+			a = types.NewQOSAccountWithAddress(receiver.Address)
+		}
+		acc, _ := types.ToQOSAccount(a)
+		acc.MustPlus(receiver.QOS, receiver.QSCs)
+		accountMapper.SetAccount(a)
+	}
 
 	return
 }
 
 // 签名账号：被授权账户
-func (tx TxUseApprove) GetSigner() []btypes.Address {
+func (tx TxTransferFromApprove) GetSigner() []btypes.Address {
 	return []btypes.Address{tx.To}
 }
 
-// Gas TODO
-func (tx TxUseApprove) CalcGas() btypes.BigInt {
-	return btypes.NewInt(0)
+func (tx TxTransferFromApprove) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx TxTransferFromApprove) GasItems() uint64 {
+	return uint64(len(tx.Receivers))
 }
 
 // Gas Payer：被授权账户
-func (tx TxUseApprove) GetGasPayer() btypes.Address {
+func (tx TxTransferFromApprove) GetGasPayer() btypes.Address {
 	return tx.To
 }
 
+// 签名字节
+func (tx TxTransferFromApprove) GetSignData() (ret []byte) {
+	ret = append(ret, tx.From...)
+	ret = append(ret, tx.To...)
+	for _, receiver := range tx.Receivers {
+		ret = append(ret, receiver.Address...)
+		ret = append(ret, (receiver.QOS.NilToZero()).String()...)
+		ret = append(ret, receiver.QSCs.String()...)
+	}
+
+	return ret
+}
+
 // 取消授权 Tx
 type TxCancelApprove struct {
 	From btypes.Address `json:"from"` // 授权账号
@@ -289,9 +395,13 @@ func (tx TxCancelApprove) GetSigner() []btypes.Address {
 	return []btypes.Address{tx.From}
 }
 
-// Gas TODO
+// 交易gas由gasHandler按gas参数表计算
 func (tx TxCancelApprove) CalcGas() btypes.BigInt {
-	return btypes.NewInt(0)
+	return btypes.ZeroInt()
+}
+
+func (tx TxCancelApprove) GasItems() uint64 {
+	return 0
 }
 
 // Gas Payer：被授权账号
@@ -307,6 +417,59 @@ func (tx TxCancelApprove) GetSignData() (ret []byte) {
 	return ret
 }
 
+// 校验授权及授权账户能否支付use中的QOS、QSCs
+func validateUseApprove(ctx context.Context, use approvetypes.Approve) error {
+	// 校验授权信息
+	approveMapper := ctx.Mapper(ApproveMapperName).(*ApproveMapper)
+	approve, exisit := approveMapper.GetApprove(use.From, use.To)
+	if !exisit {
+		return ErrApproveNotExists(DefaultCodeSpace, "")
+	}
+	if !approve.IsGTE(use.QOS, use.QSCs) {
+		return ErrApproveNotEnough(DefaultCodeSpace, "")
+	}
+	blockTime := ctx.BlockHeader().Time.UTC()
+	if approve.IsExpired(blockTime, uint64(ctx.BlockHeight())) {
+		return ErrApproveExpired(DefaultCodeSpace, "")
+	}
+	if remaining, limited := approve.PeriodRemaining(blockTime); limited && remaining.LT(use.QOS.NilToZero()) {
+		return ErrApprovePeriodLimit(DefaultCodeSpace, fmt.Sprintf("remaining in current period: %s", remaining))
+	}
+
+	// 校验授权用户状态
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	iAcc := accountMapper.GetAccount(use.From)
+	if iAcc == nil {
+		return ErrFromAccountNotExists(DefaultCodeSpace, "")
+	}
+	from, _ := types.ToQOSAccount(iAcc)
+	if use.IsGT(from.QOS, from.QSCs) {
+		return ErrFromAccountCoinsNotEnough(DefaultCodeSpace, "")
+	}
+	if types.SpendableQOS(iAcc, blockTime).LT(use.QOS.NilToZero()) {
+		return ErrFromAccountCoinsNotEnough(DefaultCodeSpace, "vesting QOS cannot be used")
+	}
+	if err := qsc.ValidateNotFrozen(ctx, use.From, use.QSCs); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// 扣减授权及授权账户中use的QOS、QSCs
+func useApprove(ctx context.Context, use approvetypes.Approve) {
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	fromAcc := accountMapper.GetAccount(use.From)
+	from, _ := types.ToQOSAccount(fromAcc)
+	from.MustMinus(use.QOS, use.QSCs)
+	accountMapper.SetAccount(fromAcc)
+
+	approveMapper := ctx.Mapper(ApproveMapperName).(*ApproveMapper)
+	approve, _ := approveMapper.GetApprove(use.From, use.To)
+	approve = approve.Minus(use.QOS, use.QSCs).SpendInPeriod(ctx.BlockHeader().Time.UTC(), use.QOS)
+	approveMapper.SaveApprove(approve)
+}
+
 // 基础数据校验
 func validateData(ctx context.Context, msg approvetypes.Approve) error {
 	if valid, err := msg.IsValid(); !valid {
@@ -7,8 +7,10 @@ import (
 	"github.com/QOSGroup/qbase/store"
 	btypes "github.com/QOSGroup/qbase/types"
 	approvetype "github.com/QOSGroup/qos/module/approve/types"
+	ecotypes "github.com/QOSGroup/qos/module/eco/types"
 	"github.com/QOSGroup/qos/module/qsc"
 	qsctype "github.com/QOSGroup/qos/module/qsc/types"
+	transfertypes "github.com/QOSGroup/qos/module/transfer/types"
 	"github.com/QOSGroup/qos/types"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -16,6 +18,7 @@ import (
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 	"testing"
+	"time"
 )
 
 var testFromAddr = btypes.Address(ed25519.GenPrivKey().PubKey().Address())
@@ -168,6 +171,14 @@ func TestTxApproveIncrease_ValidateData(t *testing.T) {
 	approveMapper.SaveApprove(createTx.Approve)
 
 	require.Nil(t, increaseTx.ValidateData(ctx))
+
+	// 不可修改过期及周期限额信息
+	increaseTx.ExpireHeight = 100
+	require.NotNil(t, increaseTx.ValidateData(ctx))
+	increaseTx.ExpireHeight = 0
+	increaseTx.PeriodLimit = btypes.NewInt(10)
+	increaseTx.PeriodSecs = 60
+	require.NotNil(t, increaseTx.ValidateData(ctx))
 }
 
 func TestTxApproveIncrease_Exec(t *testing.T) {
@@ -213,6 +224,11 @@ func TestTxApproveDecrease_ValidateData(t *testing.T) {
 
 	decreaseTx.QOS = btypes.NewInt(110)
 	require.NotNil(t, decreaseTx.ValidateData(ctx))
+
+	// 不可修改过期及周期限额信息
+	decreaseTx.QOS = btypes.NewInt(100)
+	decreaseTx.ExpireTime = time.Now().Add(time.Hour)
+	require.NotNil(t, decreaseTx.ValidateData(ctx))
 }
 
 func TestTxApproveDecrease_Exec(t *testing.T) {
@@ -257,6 +273,13 @@ func TestTxApproveUse_ValidateData(t *testing.T) {
 
 	require.Nil(t, useTx.ValidateData(ctx))
 
+	// 授权账户QSC冻结后不可使用
+	qscMapper := ctx.Mapper(qsc.QSCMapperName).(*qsc.QSCMapper)
+	qscMapper.FreezeAccount("qstar", useTx.From)
+	require.NotNil(t, useTx.ValidateData(ctx))
+	qscMapper.UnfreezeAccount("qstar", useTx.From)
+	require.Nil(t, useTx.ValidateData(ctx))
+
 	useTx.QOS = btypes.NewInt(110)
 	require.NotNil(t, useTx.ValidateData(ctx))
 
@@ -349,7 +372,8 @@ func TestTxApproveCancel_GetGasPayer(t *testing.T) {
 
 func TestTxApproveCancel_CalcGas(t *testing.T) {
 	cancelTx := genApproveCancelTx()
-	require.Equal(t, cancelTx.CalcGas(), btypes.NewInt(0))
+	// base 200 + 1个签名账户 100 + 签名数据字节数
+	require.Equal(t, uint64(300+len(cancelTx.GetSignData())), ecotypes.DefaultGasSchedule().CalcTxGas(cancelTx))
 }
 
 func TestTxApproveCancel_GetSignData(t *testing.T) {
@@ -359,3 +383,96 @@ func TestTxApproveCancel_GetSignData(t *testing.T) {
 	ret = append(ret, cancelTx.To...)
 	require.Equal(t, cancelTx.GetSignData(), ret)
 }
+
+func TestTxApproveUse_ExpireAndPeriodLimit(t *testing.T) {
+	blockTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := defaultContextWithQSC().WithBlockHeight(10).WithBlockHeader(abci.Header{Height: 10, Time: blockTime})
+
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	accountMapper.SetAccount(genTestAccount(testFromAddr))
+	accountMapper.SetAccount(genTestAccount(testToAddr))
+
+	//过期时间不能早于当前区块
+	appr := approvetype.NewApprove(testFromAddr, testToAddr, btypes.NewInt(100), nil)
+	appr.ExpireTime = blockTime
+	require.NotNil(t, TxCreateApprove{appr}.ValidateData(ctx))
+
+	//周期限额需设置周期
+	appr.ExpireTime = blockTime.Add(72 * time.Hour)
+	appr.PeriodLimit = btypes.NewInt(30)
+	require.NotNil(t, TxCreateApprove{appr}.ValidateData(ctx))
+
+	appr.PeriodSecs = 86400
+	createTx := TxCreateApprove{appr}
+	require.Nil(t, createTx.ValidateData(ctx))
+	result, _ := createTx.Exec(ctx)
+	require.True(t, result.IsOK())
+
+	useTx := TxUseApprove{approvetype.NewApprove(testFromAddr, testToAddr, btypes.NewInt(20), nil)}
+	require.Nil(t, useTx.ValidateData(ctx))
+	result, _ = useTx.Exec(ctx)
+	require.True(t, result.IsOK())
+
+	//超出当前周期限额
+	require.NotNil(t, useTx.ValidateData(ctx))
+
+	//新周期限额重置
+	ctx = ctx.WithBlockHeader(abci.Header{Height: 20, Time: blockTime.Add(24 * time.Hour)})
+	require.Nil(t, useTx.ValidateData(ctx))
+	result, _ = useTx.Exec(ctx)
+	require.True(t, result.IsOK())
+
+	approveMapper := ctx.Mapper(ApproveMapperName).(*ApproveMapper)
+	approve, _ := approveMapper.GetApprove(testFromAddr, testToAddr)
+	require.True(t, approve.QOS.Equal(btypes.NewInt(60)))
+	require.True(t, approve.PeriodSpent.Equal(btypes.NewInt(20)))
+	require.Equal(t, blockTime.Add(24*time.Hour), approve.PeriodStart)
+	require.Equal(t, blockTime.Add(72*time.Hour), approve.ExpireTime)
+
+	//已过期
+	ctx = ctx.WithBlockHeader(abci.Header{Height: 30, Time: blockTime.Add(72 * time.Hour)})
+	require.NotNil(t, useTx.ValidateData(ctx))
+}
+
+func TestTxTransferFromApprove(t *testing.T) {
+	ctx := defaultContextWithQSC()
+
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	accountMapper.SetAccount(genTestAccount(testFromAddr))
+	accountMapper.SetAccount(genTestAccount(testToAddr))
+
+	merchant := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	receivers := transfertypes.TransItems{
+		{Address: merchant, QOS: btypes.NewInt(30), QSCs: types.QSCs{{Name: "qstar", Amount: btypes.NewInt(10)}}},
+		{Address: testToAddr, QOS: btypes.NewInt(20)},
+	}
+	tx := NewTransferFromApproveTx(testFromAddr, testToAddr, receivers)
+	require.Equal(t, []btypes.Address{testToAddr}, tx.GetSigner())
+
+	//授权不存在
+	require.NotNil(t, tx.ValidateData(ctx))
+
+	approveMapper := ctx.Mapper(ApproveMapperName).(*ApproveMapper)
+	approveMapper.SaveApprove(approvetype.NewApprove(testFromAddr, testToAddr, btypes.NewInt(40), types.QSCs{{Name: "qstar", Amount: btypes.NewInt(100)}}))
+
+	//授权不足
+	require.NotNil(t, tx.ValidateData(ctx))
+
+	approveMapper.SaveApprove(genTestApprove())
+	require.Nil(t, tx.ValidateData(ctx))
+	result, _ := tx.Exec(ctx)
+	require.True(t, result.IsOK())
+
+	approve, _ := approveMapper.GetApprove(testFromAddr, testToAddr)
+	require.True(t, approve.QOS.Equal(btypes.NewInt(50)))
+	require.True(t, approve.QSCs.AmountOf("qstar").Equal(btypes.NewInt(90)))
+
+	from, _ := types.ToQOSAccount(accountMapper.GetAccount(testFromAddr))
+	require.True(t, from.QOS.Equal(btypes.NewInt(50)))
+	require.True(t, from.QSCs.AmountOf("qstar").Equal(btypes.NewInt(90)))
+	to, _ := types.ToQOSAccount(accountMapper.GetAccount(testToAddr))
+	require.True(t, to.QOS.Equal(btypes.NewInt(120)))
+	m, _ := types.ToQOSAccount(accountMapper.GetAccount(merchant))
+	require.True(t, m.QOS.Equal(btypes.NewInt(30)))
+	require.True(t, m.QSCs.AmountOf("qstar").Equal(btypes.NewInt(10)))
+}
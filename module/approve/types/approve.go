@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 	"sort"
 	"strings"
+	"time"
 )
 
 // 授权 Common 结构
@@ -16,6 +17,13 @@ type Approve struct {
 	To   btypes.Address `json:"to"`   // 被授权账号
 	QOS  btypes.BigInt  `json:"qos"`  // QOS
 	QSCs types.QSCs     `json:"qscs"` // QSCs
+
+	ExpireTime   time.Time     `json:"expire_time"`   // 过期时间，为空时不过期
+	ExpireHeight uint64        `json:"expire_height"` // 过期高度，为0时不过期
+	PeriodLimit  btypes.BigInt `json:"period_limit"`  // 每周期可使用的QOS上限，为空时不限制
+	PeriodSecs   uint64        `json:"period_secs"`   // 周期时长，单位秒
+	PeriodStart  time.Time     `json:"period_start"`  // 当前周期开始时间
+	PeriodSpent  btypes.BigInt `json:"period_spent"`  // 当前周期已使用的QOS
 }
 
 func NewApprove(from btypes.Address, to btypes.Address, qos btypes.BigInt, qscs types.QSCs) Approve {
@@ -49,6 +57,15 @@ func (approve Approve) IsValid() (bool, error) {
 		}
 	}
 
+	if approve.HasPeriodLimit() {
+		if approve.PeriodLimit.LT(btypes.ZeroInt()) {
+			return false, errors.New("period limit is negative")
+		}
+		if approve.PeriodSecs == 0 {
+			return false, errors.New("period secs is zero while period limit is set")
+		}
+	}
+
 	return true, nil
 }
 
@@ -63,6 +80,16 @@ func (approve Approve) GetSignData() (ret []byte) {
 		ret = append(ret, []byte(coin.Name)...)
 		ret = append(ret, []byte(coin.Amount.String())...)
 	}
+	if !approve.ExpireTime.IsZero() {
+		ret = append(ret, approve.ExpireTime.UTC().String()...)
+	}
+	if approve.ExpireHeight > 0 {
+		ret = append(ret, fmt.Sprintf("%d", approve.ExpireHeight)...)
+	}
+	if approve.HasPeriodLimit() {
+		ret = append(ret, approve.PeriodLimit.String()...)
+		ret = append(ret, fmt.Sprintf("%d", approve.PeriodSecs)...)
+	}
 
 	return ret
 }
@@ -89,24 +116,93 @@ func (approve Approve) IsNotNegative() bool {
 	return approve.QSCs.IsNotNegative()
 }
 
-// 返回相反值
+// 返回相反值，过期及周期限额信息不变
 func (approve Approve) Negative() Approve {
+	approve.QOS = approve.QOS.NilToZero().Neg()
+	approve.QSCs = approve.QSCs.Negative()
 
-	return NewApprove(approve.From, approve.To, approve.QOS.Neg(), approve.QSCs.Negative())
+	return approve
 }
 
-// Plus
+// Plus，过期及周期限额信息不变
 func (approve Approve) Plus(qos btypes.BigInt, qscs types.QSCs) Approve {
+	approve.QOS = approve.QOS.NilToZero().Add(qos.NilToZero())
+	approve.QSCs = approve.QSCs.Plus(qscs)
 
-	return NewApprove(approve.From, approve.To, approve.QOS.Add(qos.NilToZero()), approve.QSCs.Plus(qscs))
+	return approve
 }
 
-// Minus
+// Minus，过期及周期限额信息不变
 func (approve Approve) Minus(qos btypes.BigInt, qscs types.QSCs) Approve {
-	approve.QOS = approve.QOS.NilToZero()
-	qos = qos.NilToZero()
+	approve.QOS = approve.QOS.NilToZero().Add(qos.NilToZero().Neg())
+	approve.QSCs = approve.QSCs.Minus(qscs)
+
+	return approve
+}
+
+// 是否设置过期时间或高度
+func (approve Approve) HasExpiry() bool {
+	return !approve.ExpireTime.IsZero() || approve.ExpireHeight > 0
+}
+
+// blockTime、height时是否已过期
+func (approve Approve) IsExpired(blockTime time.Time, height uint64) bool {
+	if !approve.ExpireTime.IsZero() && !blockTime.Before(approve.ExpireTime) {
+		return true
+	}
+	return approve.ExpireHeight > 0 && height >= approve.ExpireHeight
+}
+
+// 是否设置周期限额
+func (approve Approve) HasPeriodLimit() bool {
+	return !approve.PeriodLimit.IsNil() && !approve.PeriodLimit.IsZero()
+}
+
+// 是否设置了过期或周期限额信息
+func (approve Approve) HasLimits() bool {
+	return !approve.ExpireTime.IsZero() || approve.ExpireHeight > 0 || approve.HasPeriodLimit() || approve.PeriodSecs > 0 ||
+		!approve.PeriodStart.IsZero() || !(approve.PeriodSpent.IsNil() || approve.PeriodSpent.IsZero())
+}
+
+// blockTime是否仍在当前周期内
+func (approve Approve) inPeriod(blockTime time.Time) bool {
+	if approve.PeriodStart.IsZero() {
+		return false
+	}
+	return blockTime.Before(approve.PeriodStart.Add(time.Duration(approve.PeriodSecs) * time.Second))
+}
+
+// blockTime时当前周期剩余可使用的QOS，未设置周期限额时返回false
+func (approve Approve) PeriodRemaining(blockTime time.Time) (btypes.BigInt, bool) {
+	if !approve.HasPeriodLimit() {
+		return btypes.ZeroInt(), false
+	}
+
+	spent := btypes.ZeroInt()
+	if approve.inPeriod(blockTime) {
+		spent = approve.PeriodSpent.NilToZero()
+	}
+	remaining := approve.PeriodLimit.Sub(spent)
+	if remaining.LT(btypes.ZeroInt()) {
+		return btypes.ZeroInt(), true
+	}
+
+	return remaining, true
+}
+
+// 记录blockTime时使用的QOS，周期结束后自动开始新周期
+func (approve Approve) SpendInPeriod(blockTime time.Time, qos btypes.BigInt) Approve {
+	if !approve.HasPeriodLimit() {
+		return approve
+	}
+
+	if !approve.inPeriod(blockTime) {
+		approve.PeriodStart = blockTime.UTC()
+		approve.PeriodSpent = btypes.ZeroInt()
+	}
+	approve.PeriodSpent = approve.PeriodSpent.NilToZero().Add(qos.NilToZero())
 
-	return NewApprove(approve.From, approve.To, approve.QOS.Add(qos.Neg()), approve.QSCs.Minus(qscs))
+	return approve
 }
 
 // 是否大于等于
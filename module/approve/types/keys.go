@@ -0,0 +1,38 @@
+package types
+
+import (
+	"fmt"
+
+	btypes "github.com/QOSGroup/qbase/types"
+)
+
+const (
+	//------query-------
+	ApproveRoute  = "approve"
+	QueryApprove  = "approve"
+	QueryApproves = "approves"
+	QueryFrom     = "from"
+	QueryTo       = "to"
+
+	DefaultQueryLimit = 100 // 分页查询默认每页数量
+)
+
+// 授权查询结果
+type ApprovesQueryResult struct {
+	Total    uint64    `json:"total"`    // 授权总数
+	Approves []Approve `json:"approves"` // 当前页授权
+}
+
+func BuildQueryApproveCustomQueryPath(from, to btypes.Address) string {
+	return fmt.Sprintf("custom/%s/%s/%s/%s", ApproveRoute, QueryApprove, from.String(), to.String())
+}
+
+// 查询from创建的授权, page从1开始
+func BuildQueryApprovesByFromCustomQueryPath(from btypes.Address, page, limit uint64) string {
+	return fmt.Sprintf("custom/%s/%s/%s/%s/%d/%d", ApproveRoute, QueryApproves, QueryFrom, from.String(), page, limit)
+}
+
+// 查询to获得的授权, page从1开始
+func BuildQueryApprovesByToCustomQueryPath(to btypes.Address, page, limit uint64) string {
+	return fmt.Sprintf("custom/%s/%s/%s/%s/%d/%d", ApproveRoute, QueryApproves, QueryTo, to.String(), page, limit)
+}
@@ -2,7 +2,9 @@ package module
 
 import (
 	"github.com/QOSGroup/qos/module/approve"
+	"github.com/QOSGroup/qos/module/distribution"
 	"github.com/QOSGroup/qos/module/eco"
+	"github.com/QOSGroup/qos/module/gov"
 	"github.com/QOSGroup/qos/module/qcp"
 	"github.com/QOSGroup/qos/module/qsc"
 	"github.com/QOSGroup/qos/module/stake"
@@ -17,4 +19,6 @@ func RegisterCodec(cdc *amino.Codec) {
 	stake.RegisterCodec(cdc)
 	qcp.RegisterCodec(cdc)
 	eco.RegisterCodec(cdc)
+	gov.RegisterCodec(cdc)
+	distribution.RegisterCodec(cdc)
 }
@@ -7,6 +7,8 @@ import (
 	"github.com/QOSGroup/qos/module/eco"
 	"github.com/QOSGroup/qos/module/eco/mapper"
 	"github.com/QOSGroup/qos/module/eco/types"
+	"github.com/QOSGroup/qos/module/supply"
+	supplytypes "github.com/QOSGroup/qos/module/supply/types"
 	qtypes "github.com/QOSGroup/qos/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
@@ -83,7 +85,7 @@ func distributeEarningByValidator(e eco.Eco, valAddr btypes.Address, delegators
 		//validator不存在时, 获取delegator当前收益信息, 将收益直接返还账户中,并删除当前delegator信息
 		for _, deleAddr := range delegators {
 			if info, _exsits := e.DistributionMapper.GetDelegatorEarningStartInfo(valAddr, deleAddr); _exsits {
-				eco.IncrAccountQOS(e.Context, deleAddr, info.HistoricalRewardFees.NilToZero())
+				payRewards(e, deleAddr, info.HistoricalRewardFees.NilToZero())
 				e.DistributionMapper.DelDelegatorEarningStartInfo(valAddr, deleAddr)
 				e.DelegationMapper.DelDelegationInfo(deleAddr, valAddr)
 			}
@@ -92,7 +94,7 @@ func distributeEarningByValidator(e eco.Eco, valAddr btypes.Address, delegators
 	}
 
 	//1. validator汇总收益增加计费周期
-	endPeriod := e.DistributionMapper.IncrementValidatorPeriod(validator)
+	endPeriod := e.IncrementValidatorPeriod(validator)
 
 	//2. 处理delegator收益信息
 	for _, deleAddr := range delegators {
@@ -110,9 +112,20 @@ func distributeEarningByValidator(e eco.Eco, valAddr btypes.Address, delegators
 		updatedTokens := validator.BondTokens + addCompoundTokens
 		log.Debug("validator incr tokens", "validator", valAddr.String(), "addCompoundTokens", addCompoundTokens, "updatedTokens", updatedTokens)
 		e.ValidatorMapper.ChangeValidatorBondTokens(validator, updatedTokens)
+		supply.GetSupplyMapper(e.Context).MoveLockedQOS(supplytypes.Rewards, supplytypes.Bonded, btypes.NewInt(int64(addCompoundTokens)))
 	}
 }
 
+//收益发放至delegator收益提取地址
+func payRewards(e eco.Eco, deleAddr btypes.Address, rewards btypes.BigInt) error {
+	if err := eco.IncrAccountQOS(e.Context, e.DistributionMapper.GetDelegatorWithdrawAddress(deleAddr), rewards); err != nil {
+		return err
+	}
+	supply.GetSupplyMapper(e.Context).UnlockQOS(supplytypes.Rewards, rewards)
+
+	return nil
+}
+
 func distributeDelegatorEarning(e eco.Eco, validator types.Validator, endPeriod uint64, deleAddr btypes.Address, blockHeight, periodHeightParam uint64) uint64 {
 
 	valAddr := validator.GetValidatorAddress()
@@ -131,7 +144,7 @@ func distributeDelegatorEarning(e eco.Eco, validator types.Validator, endPeriod
 	if !exsits || delegationInfo.Amount == 0 {
 		//已无委托关系,收益直接分配到delegator账户中
 		log.Debug("delegation not exsits. rewards to account", "rewards", rewards)
-		eco.IncrAccountQOS(e.Context, deleAddr, rewards.NilToZero())
+		payRewards(e, deleAddr, rewards.NilToZero())
 		e.DistributionMapper.DelDelegatorEarningStartInfo(valAddr, deleAddr)
 		e.DelegationMapper.DelDelegationInfo(deleAddr, valAddr)
 		return 0
@@ -144,7 +157,7 @@ func distributeDelegatorEarning(e eco.Eco, validator types.Validator, endPeriod
 	//非复投,收益直接分配到delegator账户中
 	if !delegationInfo.IsCompound {
 		log.Debug("delegation is not compound. rewards to delegator account", "rewards", rewards)
-		eco.IncrAccountQOS(e.Context, deleAddr, rewards.NilToZero())
+		payRewards(e, deleAddr, rewards.NilToZero())
 		return 0
 	}
 
@@ -186,6 +199,7 @@ func allocateQOS(ctx context.Context, signedTotalPower, totalPower int64, propos
 	log.Debug("total rewards", "total rewards", totalAmount, "height", ctx.BlockHeight())
 	//proposer奖励,直接归属proposer
 	proposerRewards := params.ProposerRewardRate.MultiBigInt(totalAmount)
+	proposerAddr = e.ValidatorMapper.GetLatestValidatorAddress(proposerAddr)
 	proposerValidater, exsits := e.ValidatorMapper.GetValidator(proposerAddr)
 	if !exsits {
 		log.Error("proposer validator not exsits", "proposer", proposerAddr)
@@ -205,7 +219,7 @@ func allocateQOS(ctx context.Context, signedTotalPower, totalPower int64, propos
 		rewards := votePowerFrac.Mul(votePercent).MultiBigInt(totalAmount)
 		log.Debug("reward validator", "validator", btypes.Address(vote.Validator.Address).String(), "power", vote.Validator.Power, "total rewards", rewards)
 		remainQOS = remainQOS.Sub(rewards)
-		rewardToValidator(e, vote.Validator.Address, rewards, params.ValidatorCommissionRate)
+		rewardToValidator(e, e.ValidatorMapper.GetLatestValidatorAddress(vote.Validator.Address), rewards)
 	}
 
 	//社区奖励
@@ -213,21 +227,26 @@ func allocateQOS(ctx context.Context, signedTotalPower, totalPower int64, propos
 	communityFeePool = communityFeePool.Add(remainQOS)
 	log.Debug("reward community", "rewards", remainQOS)
 	e.DistributionMapper.SetCommunityFeePool(communityFeePool)
+
+	supplyMapper := supply.GetSupplyMapper(ctx)
+	supplyMapper.MoveLockedQOS(supplytypes.PreDistribution, supplytypes.Rewards, totalAmount.Sub(remainQOS))
+	supplyMapper.MoveLockedQOS(supplytypes.PreDistribution, supplytypes.CommunityPool, remainQOS)
 }
 
-func rewardToValidator(e eco.Eco, valAddr btypes.Address, rewards btypes.BigInt, commissionRate qtypes.Fraction) {
+func rewardToValidator(e eco.Eco, valAddr btypes.Address, rewards btypes.BigInt) {
 
 	log := e.Context.Logger()
 
-	commissionReward := commissionRate.MultiBigInt(rewards)
-	sharedReward := rewards.Sub(commissionReward)
-
 	validator, exsits := e.ValidatorMapper.GetValidator(valAddr)
 	if !exsits {
 		log.Error("reward validator, validator not exsits", "validator", valAddr.String())
 		return
 	}
 
+	//按validator自行设置的佣金比例计算佣金
+	commissionReward := validator.Commission.Rate.MultiBigInt(rewards)
+	sharedReward := rewards.Sub(commissionReward)
+
 	//validator 佣金收益
 	if info, exsits := e.DistributionMapper.GetDelegatorEarningStartInfo(valAddr, validator.Owner); exsits {
 		info.HistoricalRewardFees = info.HistoricalRewardFees.Add(commissionReward)
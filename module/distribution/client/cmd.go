@@ -6,10 +6,20 @@ import (
 	amino "github.com/tendermint/go-amino"
 )
 
+func TxCommands(cdc *amino.Codec) []*cobra.Command {
+	return bctypes.PostCommands(
+		CommunityPoolSpendCmd(cdc),
+		WithdrawDelegatorRewardCmd(cdc),
+		SetWithdrawAddressCmd(cdc),
+	)
+}
+
 func QueryCommands(cdc *amino.Codec) []*cobra.Command {
 	return bctypes.GetCommands(
 		queryValidatorPeriodCommand(cdc),
 		queryDelegatorIncomeInfoCommand(cdc),
 		queryCommunityFeePoolCommand(cdc),
+		queryCommunityPoolSpendsCommand(cdc),
+		queryWithdrawAddressCommand(cdc),
 	)
 }
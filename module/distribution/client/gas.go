@@ -0,0 +1,56 @@
+package distribution
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/QOSGroup/qbase/client/context"
+	bctypes "github.com/QOSGroup/qbase/client/types"
+	qclitx "github.com/QOSGroup/qbase/client/tx"
+	"github.com/QOSGroup/qbase/txs"
+	btypes "github.com/QOSGroup/qbase/types"
+	ecotypes "github.com/QOSGroup/qos/module/eco/types"
+	"github.com/QOSGroup/qos/types"
+	"github.com/spf13/viper"
+	"github.com/tendermint/go-amino"
+)
+
+// 广播交易并打印结果, 广播前按链上gas参数表打印交易gas、max-gas对应的出价及最多支付的QOS
+func BroadcastTxAndPrintResult(cdc *amino.Codec, txBuilder qclitx.ITxBuilder) error {
+	return qclitx.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+		itx, err := txBuilder(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if gasTx, ok := itx.(ecotypes.GasTx); ok {
+			params, err := queryDistributionParams(ctx)
+			if err != nil {
+				return nil, err
+			}
+			gas := params.GasSchedule.CalcTxGas(gasTx)
+			if gas > 0 {
+				maxGas := btypes.NewInt(viper.GetInt64(bctypes.FlagMaxGas))
+				unitGas := btypes.NewInt(int64(params.GasPerUnitCost * gas))
+				price := types.NewDecFromInt(maxGas).QuoInt(unitGas)
+				fmt.Fprintf(os.Stderr, "tx gas: %d, max-gas: %s, gas price: %s QOS/gas, max fee: %s QOS\n", gas, maxGas, price, maxGas.Mul(maxGas).Div(unitGas))
+			}
+		}
+
+		return itx, nil
+	})
+}
+
+func queryDistributionParams(ctx context.CLIContext) (ecotypes.DistributionParams, error) {
+	res, err := ctx.Query(fmt.Sprintf("/store/%s/key", ecotypes.DistributionMapperName), ecotypes.BuildDistributeParamsKey())
+	if err != nil {
+		return ecotypes.DistributionParams{}, err
+	}
+	if len(res) == 0 {
+		return ecotypes.DefaultDistributionParams(), nil
+	}
+
+	var params ecotypes.DistributionParams
+	err = ctx.Codec.UnmarshalBinaryBare(res, &params)
+	return params, err
+}
@@ -106,3 +106,54 @@ func queryCommunityFeePoolCommand(cdc *amino.Codec) *cobra.Command {
 
 	return cmd
 }
+
+func queryCommunityPoolSpendsCommand(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "community-pool-spends",
+		Short: "Query community fee pool spend history",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.Query(ecotypes.BuildQueryCommunityPoolSpendsCustomQueryPath(), []byte(""))
+			if err != nil {
+				return err
+			}
+
+			var result []ecotypes.CommunityPoolSpend
+			cliCtx.Codec.UnmarshalJSON(res, &result)
+			return cliCtx.PrintResult(result)
+		},
+	}
+
+	return cmd
+}
+
+func queryWithdrawAddressCommand(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "withdraw-address",
+		Short: "Query delegator reward withdraw address",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			delegator, err := qcliacc.GetAddrFromFlag(cliCtx, flagDelegator)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.Query(ecotypes.BuildQueryWithdrawAddressCustomQueryPath(delegator), []byte(""))
+			if err != nil {
+				return err
+			}
+
+			var result distribution.WithdrawAddressQueryResult
+			cliCtx.Codec.UnmarshalJSON(res, &result)
+			return cliCtx.PrintResult(result)
+		},
+	}
+
+	cmd.Flags().String(flagDelegator, "", "delegator address")
+	cmd.MarkFlagRequired(flagDelegator)
+	return cmd
+}
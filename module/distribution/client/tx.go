@@ -0,0 +1,166 @@
+package distribution
+
+import (
+	"errors"
+
+	qcliacc "github.com/QOSGroup/qbase/client/account"
+	"github.com/QOSGroup/qbase/client/context"
+	"github.com/QOSGroup/qbase/txs"
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/module/distribution"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/go-amino"
+)
+
+const (
+	flagApprovers = "approvers"
+	flagReceiver  = "receiver"
+	flagAmount    = "amount"
+	flagMemo      = "memo"
+	flagAll       = "all"
+	flagWithdraw  = "withdraw-address"
+)
+
+func CommunityPoolSpendCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "community-pool-spend",
+		Short: "Spend QOS from community fee pool, signed by community pool spenders",
+		Long: `
+approvers: keystore names or account addresses of community pool spenders, the number of approvers must reach community_pool_spend_threshold.
+
+example:
+
+	 qoscli tx community-pool-spend --approvers spender1,spender2 --receiver address1xxx --amount 100 --memo "memo"
+
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+				values := viper.GetStringSlice(flagApprovers)
+				if len(values) == 0 {
+					return nil, errors.New("approvers is empty")
+				}
+				approvers := make([]btypes.Address, 0, len(values))
+				for _, value := range values {
+					approver, err := qcliacc.GetAddrFromValue(ctx, value)
+					if err != nil {
+						return nil, err
+					}
+					approvers = append(approvers, approver)
+				}
+
+				receiver, err := qcliacc.GetAddrFromFlag(ctx, flagReceiver)
+				if err != nil {
+					return nil, err
+				}
+
+				amount := viper.GetInt64(flagAmount)
+				if amount <= 0 {
+					return nil, errors.New("amount lte zero")
+				}
+
+				memo := viper.GetString(flagMemo)
+				if len(memo) > distribution.MaxMemoLen {
+					return nil, errors.New("memo is too long")
+				}
+
+				return distribution.NewCommunityPoolSpendTx(approvers, receiver, uint64(amount), memo), nil
+			})
+		},
+	}
+
+	cmd.Flags().StringSlice(flagApprovers, []string{}, "keystore names or account addresses of approvers, separated by comma")
+	cmd.Flags().String(flagReceiver, "", "receiver keystore name or account address")
+	cmd.Flags().Int64(flagAmount, 0, "QOS amount to spend")
+	cmd.Flags().String(flagMemo, "", "memo of the spend")
+
+	cmd.MarkFlagRequired(flagApprovers)
+	cmd.MarkFlagRequired(flagReceiver)
+	cmd.MarkFlagRequired(flagAmount)
+
+	return cmd
+}
+
+func WithdrawDelegatorRewardCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "withdraw-reward",
+		Short: "Withdraw delegator rewards without unbonding",
+		Long: `
+Withdraw rewards of the delegation on the validator of owner, or rewards of all delegations with --all.
+
+example:
+
+	 qoscli tx withdraw-reward --delegator delegatorName --owner address1xxx
+	 qoscli tx withdraw-reward --delegator delegatorName --all
+
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+				delegator, err := qcliacc.GetAddrFromFlag(ctx, flagDelegator)
+				if err != nil {
+					return nil, err
+				}
+
+				if viper.GetBool(flagAll) {
+					return distribution.NewWithdrawAllDelegatorRewardsTx(delegator), nil
+				}
+
+				if viper.GetString(flagOwner) == "" {
+					return nil, errors.New("owner is empty, use --all to withdraw rewards of all delegations")
+				}
+				owner, err := qcliacc.GetAddrFromFlag(ctx, flagOwner)
+				if err != nil {
+					return nil, err
+				}
+
+				return distribution.NewWithdrawDelegatorRewardTx(delegator, owner), nil
+			})
+		},
+	}
+
+	cmd.Flags().String(flagDelegator, "", "delegator keystore name or account address")
+	cmd.Flags().String(flagOwner, "", "validator's owner keystore name or account address")
+	cmd.Flags().Bool(flagAll, false, "withdraw rewards of all delegations")
+
+	cmd.MarkFlagRequired(flagDelegator)
+
+	return cmd
+}
+
+func SetWithdrawAddressCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-withdraw-address",
+		Short: "Set the address receiving delegator rewards and unbonded QOS",
+		Long: `
+Rewards and unbonded QOS of delegator are paid to the withdraw address, set it to the delegator itself to restore default.
+
+example:
+
+	 qoscli tx set-withdraw-address --delegator delegatorName --withdraw-address address1xxx
+
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+				delegator, err := qcliacc.GetAddrFromFlag(ctx, flagDelegator)
+				if err != nil {
+					return nil, err
+				}
+
+				withdrawAddr, err := qcliacc.GetAddrFromFlag(ctx, flagWithdraw)
+				if err != nil {
+					return nil, err
+				}
+
+				return distribution.NewSetWithdrawAddressTx(delegator, withdrawAddr), nil
+			})
+		},
+	}
+
+	cmd.Flags().String(flagDelegator, "", "delegator keystore name or account address")
+	cmd.Flags().String(flagWithdraw, "", "withdraw keystore name or account address")
+
+	cmd.MarkFlagRequired(flagDelegator)
+	cmd.MarkFlagRequired(flagWithdraw)
+
+	return cmd
+}
@@ -0,0 +1,21 @@
+package distribution
+
+import (
+	"github.com/QOSGroup/qbase/baseabci"
+	qtypes "github.com/QOSGroup/qos/types"
+	"github.com/tendermint/go-amino"
+)
+
+var cdc = baseabci.MakeQBaseCodec()
+
+func init() {
+	qtypes.RegisterCodec(cdc)
+	RegisterCodec(cdc)
+}
+
+func RegisterCodec(cdc *amino.Codec) {
+	cdc.RegisterConcrete(&TxCommunityPoolSpend{}, "qos/txs/TxCommunityPoolSpend", nil)
+	cdc.RegisterConcrete(&TxWithdrawDelegatorReward{}, "qos/txs/TxWithdrawDelegatorReward", nil)
+	cdc.RegisterConcrete(&TxWithdrawAllDelegatorRewards{}, "qos/txs/TxWithdrawAllDelegatorRewards", nil)
+	cdc.RegisterConcrete(&TxSetWithdrawAddress{}, "qos/txs/TxSetWithdrawAddress", nil)
+}
@@ -0,0 +1,73 @@
+package distribution
+
+import (
+	btypes "github.com/QOSGroup/qbase/types"
+)
+
+// distribution errors reserve 700 ~ 799.
+const (
+	DefaultCodeSpace btypes.CodespaceType = "distribution"
+
+	CodeInvalidInput           btypes.CodeType = 701 // 输入有误
+	CodeCommunityPoolNotEnough btypes.CodeType = 702 // 社区奖励池余额不足
+	CodeSpendNotAllowed        btypes.CodeType = 703 // 未配置社区奖励池支出签名账户
+	CodeInvalidApprover        btypes.CodeType = 704 // 签名账户不在支出签名账户列表中
+	CodeNotEnoughApprovers     btypes.CodeType = 705 // 签名账户数量不足
+	CodeDelegationNotExists    btypes.CodeType = 706 // 委托关系不存在
+)
+
+func msgOrDefaultMsg(msg string, code btypes.CodeType) string {
+	if msg != "" {
+		return msg
+	}
+	return codeToDefaultMsg(code)
+}
+
+func newError(codeSpace btypes.CodespaceType, code btypes.CodeType, msg string) btypes.Error {
+	msg = msgOrDefaultMsg(msg, code)
+	return btypes.NewError(codeSpace, code, msg)
+}
+
+// NOTE: Don't stringer this, we'll put better messages in later.
+func codeToDefaultMsg(code btypes.CodeType) string {
+	switch code {
+	case CodeInvalidInput:
+		return "invalid input"
+	case CodeCommunityPoolNotEnough:
+		return "community fee pool not enough"
+	case CodeSpendNotAllowed:
+		return "community fee pool spend not allowed"
+	case CodeInvalidApprover:
+		return "invalid community fee pool spend approver"
+	case CodeNotEnoughApprovers:
+		return "not enough community fee pool spend approvers"
+	case CodeDelegationNotExists:
+		return "delegation not exists"
+	default:
+		return btypes.CodeToDefaultMsg(code)
+	}
+}
+
+func ErrInvalidInput(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeInvalidInput, msg)
+}
+
+func ErrCommunityPoolNotEnough(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeCommunityPoolNotEnough, msg)
+}
+
+func ErrSpendNotAllowed(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeSpendNotAllowed, msg)
+}
+
+func ErrInvalidApprover(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeInvalidApprover, msg)
+}
+
+func ErrNotEnoughApprovers(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeNotEnoughApprovers, msg)
+}
+
+func ErrDelegationNotExists(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeDelegationNotExists, msg)
+}
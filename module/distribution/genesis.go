@@ -5,6 +5,8 @@ import (
 	btypes "github.com/QOSGroup/qbase/types"
 	"github.com/QOSGroup/qos/module/eco/mapper"
 	"github.com/QOSGroup/qos/module/eco/types"
+	"github.com/QOSGroup/qos/module/supply"
+	supplytypes "github.com/QOSGroup/qos/module/supply/types"
 	qtypes "github.com/QOSGroup/qos/types"
 	"github.com/tendermint/tendermint/crypto"
 )
@@ -18,6 +20,8 @@ type GenesisState struct {
 	DelegatorEarningInfos    []DelegatorEarningStartState  `json:"delegators_earning_info"`
 	DelegatorIncomeHeights   []DelegatorIncomeHeightState  `json:"delegators_income_height"`
 	Params                   types.DistributionParams      `json:"params"`
+	CommunityPoolSpends      []types.CommunityPoolSpend    `json:"community_pool_spends"`
+	DelegatorWithdrawAddrs   []DelegatorWithdrawAddrState  `json:"delegators_withdraw_address"`
 }
 
 func NewGenesisState(communityFeePool btypes.BigInt,
@@ -27,7 +31,9 @@ func NewGenesisState(communityFeePool btypes.BigInt,
 	validatorCurrentPeriods []ValidatorCurrentPeriodState,
 	delegatorEarningInfos []DelegatorEarningStartState,
 	delegatorIncomeHeights []DelegatorIncomeHeightState,
-	params types.DistributionParams) GenesisState {
+	params types.DistributionParams,
+	communityPoolSpends []types.CommunityPoolSpend,
+	delegatorWithdrawAddrs []DelegatorWithdrawAddrState) GenesisState {
 	return GenesisState{
 		CommunityFeePool:         communityFeePool,
 		LastBlockProposer:        lastBlockProposer,
@@ -37,6 +43,8 @@ func NewGenesisState(communityFeePool btypes.BigInt,
 		DelegatorEarningInfos:    delegatorEarningInfos,
 		DelegatorIncomeHeights:   delegatorIncomeHeights,
 		Params:                   params,
+		CommunityPoolSpends:      communityPoolSpends,
+		DelegatorWithdrawAddrs:   delegatorWithdrawAddrs,
 	}
 }
 
@@ -83,6 +91,42 @@ func InitGenesis(ctx context.Context, data GenesisState) {
 		distributionMapper.Set(key, true)
 	}
 
+	var lastSpendID uint64
+	for _, spend := range data.CommunityPoolSpends {
+		distributionMapper.SetCommunityPoolSpend(spend)
+		if spend.ID > lastSpendID {
+			lastSpendID = spend.ID
+		}
+	}
+	if lastSpendID > 0 {
+		distributionMapper.Set(types.BuildLastCommunityPoolSpendIDKey(), lastSpendID)
+	}
+
+	for _, withdrawAddrState := range data.DelegatorWithdrawAddrs {
+		distributionMapper.SetDelegatorWithdrawAddress(withdrawAddrState.DeleAddress, withdrawAddrState.WithdrawAddress)
+	}
+
+	supplyMapper := supply.GetSupplyMapper(ctx)
+	supplyMapper.SetLockedQOS(supplytypes.PreDistribution, distributionMapper.GetPreDistributionQOS())
+	supplyMapper.SetLockedQOS(supplytypes.CommunityPool, distributionMapper.GetCommunityFeePool())
+	supplyMapper.SetLockedQOS(supplytypes.Rewards, unpaidRewards(distributionMapper))
+}
+
+//已分配未发放的收益: validator当前计费点收益 + delegator历史收益 + delegator上次计算后各计费点的收益
+func unpaidRewards(distributionMapper *mapper.DistributionMapper) btypes.BigInt {
+	rewards := btypes.ZeroInt()
+	distributionMapper.IteratorValidatorsCurrentPeriod(func(_ btypes.Address, vcps types.ValidatorCurrentPeriodSummary) {
+		rewards = rewards.Add(vcps.Fees.NilToZero())
+	})
+
+	distributionMapper.IteratorDelegatorsEarningStartInfo(func(valAddr btypes.Address, _ btypes.Address, info types.DelegatorEarningsStartInfo) {
+		rewards = rewards.Add(info.HistoricalRewardFees.NilToZero())
+		if vcps, exsits := distributionMapper.GetValidatorCurrentPeriodSummary(valAddr); exsits && vcps.Period > 0 {
+			rewards = rewards.Add(distributionMapper.CalculateRewardsBetweenPeriod(valAddr, info.PreviousPeriod, vcps.Period-1, info.BondToken))
+		}
+	})
+
+	return rewards
 }
 
 func ExportGenesis(ctx context.Context, forZeroHeight bool) GenesisState {
@@ -159,6 +203,19 @@ func ExportGenesis(ctx context.Context, forZeroHeight bool) GenesisState {
 		}
 	})
 
+	var communityPoolSpends []types.CommunityPoolSpend
+	distributionMapper.IteratorCommunityPoolSpends(func(spend types.CommunityPoolSpend) {
+		communityPoolSpends = append(communityPoolSpends, spend)
+	})
+
+	var delegatorWithdrawAddrs []DelegatorWithdrawAddrState
+	distributionMapper.IteratorDelegatorsWithdrawAddress(func(deleAddr, withdrawAddr btypes.Address) {
+		delegatorWithdrawAddrs = append(delegatorWithdrawAddrs, DelegatorWithdrawAddrState{
+			DeleAddress:     deleAddr,
+			WithdrawAddress: withdrawAddr,
+		})
+	})
+
 	return NewGenesisState(feePool,
 		lastBlockProposer,
 		preDistributionQOS,
@@ -167,6 +224,8 @@ func ExportGenesis(ctx context.Context, forZeroHeight bool) GenesisState {
 		delegatorEarningInfos,
 		delegatorIncomeHeights,
 		params,
+		communityPoolSpends,
+		delegatorWithdrawAddrs,
 	)
 }
 
@@ -192,3 +251,8 @@ type DelegatorIncomeHeightState struct {
 	DeleAddress     btypes.Address `json:"delegator_address"`
 	Height          uint64         `json:"height"`
 }
+
+type DelegatorWithdrawAddrState struct {
+	DeleAddress     btypes.Address `json:"delegator_address"`
+	WithdrawAddress btypes.Address `json:"withdraw_address"`
+}
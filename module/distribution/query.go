@@ -22,6 +22,8 @@ custom path:
 query path:
 	/validatorPeriodInfo/:ownerAddr : 根据validator owner地址查询validator period info
 	/delegatorIncomeInfo/:delegatorAddr/:ownerAddr : 查询delegator地址查询收益计算信息
+	/communityPoolSpends : 查询社区奖励池支出记录
+	/withdrawAddress/:delegatorAddr : 查询delegator收益提取地址
 
 	xxx为bech32 address
 
@@ -38,17 +40,24 @@ func Query(ctx context.Context, route []string, req abci.RequestQuery) (res []by
 		}
 	}()
 
-	if len(route) < 2 {
+	if len(route) < 1 {
 		return nil, btypes.ErrInternal("custom query miss parameters")
 	}
 
 	var data []byte
 	var e error
 
-	if route[0] == ecotypes.ValidatorPeriodInfo {
+	if route[0] == ecotypes.CommunityPoolSpends {
+		data, e = queryCommunityPoolSpends(ctx)
+	} else if len(route) < 2 {
+		return nil, btypes.ErrInternal("custom query miss parameters")
+	} else if route[0] == ecotypes.ValidatorPeriodInfo {
 		ownerAddr, _ := btypes.GetAddrFromBech32(route[1])
 		data, e = queryValidatorPeriodInfo(ctx, ownerAddr)
-	} else if route[0] == ecotypes.DelegatorIncomeInfo {
+	} else if route[0] == ecotypes.WithdrawAddress {
+		deleAddr, _ := btypes.GetAddrFromBech32(route[1])
+		data, e = queryWithdrawAddress(ctx, deleAddr)
+	} else if route[0] == ecotypes.DelegatorIncomeInfo && len(route) > 2 {
 		deleAddr, _ := btypes.GetAddrFromBech32(route[1])
 		ownerAddr, _ := btypes.GetAddrFromBech32(route[2])
 		data, e = queryDelegatorIncomeInfo(ctx, deleAddr, ownerAddr)
@@ -123,6 +132,27 @@ func queryDelegatorIncomeInfo(ctx context.Context, delegator btypes.Address, own
 	return distributionMapper.GetCodec().MarshalJSON(result)
 }
 
+func queryCommunityPoolSpends(ctx context.Context) ([]byte, error) {
+	distributionMapper := ecomapper.GetDistributionMapper(ctx)
+
+	spends := make([]ecotypes.CommunityPoolSpend, 0)
+	distributionMapper.IteratorCommunityPoolSpends(func(spend ecotypes.CommunityPoolSpend) {
+		spends = append(spends, spend)
+	})
+
+	return distributionMapper.GetCodec().MarshalJSON(spends)
+}
+
+func queryWithdrawAddress(ctx context.Context, delegator btypes.Address) ([]byte, error) {
+	distributionMapper := ecomapper.GetDistributionMapper(ctx)
+
+	result := WithdrawAddressQueryResult{
+		DeleAddress:     delegator,
+		WithdrawAddress: distributionMapper.GetDelegatorWithdrawAddress(delegator),
+	}
+	return distributionMapper.GetCodec().MarshalJSON(result)
+}
+
 type ValidatorPeriodInfoQueryResult struct {
 	OwnerAddr          btypes.Address  `json:"owner_address"`
 	ValidatorPubKey    crypto.PubKey   `json:"validator_pub_key"`
@@ -144,3 +174,8 @@ type DelegatorIncomeInfoQueryResult struct {
 	LastIncomeCalHeight   uint64         `json:"last_income_calHeight"`
 	LastIncomeCalFees     btypes.BigInt  `json:"last_income_calFees"`
 }
+
+type WithdrawAddressQueryResult struct {
+	DeleAddress     btypes.Address `json:"delegator_address"`
+	WithdrawAddress btypes.Address `json:"withdraw_address"`
+}
@@ -0,0 +1,362 @@
+package distribution
+
+import (
+	"fmt"
+
+	bacc "github.com/QOSGroup/qbase/account"
+	"github.com/QOSGroup/qbase/context"
+	"github.com/QOSGroup/qbase/txs"
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/module/eco"
+	"github.com/QOSGroup/qos/module/eco/mapper"
+	"github.com/QOSGroup/qos/module/eco/types"
+	"github.com/QOSGroup/qos/module/supply"
+	supplytypes "github.com/QOSGroup/qos/module/supply/types"
+)
+
+const MaxMemoLen = 256
+
+// 社区奖励池支出, 需distribution参数中配置的支出账户多签
+type TxCommunityPoolSpend struct {
+	Approvers []btypes.Address //签名账户
+	Receiver  btypes.Address   //接收账户
+	Amount    uint64           //QOS数量
+	Memo      string           //备注
+}
+
+var _ txs.ITx = (*TxCommunityPoolSpend)(nil)
+
+func NewCommunityPoolSpendTx(approvers []btypes.Address, receiver btypes.Address, amount uint64, memo string) *TxCommunityPoolSpend {
+	return &TxCommunityPoolSpend{
+		Approvers: approvers,
+		Receiver:  receiver,
+		Amount:    amount,
+		Memo:      memo,
+	}
+}
+
+func (tx *TxCommunityPoolSpend) ValidateData(ctx context.Context) error {
+	if len(tx.Approvers) == 0 || len(tx.Receiver) == 0 || tx.Amount == 0 || len(tx.Memo) > MaxMemoLen {
+		return ErrInvalidInput(DefaultCodeSpace, "")
+	}
+
+	params := mapper.GetDistributionMapper(ctx).GetParams()
+	if params.CommunityPoolSpendThreshold == 0 || len(params.CommunityPoolSpenders) == 0 {
+		return ErrSpendNotAllowed(DefaultCodeSpace, "")
+	}
+
+	spenders := make(map[string]bool)
+	for _, spender := range params.CommunityPoolSpenders {
+		spenders[spender.String()] = true
+	}
+	approved := make(map[string]bool)
+	for _, approver := range tx.Approvers {
+		if approved[approver.String()] {
+			return ErrInvalidInput(DefaultCodeSpace, fmt.Sprintf("duplicate approver %s", approver))
+		}
+		if !spenders[approver.String()] {
+			return ErrInvalidApprover(DefaultCodeSpace, fmt.Sprintf("%s is not a community fee pool spender", approver))
+		}
+		approved[approver.String()] = true
+	}
+	if uint32(len(approved)) < params.CommunityPoolSpendThreshold {
+		return ErrNotEnoughApprovers(DefaultCodeSpace, fmt.Sprintf("need %d approvers, got %d", params.CommunityPoolSpendThreshold, len(approved)))
+	}
+
+	pool := mapper.GetDistributionMapper(ctx).GetCommunityFeePool()
+	if pool.LT(btypes.NewInt(int64(tx.Amount))) {
+		return ErrCommunityPoolNotEnough(DefaultCodeSpace, "")
+	}
+
+	return nil
+}
+
+func (tx *TxCommunityPoolSpend) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	id, err := SpendCommunityFeePool(ctx, tx.Receiver, tx.Amount, tx.Memo, tx.Approvers, 0)
+	if err != nil {
+		return btypes.Result{Code: btypes.CodeInternal, Codespace: btypes.CodespaceType(err.Error())}, nil
+	}
+
+	return btypes.Result{Code: btypes.CodeOK, Data: []byte(fmt.Sprintf("%d", id))}, nil
+}
+
+func (tx *TxCommunityPoolSpend) GetSigner() []btypes.Address {
+	return tx.Approvers
+}
+
+func (tx *TxCommunityPoolSpend) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx *TxCommunityPoolSpend) GasItems() uint64 {
+	return 0
+}
+
+func (tx *TxCommunityPoolSpend) GetGasPayer() btypes.Address {
+	if len(tx.Approvers) == 0 {
+		return nil
+	}
+	return tx.Approvers[0]
+}
+
+func (tx *TxCommunityPoolSpend) GetSignData() (ret []byte) {
+	for _, approver := range tx.Approvers {
+		ret = append(ret, approver...)
+	}
+	ret = append(ret, tx.Receiver...)
+	ret = append(ret, btypes.Int2Byte(int64(tx.Amount))...)
+	ret = append(ret, []byte(tx.Memo)...)
+
+	return
+}
+
+// 从社区奖励池向receiver支付amount QOS, 并保存支出记录. 多签支出时proposalID为0, 治理提议支出时approvers为空
+func SpendCommunityFeePool(ctx context.Context, receiver btypes.Address, amount uint64, memo string,
+	approvers []btypes.Address, proposalID uint64) (uint64, error) {
+	distributionMapper := mapper.GetDistributionMapper(ctx)
+
+	qos := btypes.NewInt(int64(amount))
+	pool := distributionMapper.GetCommunityFeePool()
+	if pool.LT(qos) {
+		return 0, ErrCommunityPoolNotEnough(DefaultCodeSpace, "")
+	}
+
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	if accountMapper.GetAccount(receiver) == nil {
+		accountMapper.SetAccount(accountMapper.NewAccountWithAddress(receiver))
+	}
+	if err := eco.IncrAccountQOS(ctx, receiver, qos); err != nil {
+		return 0, err
+	}
+	distributionMapper.SetCommunityFeePool(pool.Sub(qos))
+	supply.GetSupplyMapper(ctx).UnlockQOS(supplytypes.CommunityPool, qos)
+
+	id := distributionMapper.AddCommunityPoolSpend(types.CommunityPoolSpend{
+		Height:     uint64(ctx.BlockHeight()),
+		Receiver:   receiver,
+		Amount:     amount,
+		Memo:       memo,
+		Approvers:  approvers,
+		ProposalID: proposalID,
+	})
+
+	return id, nil
+}
+
+// delegator提取在validator上的收益, 不解除委托
+type TxWithdrawDelegatorReward struct {
+	Delegator btypes.Address //delegator地址
+	Owner     btypes.Address //validator owner地址
+}
+
+var _ txs.ITx = (*TxWithdrawDelegatorReward)(nil)
+
+func NewWithdrawDelegatorRewardTx(delegator, owner btypes.Address) *TxWithdrawDelegatorReward {
+	return &TxWithdrawDelegatorReward{
+		Delegator: delegator,
+		Owner:     owner,
+	}
+}
+
+func (tx *TxWithdrawDelegatorReward) ValidateData(ctx context.Context) error {
+	if len(tx.Delegator) == 0 || len(tx.Owner) == 0 {
+		return ErrInvalidInput(DefaultCodeSpace, "")
+	}
+
+	validator, exists := mapper.GetValidatorMapper(ctx).GetValidatorByOwner(tx.Owner)
+	if !exists {
+		return ErrInvalidInput(DefaultCodeSpace, fmt.Sprintf("validator of owner %s not exists", tx.Owner))
+	}
+
+	if _, exists := mapper.GetDistributionMapper(ctx).GetDelegatorEarningStartInfo(validator.GetValidatorAddress(), tx.Delegator); !exists {
+		return ErrDelegationNotExists(DefaultCodeSpace, "")
+	}
+
+	return nil
+}
+
+func (tx *TxWithdrawDelegatorReward) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	e := eco.GetEco(ctx)
+	validator, _ := e.ValidatorMapper.GetValidatorByOwner(tx.Owner)
+
+	rewards, err := withdrawDelegatorReward(e, validator, tx.Delegator)
+	if err != nil {
+		return btypes.Result{Code: btypes.CodeInternal, Codespace: btypes.CodespaceType(err.Error())}, nil
+	}
+
+	return btypes.Result{Code: btypes.CodeOK, Data: []byte(rewards.String())}, nil
+}
+
+func (tx *TxWithdrawDelegatorReward) GetSigner() []btypes.Address {
+	return []btypes.Address{tx.Delegator}
+}
+
+func (tx *TxWithdrawDelegatorReward) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx *TxWithdrawDelegatorReward) GasItems() uint64 {
+	return 0
+}
+
+func (tx *TxWithdrawDelegatorReward) GetGasPayer() btypes.Address {
+	return tx.Delegator
+}
+
+func (tx *TxWithdrawDelegatorReward) GetSignData() (ret []byte) {
+	ret = append(ret, tx.Delegator...)
+	ret = append(ret, tx.Owner...)
+
+	return
+}
+
+// delegator提取在所有委托validator上的收益, 不解除委托
+type TxWithdrawAllDelegatorRewards struct {
+	Delegator btypes.Address //delegator地址
+}
+
+var _ txs.ITx = (*TxWithdrawAllDelegatorRewards)(nil)
+
+func NewWithdrawAllDelegatorRewardsTx(delegator btypes.Address) *TxWithdrawAllDelegatorRewards {
+	return &TxWithdrawAllDelegatorRewards{
+		Delegator: delegator,
+	}
+}
+
+func (tx *TxWithdrawAllDelegatorRewards) ValidateData(ctx context.Context) error {
+	if len(tx.Delegator) == 0 {
+		return ErrInvalidInput(DefaultCodeSpace, "")
+	}
+
+	if len(delegatedValidators(eco.GetEco(ctx), tx.Delegator)) == 0 {
+		return ErrDelegationNotExists(DefaultCodeSpace, "")
+	}
+
+	return nil
+}
+
+func (tx *TxWithdrawAllDelegatorRewards) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	e := eco.GetEco(ctx)
+
+	total := btypes.ZeroInt()
+	for _, validator := range delegatedValidators(e, tx.Delegator) {
+		rewards, err := withdrawDelegatorReward(e, validator, tx.Delegator)
+		if err != nil {
+			return btypes.Result{Code: btypes.CodeInternal, Codespace: btypes.CodespaceType(err.Error())}, nil
+		}
+		total = total.Add(rewards)
+	}
+
+	return btypes.Result{Code: btypes.CodeOK, Data: []byte(total.String())}, nil
+}
+
+func (tx *TxWithdrawAllDelegatorRewards) GetSigner() []btypes.Address {
+	return []btypes.Address{tx.Delegator}
+}
+
+func (tx *TxWithdrawAllDelegatorRewards) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx *TxWithdrawAllDelegatorRewards) GasItems() uint64 {
+	return 0
+}
+
+func (tx *TxWithdrawAllDelegatorRewards) GetGasPayer() btypes.Address {
+	return tx.Delegator
+}
+
+func (tx *TxWithdrawAllDelegatorRewards) GetSignData() (ret []byte) {
+	ret = append(ret, tx.Delegator...)
+
+	return
+}
+
+// delegator有收益计算信息且仍存在的validator
+func delegatedValidators(e eco.Eco, deleAddr btypes.Address) (validators []types.Validator) {
+	e.DelegationMapper.IterateDelegationsInfo(deleAddr, func(info types.DelegationInfo) {
+		validator, exists := e.ValidatorMapper.GetValidator(info.ValidatorAddr)
+		if !exists {
+			return
+		}
+		if _, exists := e.DistributionMapper.GetDelegatorEarningStartInfo(info.ValidatorAddr, deleAddr); !exists {
+			return
+		}
+		validators = append(validators, validator)
+	})
+
+	return
+}
+
+// 立即计算delegator在validator上的收益并发放至收益提取地址, 重置收益计算信息, 周期收益发放计划不变
+func withdrawDelegatorReward(e eco.Eco, validator types.Validator, deleAddr btypes.Address) (btypes.BigInt, error) {
+	endPeriod := e.IncrementValidatorPeriod(validator)
+	rewards, err := e.DistributionMapper.CalculateDelegatorPeriodRewards(validator.GetValidatorAddress(), deleAddr, endPeriod, uint64(e.Context.BlockHeight()))
+	if err != nil {
+		return btypes.ZeroInt(), err
+	}
+
+	rewards = rewards.NilToZero()
+	if err := payRewards(e, deleAddr, rewards); err != nil {
+		return btypes.ZeroInt(), err
+	}
+
+	e.Context.Logger().Debug("withdraw delegator reward", "delegator", deleAddr.String(), "validator", validator.GetValidatorAddress().String(), "rewards", rewards)
+	return rewards, nil
+}
+
+// 设置delegator收益提取地址, 委托收益及解除委托返还的QOS均发放至该地址
+type TxSetWithdrawAddress struct {
+	Delegator       btypes.Address //delegator地址
+	WithdrawAddress btypes.Address //收益提取地址, 与delegator地址相同时恢复默认
+}
+
+var _ txs.ITx = (*TxSetWithdrawAddress)(nil)
+
+func NewSetWithdrawAddressTx(delegator, withdrawAddress btypes.Address) *TxSetWithdrawAddress {
+	return &TxSetWithdrawAddress{
+		Delegator:       delegator,
+		WithdrawAddress: withdrawAddress,
+	}
+}
+
+func (tx *TxSetWithdrawAddress) ValidateData(ctx context.Context) error {
+	if len(tx.Delegator) == 0 || len(tx.WithdrawAddress) == 0 {
+		return ErrInvalidInput(DefaultCodeSpace, "")
+	}
+
+	return nil
+}
+
+func (tx *TxSetWithdrawAddress) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	if accountMapper.GetAccount(tx.WithdrawAddress) == nil {
+		accountMapper.SetAccount(accountMapper.NewAccountWithAddress(tx.WithdrawAddress))
+	}
+	mapper.GetDistributionMapper(ctx).SetDelegatorWithdrawAddress(tx.Delegator, tx.WithdrawAddress)
+
+	return btypes.Result{Code: btypes.CodeOK}, nil
+}
+
+func (tx *TxSetWithdrawAddress) GetSigner() []btypes.Address {
+	return []btypes.Address{tx.Delegator}
+}
+
+func (tx *TxSetWithdrawAddress) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx *TxSetWithdrawAddress) GasItems() uint64 {
+	return 0
+}
+
+func (tx *TxSetWithdrawAddress) GetGasPayer() btypes.Address {
+	return tx.Delegator
+}
+
+func (tx *TxSetWithdrawAddress) GetSignData() (ret []byte) {
+	ret = append(ret, tx.Delegator...)
+	ret = append(ret, tx.WithdrawAddress...)
+
+	return
+}
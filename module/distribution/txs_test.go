@@ -0,0 +1,261 @@
+package distribution
+
+import (
+	"testing"
+	"time"
+
+	"github.com/QOSGroup/qbase/account"
+	"github.com/QOSGroup/qbase/context"
+	"github.com/QOSGroup/qbase/mapper"
+	"github.com/QOSGroup/qbase/store"
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/module/eco"
+	ecomapper "github.com/QOSGroup/qos/module/eco/mapper"
+	ecotypes "github.com/QOSGroup/qos/module/eco/types"
+	"github.com/QOSGroup/qos/module/supply"
+	supplytypes "github.com/QOSGroup/qos/module/supply/types"
+	qtypes "github.com/QOSGroup/qos/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestTxCommunityPoolSpend(t *testing.T) {
+	ctx := defaultContext()
+
+	spenderA := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	spenderB := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	other := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	receiver := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+
+	distributionMapper := ecomapper.GetDistributionMapper(ctx)
+	distributionMapper.SetCommunityFeePool(btypes.NewInt(1000))
+	supplyMapper := supply.GetSupplyMapper(ctx)
+	supplyMapper.SetLockedQOS(supplytypes.CommunityPool, btypes.NewInt(1000))
+
+	//未配置支出账户
+	params := ecotypes.DefaultDistributionParams()
+	distributionMapper.SetParams(params)
+	tx := NewCommunityPoolSpendTx([]btypes.Address{spenderA}, receiver, 100, "memo")
+	require.NotNil(t, tx.ValidateData(ctx))
+
+	params.CommunityPoolSpenders = []btypes.Address{spenderA, spenderB}
+	params.CommunityPoolSpendThreshold = 2
+	distributionMapper.SetParams(params)
+
+	//签名数量不足
+	require.NotNil(t, tx.ValidateData(ctx))
+	//无签名账户
+	tx = NewCommunityPoolSpendTx(nil, receiver, 100, "memo")
+	require.Nil(t, tx.GetGasPayer())
+	require.NotNil(t, tx.ValidateData(ctx))
+	//重复签名
+	tx = NewCommunityPoolSpendTx([]btypes.Address{spenderA, spenderA}, receiver, 100, "memo")
+	require.NotNil(t, tx.ValidateData(ctx))
+	//非支出账户签名
+	tx = NewCommunityPoolSpendTx([]btypes.Address{spenderA, other}, receiver, 100, "memo")
+	require.NotNil(t, tx.ValidateData(ctx))
+	//余额不足
+	tx = NewCommunityPoolSpendTx([]btypes.Address{spenderA, spenderB}, receiver, 1001, "memo")
+	require.NotNil(t, tx.ValidateData(ctx))
+
+	tx = NewCommunityPoolSpendTx([]btypes.Address{spenderA, spenderB}, receiver, 100, "memo")
+	require.Nil(t, tx.ValidateData(ctx))
+	result, _ := tx.Exec(ctx)
+	require.True(t, result.IsOK())
+
+	require.Equal(t, btypes.NewInt(900), distributionMapper.GetCommunityFeePool())
+	require.Equal(t, btypes.NewInt(900), supplyMapper.GetLockedQOS(supplytypes.CommunityPool))
+	accountMapper := ctx.Mapper(account.AccountMapperName).(*account.AccountMapper)
+	require.Equal(t, btypes.NewInt(100), accountMapper.GetAccount(receiver).(*qtypes.QOSAccount).QOS)
+
+	var spends []ecotypes.CommunityPoolSpend
+	distributionMapper.IteratorCommunityPoolSpends(func(spend ecotypes.CommunityPoolSpend) {
+		spends = append(spends, spend)
+	})
+	require.Equal(t, 1, len(spends))
+	require.Equal(t, uint64(1), spends[0].ID)
+	require.Equal(t, uint64(100), spends[0].Amount)
+	require.Equal(t, "memo", spends[0].Memo)
+	require.Equal(t, 2, len(spends[0].Approvers))
+}
+
+func TestTxWithdrawDelegatorReward(t *testing.T) {
+	ctx := defaultContext().WithBlockHeight(10)
+	e := eco.GetEco(ctx)
+	e.DistributionMapper.SetParams(ecotypes.DefaultDistributionParams())
+
+	ownerA := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	ownerB := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	delegator := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	for _, addr := range []btypes.Address{ownerA, ownerB, delegator} {
+		createAccount(ctx, addr)
+	}
+
+	//A: ownerA 600, delegator 400; B: ownerB 800, delegator 200
+	valA := createValidator(ctx, ownerA, 600, delegator, 400)
+	valB := createValidator(ctx, ownerB, 800, delegator, 200)
+
+	//佣金10%: ownerA 100, 其余900按绑定比例分配
+	rewardToValidator(e, valA, btypes.NewInt(1000))
+	rewardToValidator(e, valB, btypes.NewInt(1000))
+
+	//无委托关系
+	tx := NewWithdrawDelegatorRewardTx(delegator, btypes.Address(ed25519.GenPrivKey().PubKey().Address()))
+	require.NotNil(t, tx.ValidateData(ctx))
+	require.NotNil(t, NewWithdrawAllDelegatorRewardsTx(btypes.Address(ed25519.GenPrivKey().PubKey().Address())).ValidateData(ctx))
+
+	tx = NewWithdrawDelegatorRewardTx(delegator, ownerA)
+	require.Nil(t, tx.ValidateData(ctx))
+	result, _ := tx.Exec(ctx)
+	require.True(t, result.IsOK())
+	require.Equal(t, btypes.NewInt(360), getQOS(ctx, delegator))
+
+	info, _ := e.DistributionMapper.GetDelegatorEarningStartInfo(valA, delegator)
+	require.Equal(t, btypes.ZeroInt(), info.HistoricalRewardFees)
+	require.Equal(t, uint64(10), info.CurrentStartingHeight)
+	require.Equal(t, uint64(400), info.BondToken)
+
+	//重复提取无收益
+	result, _ = tx.Exec(ctx)
+	require.True(t, result.IsOK())
+	require.Equal(t, btypes.NewInt(360), getQOS(ctx, delegator))
+
+	ownerTx := NewWithdrawDelegatorRewardTx(ownerA, ownerA)
+	require.Nil(t, ownerTx.ValidateData(ctx))
+	ownerTx.Exec(ctx)
+	require.Equal(t, btypes.NewInt(640), getQOS(ctx, ownerA))
+
+	//提取全部: A无新增收益, B 900*200/1000
+	allTx := NewWithdrawAllDelegatorRewardsTx(delegator)
+	require.Nil(t, allTx.ValidateData(ctx))
+	result, _ = allTx.Exec(ctx)
+	require.True(t, result.IsOK())
+	require.Equal(t, btypes.NewInt(540), getQOS(ctx, delegator))
+
+	//周期收益发放计划不变
+	incomes := 0
+	e.DistributionMapper.IteratorDelegatorsIncomeHeight(func(_ btypes.Address, _ btypes.Address, _ uint64) {
+		incomes++
+	})
+	require.Equal(t, 4, incomes)
+}
+
+func TestTxSetWithdrawAddress(t *testing.T) {
+	ctx := defaultContext().WithBlockHeight(10)
+	e := eco.GetEco(ctx)
+	e.DistributionMapper.SetParams(ecotypes.DefaultDistributionParams())
+
+	owner := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	delegator := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	withdrawAddr := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	createAccount(ctx, owner)
+	createAccount(ctx, delegator)
+
+	valAddr := createValidator(ctx, owner, 600, delegator, 400)
+	rewardToValidator(e, valAddr, btypes.NewInt(1000))
+	require.Equal(t, delegator, e.DistributionMapper.GetDelegatorWithdrawAddress(delegator))
+
+	tx := NewSetWithdrawAddressTx(delegator, withdrawAddr)
+	require.Nil(t, tx.ValidateData(ctx))
+	result, _ := tx.Exec(ctx)
+	require.True(t, result.IsOK())
+	require.Equal(t, withdrawAddr, e.DistributionMapper.GetDelegatorWithdrawAddress(delegator))
+
+	//收益发放至提取地址
+	NewWithdrawDelegatorRewardTx(delegator, owner).Exec(ctx)
+	require.Equal(t, btypes.ZeroInt(), getQOS(ctx, delegator))
+	require.Equal(t, btypes.NewInt(360), getQOS(ctx, withdrawAddr))
+
+	//导出
+	var exported []DelegatorWithdrawAddrState
+	e.DistributionMapper.IteratorDelegatorsWithdrawAddress(func(deleAddr, withdrawAddr btypes.Address) {
+		exported = append(exported, DelegatorWithdrawAddrState{deleAddr, withdrawAddr})
+	})
+	require.Equal(t, []DelegatorWithdrawAddrState{{delegator, withdrawAddr}}, exported)
+
+	//恢复默认
+	result, _ = NewSetWithdrawAddressTx(delegator, delegator).Exec(ctx)
+	require.True(t, result.IsOK())
+	require.Equal(t, delegator, e.DistributionMapper.GetDelegatorWithdrawAddress(delegator))
+	exported = nil
+	e.DistributionMapper.IteratorDelegatorsWithdrawAddress(func(deleAddr, withdrawAddr btypes.Address) {
+		exported = append(exported, DelegatorWithdrawAddrState{deleAddr, withdrawAddr})
+	})
+	require.Equal(t, 0, len(exported))
+}
+
+func createValidator(ctx context.Context, owner btypes.Address, ownerTokens uint64, delegator btypes.Address, delegateTokens uint64) btypes.Address {
+	validator := ecotypes.Validator{
+		Name:            "test",
+		Owner:           owner,
+		ValidatorPubKey: ed25519.GenPrivKey().PubKey(),
+		BondTokens:      ownerTokens + delegateTokens,
+		Status:          ecotypes.Active,
+		BondHeight:      1,
+		Commission:      ecotypes.NewCommission(qtypes.NewFraction(1, 10), qtypes.NewFraction(2, 10), qtypes.NewFraction(1, 100), time.Time{}),
+	}
+	valAddr := validator.GetValidatorAddress()
+
+	ecomapper.GetValidatorMapper(ctx).CreateValidator(validator)
+	delegationMapper := ecomapper.GetDelegationMapper(ctx)
+	distributionMapper := ecomapper.GetDistributionMapper(ctx)
+	distributionMapper.InitValidatorPeriodSummaryInfo(valAddr)
+	delegationMapper.SetDelegationInfo(ecotypes.NewDelegationInfo(owner, valAddr, ownerTokens, false))
+	distributionMapper.InitDelegatorIncomeInfo(valAddr, owner, ownerTokens, 1)
+	delegationMapper.SetDelegationInfo(ecotypes.NewDelegationInfo(delegator, valAddr, delegateTokens, false))
+	distributionMapper.InitDelegatorIncomeInfo(valAddr, delegator, delegateTokens, 1)
+
+	return valAddr
+}
+
+func createAccount(ctx context.Context, addr btypes.Address) {
+	accountMapper := ctx.Mapper(account.AccountMapperName).(*account.AccountMapper)
+	accountMapper.SetAccount(accountMapper.NewAccountWithAddress(addr))
+}
+
+func getQOS(ctx context.Context, addr btypes.Address) btypes.BigInt {
+	accountMapper := ctx.Mapper(account.AccountMapperName).(*account.AccountMapper)
+	return accountMapper.GetAccount(addr).(*qtypes.QOSAccount).QOS.NilToZero()
+}
+
+func defaultContext() context.Context {
+
+	mapperMap := make(map[string]mapper.IMapper)
+
+	accountMapper := account.NewAccountMapper(cdc, qtypes.ProtoQOSAccount)
+	mapperMap[account.AccountMapperName] = accountMapper
+
+	distributionMapper := ecomapper.NewDistributionMapper()
+	distributionMapper.SetCodec(cdc)
+	mapperMap[ecotypes.DistributionMapperName] = distributionMapper
+
+	validatorMapper := ecomapper.NewValidatorMapper()
+	validatorMapper.SetCodec(cdc)
+	mapperMap[ecotypes.ValidatorMapperName] = validatorMapper
+
+	delegationMapper := ecomapper.NewDelegationMapper()
+	delegationMapper.SetCodec(cdc)
+	mapperMap[ecotypes.DelegationMapperName] = delegationMapper
+
+	voteInfoMapper := ecomapper.NewVoteInfoMapper()
+	voteInfoMapper.SetCodec(cdc)
+	mapperMap[ecotypes.VoteInfoMapperName] = voteInfoMapper
+
+	supplyMapper := supply.NewSupplyMapper()
+	supplyMapper.SetCodec(cdc)
+	mapperMap[supplytypes.SupplyMapperName] = supplyMapper
+
+	db := dbm.NewMemDB()
+	cms := store.NewCommitMultiStore(db)
+
+	for _, v := range mapperMap {
+		cms.MountStoreWithDB(v.GetStoreKey(), store.StoreTypeIAVL, db)
+	}
+	cms.LoadLatestVersion()
+
+	ctx := context.NewContext(cms, abci.Header{}, false, log.NewNopLogger(), mapperMap)
+	return ctx
+}
@@ -20,7 +20,9 @@ func RegisterCodec(cdc *amino.Codec) {
 
 	cdc.RegisterConcrete(&types.Validator{}, "eco/types/Validator", nil)
 	cdc.RegisterConcrete(&types.DelegationInfo{}, "eco/types/DelegationInfo", nil)
+	cdc.RegisterConcrete(&types.UnbondingDelegationInfo{}, "eco/types/UnbondingDelegationInfo", nil)
 	cdc.RegisterConcrete(&types.DelegatorEarningsStartInfo{}, "eco/types/DelegatorEarningsStartInfo", nil)
 	cdc.RegisterConcrete(&types.ValidatorCurrentPeriodSummary{}, "eco/types/ValidatorCurrentPeriodSummary", nil)
 	cdc.RegisterConcrete(&types.ValidatorVoteInfo{}, "eco/types/ValidatorVoteInfo", nil)
+	cdc.RegisterConcrete(&types.CommunityPoolSpend{}, "eco/types/CommunityPoolSpend", nil)
 }
@@ -7,9 +7,12 @@ import (
 	"github.com/QOSGroup/qbase/store"
 	"github.com/QOSGroup/qos/module/eco/mapper"
 	"github.com/QOSGroup/qos/module/eco/types"
+	"github.com/QOSGroup/qos/module/supply"
+	supplytypes "github.com/QOSGroup/qos/module/supply/types"
 
 	"github.com/QOSGroup/qbase/context"
 	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/tendermint/tendermint/crypto"
 )
 
 //删除和validator相关的eco数据
@@ -35,13 +38,14 @@ func (e Eco) RemoveValidator(valAddr btypes.Address) error {
 	}
 
 	//1. validator的汇总收益增加
-	endPeriod := distributionMapper.IncrementValidatorPeriod(validator)
+	endPeriod := e.IncrementValidatorPeriod(validator)
 
 	//2. 计算所有delegator的收益信息,并将delegator绑定的token置为0
 	prefixKey := append(types.GetDelegatorEarningsStartInfoPrefixKey(), valAddr...)
 	iter := store.KVStorePrefixIterator(distributionMapper.GetStore(), prefixKey)
 	defer iter.Close()
 
+	totalUnbondTokens := uint64(0)
 	for ; iter.Valid(); iter.Next() {
 		var info types.DelegatorEarningsStartInfo
 		distributionMapper.BaseMapper.DecodeObject(iter.Value(), &info)
@@ -63,7 +67,9 @@ func (e Eco) RemoveValidator(valAddr btypes.Address) error {
 		//unbond height
 		unbondHeight := uint64(stakeParams.DelegatorUnbondReturnHeight) + height
 		delegationMapper.AddDelegatorUnbondingQOSatHeight(unbondHeight, deleAddr, unbondToken)
+		totalUnbondTokens += unbondToken
 	}
+	supply.GetSupplyMapper(e.Context).MoveLockedQOS(supplytypes.Bonded, supplytypes.Unbonding, btypes.NewInt(int64(totalUnbondTokens)))
 
 	//删除validator汇总收益数据
 	distributionMapper.DeleteValidatorPeriodSummaryInfo(valAddr)
@@ -75,6 +81,21 @@ func (e Eco) RemoveValidator(valAddr btypes.Address) error {
 	return nil
 }
 
+//更换validator共识公钥, 将validator, 投票, 委托及收益分配数据转移至新地址.
+//validator集合的变更(旧公钥移除, 新公钥加入)在EndBlocker中由GetUpdatedValidators返回
+func (e Eco) RotateValidatorKey(validator types.Validator, newPubKey crypto.PubKey) types.Validator {
+	oldAddr := validator.GetValidatorAddress()
+
+	validator = e.ValidatorMapper.ChangeValidatorPubKey(validator, newPubKey)
+	newAddr := validator.GetValidatorAddress()
+
+	e.VoteInfoMapper.ChangeValidatorAddress(oldAddr, newAddr)
+	e.DelegationMapper.ChangeValidatorAddress(oldAddr, newAddr)
+	e.DistributionMapper.ChangeValidatorAddress(oldAddr, newAddr)
+
+	return validator
+}
+
 func (e Eco) DelegateValidator(validator types.Validator, delegatorAddr btypes.Address, delegateAmount uint64, isCompound bool, needMinusAccountQOS bool) error {
 
 	height := uint64(e.Context.BlockHeight())
@@ -88,7 +109,7 @@ func (e Eco) DelegateValidator(validator types.Validator, delegatorAddr btypes.A
 	if needMinusAccountQOS {
 		//0. delegator账户扣减QOS, amount:qos = 1:1
 		decrQOS := btypes.NewInt(int64(delegateAmount))
-		if err := DecrAccountQOS(e.Context, delegatorAddr, decrQOS); err != nil {
+		if err := DelegateAccountQOS(e.Context, delegatorAddr, decrQOS); err != nil {
 			return err
 		}
 	}
@@ -105,7 +126,7 @@ func (e Eco) DelegateValidator(validator types.Validator, delegatorAddr btypes.A
 
 	updatedAmount := delegatedAmount + delegateAmount
 	//1. validator增加周期 , 计算周期段内delegator收益,并更新收益信息
-	if err := distributionMapper.ModifyDelegatorTokens(validator, delegatorAddr, updatedAmount, height); err != nil {
+	if err := e.ModifyDelegatorTokens(validator, delegatorAddr, updatedAmount, height); err != nil {
 		return err
 	}
 
@@ -126,7 +147,6 @@ func (e Eco) UnbondValidator(validator types.Validator, delegatorAddr btypes.Add
 
 	height := uint64(e.Context.BlockHeight())
 
-	distributionMapper := e.DistributionMapper
 	delegationMapper := e.DelegationMapper
 	validatorMapper := e.ValidatorMapper
 
@@ -143,7 +163,7 @@ func (e Eco) UnbondValidator(validator types.Validator, delegatorAddr btypes.Add
 
 	//1. 计算当前delegator收益
 	updatedTokens := info.Amount - unbondAmount
-	if err := distributionMapper.ModifyDelegatorTokens(validator, delegatorAddr, updatedTokens, height); err != nil {
+	if err := e.ModifyDelegatorTokens(validator, delegatorAddr, updatedTokens, height); err != nil {
 		return err
 	}
 
@@ -156,6 +176,8 @@ func (e Eco) UnbondValidator(validator types.Validator, delegatorAddr btypes.Add
 		stakeParams := validatorMapper.GetParams()
 		unbondHeight := uint64(stakeParams.DelegatorUnbondReturnHeight) + height
 		delegationMapper.AddDelegatorUnbondingQOSatHeight(unbondHeight, delegatorAddr, unbondAmount)
+		delegationMapper.AddValidatorUnbondingQOSatHeight(unbondHeight, valAddr, delegatorAddr, height, unbondAmount)
+		supply.GetSupplyMapper(e.Context).MoveLockedQOS(supplytypes.Bonded, supplytypes.Unbonding, btypes.NewInt(int64(unbondAmount)))
 	}
 
 	//4. 更新validator的bondTokens, amount:token = 1:1
@@ -166,6 +188,38 @@ func (e Eco) UnbondValidator(validator types.Validator, delegatorAddr btypes.Add
 	return nil
 }
 
+//增加validator收益计费点, validator无绑定token时当前计费点收益进入社区奖励池
+func (e Eco) IncrementValidatorPeriod(validator types.Validator) uint64 {
+	communityFee := e.zeroBondFees(validator)
+	endPeriod := e.DistributionMapper.IncrementValidatorPeriod(validator)
+	supply.GetSupplyMapper(e.Context).MoveLockedQOS(supplytypes.Rewards, supplytypes.CommunityPool, communityFee)
+
+	return endPeriod
+}
+
+//修改delegator绑定的token, 同IncrementValidatorPeriod更新社区奖励池锁定的QOS
+func (e Eco) ModifyDelegatorTokens(validator types.Validator, deleAddr btypes.Address, updatedToken, blockHeight uint64) error {
+	communityFee := e.zeroBondFees(validator)
+	if err := e.DistributionMapper.ModifyDelegatorTokens(validator, deleAddr, updatedToken, blockHeight); err != nil {
+		return err
+	}
+	supply.GetSupplyMapper(e.Context).MoveLockedQOS(supplytypes.Rewards, supplytypes.CommunityPool, communityFee)
+
+	return nil
+}
+
+//validator无绑定token时, 增加计费点后进入社区奖励池的收益
+func (e Eco) zeroBondFees(validator types.Validator) btypes.BigInt {
+	if validator.BondTokens != uint64(0) {
+		return btypes.ZeroInt()
+	}
+	vcps, exsits := e.DistributionMapper.GetValidatorCurrentPeriodSummary(validator.GetValidatorAddress())
+	if !exsits {
+		return btypes.ZeroInt()
+	}
+	return vcps.Fees.NilToZero()
+}
+
 type Eco struct {
 	Context            context.Context
 	DistributionMapper *mapper.DistributionMapper
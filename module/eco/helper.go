@@ -6,6 +6,9 @@ import (
 	"github.com/QOSGroup/qbase/baseabci"
 	"github.com/QOSGroup/qbase/context"
 	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/module/eco/mapper"
+	"github.com/QOSGroup/qos/module/supply"
+	supplytypes "github.com/QOSGroup/qos/module/supply/types"
 	qtypes "github.com/QOSGroup/qos/types"
 )
 
@@ -13,7 +16,7 @@ func IncrAccountQOS(ctx context.Context, addr btypes.Address, amount btypes.BigI
 	accountMapper := baseabci.GetAccountMapper(ctx)
 
 	acc := accountMapper.GetAccount(addr)
-	if qosAcc, ok := acc.(*qtypes.QOSAccount); ok {
+	if qosAcc, ok := qtypes.ToQOSAccount(acc); ok {
 		err := qosAcc.SetQOS(qosAcc.GetQOS().NilToZero().Add(amount))
 		if err != nil {
 			return err
@@ -25,14 +28,16 @@ func IncrAccountQOS(ctx context.Context, addr btypes.Address, amount btypes.BigI
 	return fmt.Errorf("addr: %s not a QOSAccount", addr)
 }
 
+//扣减账户QOS, 锁仓账户只能扣减已释放的QOS
 func DecrAccountQOS(ctx context.Context, addr btypes.Address, amount btypes.BigInt) error {
 	accountMapper := baseabci.GetAccountMapper(ctx)
 
 	acc := accountMapper.GetAccount(addr)
-	if qosAcc, ok := acc.(*qtypes.QOSAccount); ok {
+	if qosAcc, ok := qtypes.ToQOSAccount(acc); ok {
 		current := qosAcc.GetQOS().NilToZero()
-		if current.LT(amount) {
-			return fmt.Errorf("addr: %s has not much OQS to decrease. expect: %d , actual: %d", addr, amount, current)
+		spendable := qtypes.SpendableQOS(acc, ctx.BlockHeader().Time.UTC())
+		if spendable.LT(amount) {
+			return fmt.Errorf("addr: %s has not much OQS to decrease. expect: %d , actual: %d", addr, amount, spendable)
 		}
 
 		err := qosAcc.SetQOS(current.Sub(amount))
@@ -45,3 +50,48 @@ func DecrAccountQOS(ctx context.Context, addr btypes.Address, amount btypes.BigI
 
 	return fmt.Errorf("addr: %s not a QOSAccount", addr)
 }
+
+//扣减委托的QOS并计入绑定的QOS, 锁仓账户可委托未释放的QOS
+func DelegateAccountQOS(ctx context.Context, addr btypes.Address, amount btypes.BigInt) error {
+	accountMapper := baseabci.GetAccountMapper(ctx)
+
+	acc := accountMapper.GetAccount(addr)
+	if qosAcc, ok := qtypes.ToQOSAccount(acc); ok {
+		current := qosAcc.GetQOS().NilToZero()
+		if current.LT(amount) {
+			return fmt.Errorf("addr: %s has not much OQS to delegate. expect: %d , actual: %d", addr, amount, current)
+		}
+
+		if err := qosAcc.SetQOS(current.Sub(amount)); err != nil {
+			return err
+		}
+		if vacc, ok := acc.(qtypes.VestingAccount); ok {
+			vacc.TrackDelegation(ctx.BlockHeader().Time.UTC(), amount)
+		}
+		accountMapper.SetAccount(acc)
+		supply.GetSupplyMapper(ctx).LockQOS(supplytypes.Bonded, amount)
+		return nil
+	}
+
+	return fmt.Errorf("addr: %s not a QOSAccount", addr)
+}
+
+//返还解除委托的QOS至delegator收益提取地址, 锁仓账户返还至账户本身以保持锁仓
+func ReturnUnbondQOS(ctx context.Context, deleAddr btypes.Address, amount btypes.BigInt) error {
+	accountMapper := baseabci.GetAccountMapper(ctx)
+
+	acc := accountMapper.GetAccount(deleAddr)
+	if vacc, ok := acc.(qtypes.VestingAccount); ok {
+		vacc.TrackUndelegation(amount)
+		qosAcc := vacc.GetQOSAccount()
+		if err := qosAcc.SetQOS(qosAcc.GetQOS().Add(amount)); err != nil {
+			return err
+		}
+		accountMapper.SetAccount(acc)
+	} else if err := IncrAccountQOS(ctx, mapper.GetDistributionMapper(ctx).GetDelegatorWithdrawAddress(deleAddr), amount); err != nil {
+		return err
+	}
+
+	supply.GetSupplyMapper(ctx).UnlockQOS(supplytypes.Unbonding, amount)
+	return nil
+}
@@ -66,6 +66,55 @@ func (mapper *DelegationMapper) RemoveDelegatorUnbondingQOSatHeight(height uint6
 	mapper.Del(ecotypes.BuildUnbondingDelegationByHeightDelKey(height, delAddr))
 }
 
+//validator维度记录的unbond信息, 在validator作恶时用于惩罚解绑中的QOS
+func (mapper *DelegationMapper) AddValidatorUnbondingQOSatHeight(height uint64, valAddr, delAddr btypes.Address, createHeight, addAmount uint64) {
+	info, exist := mapper.GetValidatorUnbondingQOSatHeight(height, valAddr, delAddr)
+	if exist {
+		addAmount += info.Amount
+	}
+	mapper.SetValidatorUnbondingQOSatHeight(height, valAddr, delAddr, ecotypes.NewUnbondingDelegationInfo(createHeight, addAmount))
+}
+
+func (mapper *DelegationMapper) SetValidatorUnbondingQOSatHeight(height uint64, valAddr, delAddr btypes.Address, info ecotypes.UnbondingDelegationInfo) {
+	mapper.Set(ecotypes.BuildUnbondingDelegationByHeightValDelKey(height, valAddr, delAddr), info)
+}
+
+func (mapper *DelegationMapper) GetValidatorUnbondingQOSatHeight(height uint64, valAddr, delAddr btypes.Address) (info ecotypes.UnbondingDelegationInfo, exist bool) {
+	exist = mapper.Get(ecotypes.BuildUnbondingDelegationByHeightValDelKey(height, valAddr, delAddr), &info)
+	return
+}
+
+//删除某高度下validator维度的unbond信息
+func (mapper *DelegationMapper) RemoveValidatorUnbondingQOSatHeight(height uint64) {
+	iter := store.KVStorePrefixIterator(mapper.GetStore(), ecotypes.BuildUnbondingDelegationByHeightValPrefix(height))
+	defer iter.Close()
+
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+
+	for _, key := range keys {
+		mapper.Del(key)
+	}
+}
+
+//遍历返还高度大于height的validator维度unbond信息
+func (mapper *DelegationMapper) IterateValidatorUnbondingAfterHeight(height uint64, fn func(uint64, btypes.Address, btypes.Address, ecotypes.UnbondingDelegationInfo)) {
+	startKey := ecotypes.BuildUnbondingDelegationByHeightValPrefix(height + 1)
+	endKey := store.PrefixEndBytes(ecotypes.ValidatorUnbondingQOSatHeightKey)
+
+	iter := mapper.GetStore().Iterator(startKey, endKey)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		unbondHeight, valAddr, deleAddr := ecotypes.GetUnbondingDelegationHeightValDelAddress(iter.Key())
+		var info ecotypes.UnbondingDelegationInfo
+		mapper.DecodeObject(iter.Value(), &info)
+		fn(unbondHeight, valAddr, deleAddr, info)
+	}
+}
+
 func (mapper *DelegationMapper) IterateDelegationsValDeleAddr(valAddr btypes.Address, fn func(btypes.Address, btypes.Address)) {
 
 	var prefixKey []byte
@@ -86,6 +135,30 @@ func (mapper *DelegationMapper) IterateDelegationsValDeleAddr(valAddr btypes.Add
 	}
 }
 
+//validator更换共识公钥后, 将委托及validator维度unbond信息转移至新地址
+func (mapper *DelegationMapper) ChangeValidatorAddress(oldAddr, newAddr btypes.Address) {
+	var deleAddrs []btypes.Address
+	mapper.IterateDelegationsValDeleAddr(oldAddr, func(_ btypes.Address, deleAddr btypes.Address) {
+		deleAddrs = append(deleAddrs, deleAddr)
+	})
+
+	for _, deleAddr := range deleAddrs {
+		info, exist := mapper.GetDelegationInfo(deleAddr, oldAddr)
+		mapper.DelDelegationInfo(deleAddr, oldAddr)
+		if exist {
+			info.ValidatorAddr = newAddr
+			mapper.SetDelegationInfo(info)
+		}
+	}
+
+	//key: prefix + height + validator add + delegator add
+	kvStore := mapper.GetStore()
+	keys := collectKeys(kvStore, ecotypes.ValidatorUnbondingQOSatHeightKey, func(key []byte) bool {
+		return hasAddrAt(key, 9, oldAddr)
+	})
+	rekey(kvStore, keys, func(key []byte) []byte { return replaceAddr(key, 9, newAddr) })
+}
+
 //------------------------------genesisi export
 
 func (mapper *DelegationMapper) IterateDelegationsInfo(deleAddr btypes.Address, fn func(ecotypes.DelegationInfo)) {
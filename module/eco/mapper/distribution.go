@@ -285,6 +285,64 @@ func (mapper *DistributionMapper) SetCommunityFeePool(communityFee btypes.BigInt
 	mapper.Set(types.BuildCommunityFeePoolKey(), communityFee)
 }
 
+//保存社区奖励池支出记录, 返回记录id
+func (mapper *DistributionMapper) AddCommunityPoolSpend(spend types.CommunityPoolSpend) uint64 {
+	var lastID uint64
+	mapper.Get(types.BuildLastCommunityPoolSpendIDKey(), &lastID)
+
+	spend.ID = lastID + 1
+	mapper.SetCommunityPoolSpend(spend)
+	mapper.Set(types.BuildLastCommunityPoolSpendIDKey(), spend.ID)
+
+	return spend.ID
+}
+
+func (mapper *DistributionMapper) SetCommunityPoolSpend(spend types.CommunityPoolSpend) {
+	mapper.Set(types.BuildCommunityPoolSpendKey(spend.ID), spend)
+}
+
+func (mapper *DistributionMapper) IteratorCommunityPoolSpends(fn func(types.CommunityPoolSpend)) {
+	iter := store.KVStorePrefixIterator(mapper.GetStore(), types.GetCommunityPoolSpendPrefixKey())
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var spend types.CommunityPoolSpend
+		mapper.DecodeObject(iter.Value(), &spend)
+		fn(spend)
+	}
+}
+
+//delegator收益提取地址, 未设置时返回delegator地址
+func (mapper *DistributionMapper) GetDelegatorWithdrawAddress(deleAddr btypes.Address) btypes.Address {
+	var withdrawAddr btypes.Address
+	exsits := mapper.Get(types.BuildDelegatorWithdrawAddressKey(deleAddr), &withdrawAddr)
+	if !exsits {
+		return deleAddr
+	}
+	return withdrawAddr
+}
+
+//设置delegator收益提取地址, 与delegator地址相同时删除
+func (mapper *DistributionMapper) SetDelegatorWithdrawAddress(deleAddr, withdrawAddr btypes.Address) {
+	key := types.BuildDelegatorWithdrawAddressKey(deleAddr)
+	if deleAddr.EqualsTo(withdrawAddr) {
+		mapper.Del(key)
+		return
+	}
+	mapper.Set(key, withdrawAddr)
+}
+
+func (mapper *DistributionMapper) IteratorDelegatorsWithdrawAddress(fn func(deleAddr, withdrawAddr btypes.Address)) {
+	iter := store.KVStorePrefixIterator(mapper.GetStore(), types.GetDelegatorWithdrawAddressPrefixKey())
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var withdrawAddr btypes.Address
+		mapper.DecodeObject(iter.Value(), &withdrawAddr)
+		fn(types.GetDelegatorWithdrawAddressAddr(iter.Key()), withdrawAddr)
+	}
+}
+
 func (mapper *DistributionMapper) GetValidatorHistoryPeriodSummary(valAddr btypes.Address, period uint64) (frac qtypes.Fraction) {
 	key := types.BuildValidatorHistoryPeriodSummaryKey(valAddr, period)
 	exsits := mapper.Get(key, &frac)
@@ -327,6 +385,26 @@ func (mapper *DistributionMapper) ClearPreDistributionQOS() {
 	mapper.Set(types.BuildBlockDistributionKey(), btypes.ZeroInt())
 }
 
+//validator更换共识公钥后, 将收益分配相关信息转移至新地址
+func (mapper *DistributionMapper) ChangeValidatorAddress(oldAddr, newAddr btypes.Address) {
+	kvStore := mapper.GetStore()
+	replace := func(key []byte) []byte { return replaceAddr(key, 1, newAddr) }
+
+	rekey(kvStore, collectKeys(kvStore, types.BuildValidatorCurrentPeriodSummaryKey(oldAddr), nil), replace)
+	rekey(kvStore, collectKeys(kvStore, append(types.GetValidatorHistoryPeriodSummaryPrefixKey(), oldAddr...), nil), replace)
+	rekey(kvStore, collectKeys(kvStore, append(types.GetDelegatorEarningsStartInfoPrefixKey(), oldAddr...), nil), replace)
+
+	//key: prefix + height + validatorAddr + delegatorAddr
+	keys := collectKeys(kvStore, types.GetDelegatorPeriodIncomePrefixKey(), func(key []byte) bool {
+		return hasAddrAt(key, 9, oldAddr)
+	})
+	rekey(kvStore, keys, func(key []byte) []byte { return replaceAddr(key, 9, newAddr) })
+
+	if mapper.GetLastBlockProposer().EqualsTo(oldAddr) {
+		mapper.SetLastBlockProposer(newAddr)
+	}
+}
+
 //------------------------ genesis export
 
 func (mapper *DistributionMapper) IteratorValidatorsHistoryPeriod(fn func(valAddr btypes.Address, period uint64, frac qtypes.Fraction)) {
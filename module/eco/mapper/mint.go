@@ -81,7 +81,14 @@ func (mapper *MintMapper) SetParams(config ecotypes.MintParams) {
 }
 
 func (mapper *MintMapper) AddInflationPhrase(phrase ecotypes.InflationPhrase) {
+	mapper.Set(buildInflationPhraseKey(phrase), phrase)
+}
+
+func (mapper *MintMapper) DelInflationPhrase(phrase ecotypes.InflationPhrase) {
+	mapper.Del(buildInflationPhraseKey(phrase))
+}
 
+func buildInflationPhraseKey(phrase ecotypes.InflationPhrase) []byte {
 	endsec := uint64(phrase.EndTime.UTC().Unix())
 
 	secBytes := make([]byte, 8)
@@ -93,7 +100,7 @@ func (mapper *MintMapper) AddInflationPhrase(phrase ecotypes.InflationPhrase) {
 	copy(bz[0:keylen], ecotypes.BuildMintParamsKey())
 	copy(bz[keylen:keylen+8], secBytes)
 
-	mapper.Set(bz, phrase)
+	return bz
 }
 
 // 设置Params
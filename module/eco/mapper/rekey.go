@@ -0,0 +1,42 @@
+package mapper
+
+import (
+	"bytes"
+
+	"github.com/QOSGroup/qbase/store"
+)
+
+//遍历prefix下的所有key, filter为空时返回全部
+func collectKeys(kvStore store.KVStore, prefix []byte, filter func(key []byte) bool) (keys [][]byte) {
+	iter := store.KVStorePrefixIterator(kvStore, prefix)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		if filter == nil || filter(iter.Key()) {
+			keys = append(keys, iter.Key())
+		}
+	}
+	return
+}
+
+//将keys对应的数据原样转移至newKey(key)下
+func rekey(kvStore store.KVStore, keys [][]byte, newKey func(key []byte) []byte) {
+	for _, key := range keys {
+		value := kvStore.Get(key)
+		kvStore.Delete(key)
+		kvStore.Set(newKey(key), value)
+	}
+}
+
+//替换key中[start, start+len(newAddr))位置的地址, 新旧地址长度相同
+func replaceAddr(key []byte, start int, newAddr []byte) []byte {
+	bz := make([]byte, 0, len(key))
+	bz = append(bz, key[:start]...)
+	bz = append(bz, newAddr...)
+	return append(bz, key[start+len(newAddr):]...)
+}
+
+//key中[start, start+len(addr))位置是否为addr
+func hasAddrAt(key []byte, start int, addr []byte) bool {
+	return len(key) >= start+len(addr) && bytes.Equal(key[start:start+len(addr)], addr)
+}
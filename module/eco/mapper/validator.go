@@ -9,6 +9,7 @@ import (
 	"github.com/QOSGroup/qbase/store"
 	btypes "github.com/QOSGroup/qbase/types"
 	ecotypes "github.com/QOSGroup/qos/module/eco/types"
+	"github.com/tendermint/tendermint/crypto"
 )
 
 type ValidatorMapper struct {
@@ -40,6 +41,11 @@ func (mapper *ValidatorMapper) CreateValidator(validator ecotypes.Validator) {
 	mapper.Set(ecotypes.BuildValidatorByVotePower(validator.BondTokens, valAddr), true)
 }
 
+//更新validator基本信息, 不修改状态及索引
+func (mapper *ValidatorMapper) UpdateValidator(validator ecotypes.Validator) {
+	mapper.Set(ecotypes.BuildValidatorKey(validator.GetValidatorAddress()), validator)
+}
+
 func (mapper *ValidatorMapper) ChangeValidatorBondTokens(validator ecotypes.Validator, updatedTokens uint64) {
 	valAddr := validator.GetValidatorAddress()
 	mapper.Del(ecotypes.BuildValidatorByVotePower(validator.BondTokens, valAddr))
@@ -140,6 +146,47 @@ func (mapper *ValidatorMapper) GetValidatorByOwner(owner btypes.Address) (valida
 	return mapper.GetValidator(valAddress)
 }
 
+//更换validator共识公钥: 按新地址重建validator索引, 并记录旧地址到新地址的映射
+func (mapper *ValidatorMapper) ChangeValidatorPubKey(validator ecotypes.Validator, newPubKey crypto.PubKey) ecotypes.Validator {
+	oldAddr := validator.GetValidatorAddress()
+	mapper.Del(ecotypes.BuildValidatorKey(oldAddr))
+	if validator.IsActive() {
+		mapper.Del(ecotypes.BuildValidatorByVotePower(validator.BondTokens, oldAddr))
+	} else {
+		mapper.Del(ecotypes.BuildInactiveValidatorKeyByTime(validator.InactiveTime, oldAddr))
+	}
+
+	validator.ValidatorPubKey = newPubKey
+	newAddr := validator.GetValidatorAddress()
+	mapper.Set(ecotypes.BuildValidatorKey(newAddr), validator)
+	mapper.Set(ecotypes.BuildOwnerWithValidatorKey(validator.Owner), newAddr)
+	if validator.IsActive() {
+		mapper.Set(ecotypes.BuildValidatorByVotePower(validator.BondTokens, newAddr), true)
+	} else {
+		mapper.Set(ecotypes.BuildInactiveValidatorKeyByTime(validator.InactiveTime, newAddr), validator.InactiveTime.UTC().Unix())
+	}
+
+	mapper.Set(ecotypes.BuildRotatedValidatorKey(oldAddr), newAddr)
+
+	return validator
+}
+
+//地址是否为validator更换共识公钥前使用的地址
+func (mapper *ValidatorMapper) IsRotatedValidatorAddress(valAddress btypes.Address) bool {
+	return mapper.Get(ecotypes.BuildRotatedValidatorKey(valAddress), &(btypes.Address{}))
+}
+
+//返回validator当前地址. 共识公钥更换后tendermint仍会在一段时间内使用旧地址投票, 需转换为新地址
+func (mapper *ValidatorMapper) GetLatestValidatorAddress(valAddress btypes.Address) btypes.Address {
+	for {
+		var newAddr btypes.Address
+		if !mapper.Get(ecotypes.BuildRotatedValidatorKey(valAddress), &newAddr) {
+			return valAddress
+		}
+		valAddress = newAddr
+	}
+}
+
 func (mapper *ValidatorMapper) SetParams(params ecotypes.StakeParams) {
 	mapper.Set(ecotypes.BuildStakeParamsKey(), params)
 }
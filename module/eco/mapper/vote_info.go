@@ -81,6 +81,15 @@ func (mapper *VoteInfoMapper) ClearValidatorVoteInfoInWindow(valAddr btypes.Addr
 	}
 }
 
+//validator更换共识公钥后, 将投票信息转移至新地址
+func (mapper *VoteInfoMapper) ChangeValidatorAddress(oldAddr, newAddr btypes.Address) {
+	kvStore := mapper.GetStore()
+	replace := func(key []byte) []byte { return replaceAddr(key, 1, newAddr) }
+
+	rekey(kvStore, collectKeys(kvStore, types.BuildValidatorVoteInfoKey(oldAddr), nil), replace)
+	rekey(kvStore, collectKeys(kvStore, types.BuildValidatorVoteInfoInWindowPrefixKey(oldAddr), nil), replace)
+}
+
 //-------------------------genesis export
 
 func (mapper *VoteInfoMapper) IterateVoteInfos(fn func(btypes.Address, types.ValidatorVoteInfo)) {
@@ -22,6 +22,16 @@ var (
 	//value: bigint
 	blockDistributionKey = []byte{0x04}
 
+	//社区奖励池支出记录,key = prefix + id
+	//value: CommunityPoolSpend
+	communityPoolSpendPrefixKey = []byte{0x05}
+	//最新社区奖励池支出记录id
+	//value: uint64
+	lastCommunityPoolSpendIDKey = []byte{0x06}
+	//delegator收益提取地址,key = prefix + delegatorAddr, 未设置时为delegator地址
+	//value: address
+	delegatorWithdrawAddressPrefixKey = []byte{0x07}
+
 	//delegator收益计算信息,key = prefix+validatorAddr+delegatorAddr
 	//value: delegatorEarningsStartInfo
 	delegatorEarningsStartInfoPrefixKey = []byte{0x12}
@@ -55,6 +65,35 @@ func BuildBlockDistributionKey() []byte {
 	return blockDistributionKey
 }
 
+func BuildCommunityPoolSpendKey(id uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, id)
+	return append(communityPoolSpendPrefixKey, b...)
+}
+
+func GetCommunityPoolSpendPrefixKey() []byte {
+	return communityPoolSpendPrefixKey
+}
+
+func BuildLastCommunityPoolSpendIDKey() []byte {
+	return lastCommunityPoolSpendIDKey
+}
+
+func BuildDelegatorWithdrawAddressKey(delegatorAddr btypes.Address) []byte {
+	return append(delegatorWithdrawAddressPrefixKey, delegatorAddr...)
+}
+
+func GetDelegatorWithdrawAddressPrefixKey() []byte {
+	return delegatorWithdrawAddressPrefixKey
+}
+
+func GetDelegatorWithdrawAddressAddr(key []byte) btypes.Address {
+	if len(key) != (1 + AddrLen) {
+		panic("invalid DelegatorWithdrawAddressKey length")
+	}
+	return btypes.Address(key[1:])
+}
+
 func GetValidatorCurrentPeriodSummaryPrefixKey() []byte {
 	return validatorCurrentPeriodSummaryPrefixKey
 }
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"reflect"
+
+	"github.com/QOSGroup/qbase/txs"
+)
+
+// 交易gas参数
+type TxGas struct {
+	TxType     string `json:"tx_type"`      // 交易类型, 如TxTransfer
+	BaseGas    uint64 `json:"base_gas"`     // 基础gas
+	PerItemGas uint64 `json:"per_item_gas"` // 每项gas, 项见GasTx.GasItems
+}
+
+// 交易gas参数表: gas = base_gas + per_item_gas * 项数 + per_signer_gas * 签名账户数 + per_byte_gas * 签名数据字节数
+type GasSchedule struct {
+	Txs          []TxGas `json:"txs"`            // 各类交易gas参数
+	Default      TxGas   `json:"default"`        // 未在txs中配置的交易使用的gas参数
+	PerSignerGas uint64  `json:"per_signer_gas"` // 每个签名账户gas
+	PerByteGas   uint64  `json:"per_byte_gas"`   // 签名数据每字节gas
+}
+
+// 按gas参数表计算gas的交易
+// 交易gas只在gasHandler中按链上distribution参数中的gas参数表计算并消耗, CheckTx时校验MaxGas不小于该gas;
+// ITx.CalcGas返回0, gas上限即为MaxGas
+type GasTx interface {
+	txs.ITx
+	GasItems() uint64 // 按项计费的数量, 如转账接收账户数、联盟币初始账户数
+}
+
+func DefaultGasSchedule() GasSchedule {
+	return GasSchedule{
+		Txs: []TxGas{
+			{TxType: "TxTransfer", BaseGas: 200, PerItemGas: 100},
+			{TxType: "TxCreateQSC", BaseGas: 1000, PerItemGas: 100},
+			{TxType: "TxInitQCP", BaseGas: 1000},
+			{TxType: "TxCreateValidator", BaseGas: 1000},
+			{TxType: "TxSubmitProposal", BaseGas: 1000},
+		},
+		Default:      TxGas{BaseGas: 200, PerItemGas: 100},
+		PerSignerGas: 100,
+		PerByteGas:   1,
+	}
+}
+
+// 交易类型名, 即交易结构体名
+func GetGasTxType(tx txs.ITx) string {
+	return reflect.Indirect(reflect.ValueOf(tx)).Type().Name()
+}
+
+func (schedule GasSchedule) GetTxGas(txType string) TxGas {
+	for _, txGas := range schedule.Txs {
+		if txGas.TxType == txType {
+			return txGas
+		}
+	}
+	return schedule.Default
+}
+
+func (schedule GasSchedule) CalcTxGas(tx GasTx) uint64 {
+	txGas := schedule.GetTxGas(GetGasTxType(tx))
+	return txGas.BaseGas +
+		txGas.PerItemGas*tx.GasItems() +
+		schedule.PerSignerGas*uint64(len(tx.GetSigner())) +
+		schedule.PerByteGas*uint64(len(tx.GetSignData()))
+}
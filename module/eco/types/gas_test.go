@@ -0,0 +1,45 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/QOSGroup/qbase/context"
+	"github.com/QOSGroup/qbase/txs"
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/stretchr/testify/require"
+)
+
+type TxTest struct {
+	Signers []btypes.Address
+	Items   uint64
+}
+
+func (tx TxTest) ValidateData(ctx context.Context) error { return nil }
+func (tx TxTest) Exec(ctx context.Context) (btypes.Result, *txs.TxQcp) {
+	return btypes.Result{}, nil
+}
+func (tx TxTest) GetSigner() []btypes.Address { return tx.Signers }
+func (tx TxTest) CalcGas() btypes.BigInt      { return btypes.ZeroInt() }
+func (tx TxTest) GetGasPayer() btypes.Address { return tx.Signers[0] }
+func (tx TxTest) GetSignData() []byte         { return []byte("test") }
+func (tx TxTest) GasItems() uint64            { return tx.Items }
+
+func TestGasSchedule(t *testing.T) {
+	schedule := GasSchedule{
+		Txs:          []TxGas{{TxType: "TxTest", BaseGas: 1000, PerItemGas: 10}},
+		Default:      TxGas{BaseGas: 100},
+		PerSignerGas: 20,
+		PerByteGas:   2,
+	}
+
+	tx := TxTest{Signers: []btypes.Address{btypes.Address("signer1"), btypes.Address("signer2")}, Items: 3}
+	require.Equal(t, "TxTest", GetGasTxType(tx))
+	require.Equal(t, "TxTest", GetGasTxType(&tx))
+
+	// 1000 + 10*3 + 20*2 + 2*4
+	require.Equal(t, uint64(1078), schedule.CalcTxGas(tx))
+
+	schedule.Txs = nil
+	// 100 + 0*3 + 20*2 + 2*4
+	require.Equal(t, uint64(148), schedule.CalcTxGas(tx))
+}
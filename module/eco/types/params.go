@@ -3,23 +3,29 @@ package types
 import (
 	"time"
 
+	btypes "github.com/QOSGroup/qbase/types"
 	qtypes "github.com/QOSGroup/qos/types"
 )
 
 type DistributionParams struct {
-	ProposerRewardRate           qtypes.Fraction `json:"proposer_reward_rate"`
-	CommunityRewardRate          qtypes.Fraction `json:"community_reward_rate"`
-	ValidatorCommissionRate      qtypes.Fraction `json:"validator_commission_rate"`
-	DelegatorsIncomePeriodHeight uint64          `json:"delegator_income_period_height"`
-	GasPerUnitCost               uint64          `json:"gas_per_unit_cost"` // how much gas = 1 QOS
+	ProposerRewardRate           qtypes.Fraction  `json:"proposer_reward_rate"`
+	CommunityRewardRate          qtypes.Fraction  `json:"community_reward_rate"`
+	DelegatorsIncomePeriodHeight uint64           `json:"delegator_income_period_height"`
+	GasPerUnitCost               uint64           `json:"gas_per_unit_cost"`              // how much gas = 1 QOS
+	CommunityPoolSpenders        []btypes.Address `json:"community_pool_spenders"`        // 可签署社区奖励池支出的账户
+	CommunityPoolSpendThreshold  uint32           `json:"community_pool_spend_threshold"` // 社区奖励池支出所需最少签名数, 0表示不允许
+	GasSchedule                  GasSchedule      `json:"gas_schedule"`                   // 交易gas参数表
 }
 
 type StakeParams struct {
-	MaxValidatorCnt             uint32 `json:"max_validator_cnt"`
-	ValidatorVotingStatusLen    uint32 `json:"voting_status_len"`
-	ValidatorVotingStatusLeast  uint32 `json:"voting_status_least"`
-	ValidatorSurvivalSecs       uint32 `json:"survival_secs"`
-	DelegatorUnbondReturnHeight uint32 `json:"unbond_return_height"`
+	MaxValidatorCnt             uint32          `json:"max_validator_cnt"`
+	ValidatorVotingStatusLen    uint32          `json:"voting_status_len"`
+	ValidatorVotingStatusLeast  uint32          `json:"voting_status_least"`
+	ValidatorSurvivalSecs       uint32          `json:"survival_secs"`
+	DelegatorUnbondReturnHeight uint32          `json:"unbond_return_height"`
+	SlashFractionDoubleSign     qtypes.Fraction `json:"slash_fraction_double_sign"` // 双签惩罚比例
+	SlashFractionDowntime       qtypes.Fraction `json:"slash_fraction_downtime"`    // 漏签惩罚比例
+	ValidatorJailSecs           uint32          `json:"jail_secs"`                  // 漏签后最短禁止激活时长
 }
 
 type MintParams struct {
@@ -36,13 +42,14 @@ func DefaultDistributionParams() DistributionParams {
 	return DistributionParams{
 		ProposerRewardRate:           qtypes.NewFraction(int64(4), int64(100)), // 4%
 		CommunityRewardRate:          qtypes.NewFraction(int64(1), int64(100)), // 1%
-		ValidatorCommissionRate:      qtypes.NewFraction(int64(1), int64(100)), // 1%
 		DelegatorsIncomePeriodHeight: uint64(10),
 		GasPerUnitCost:               uint64(10),
+		GasSchedule:                  DefaultGasSchedule(),
 	}
 }
 
-func NewStakeParams(maxValidatorCnt, validatorVotingStatusLen, validatorVotingStatusLeast, validatorSurvivalSecs, delegatorUnbondReturnHeight uint32) StakeParams {
+func NewStakeParams(maxValidatorCnt, validatorVotingStatusLen, validatorVotingStatusLeast, validatorSurvivalSecs, delegatorUnbondReturnHeight uint32,
+	slashFractionDoubleSign, slashFractionDowntime qtypes.Fraction, validatorJailSecs uint32) StakeParams {
 
 	return StakeParams{
 		MaxValidatorCnt:             maxValidatorCnt,
@@ -50,11 +57,17 @@ func NewStakeParams(maxValidatorCnt, validatorVotingStatusLen, validatorVotingSt
 		ValidatorVotingStatusLeast:  validatorVotingStatusLeast,
 		ValidatorSurvivalSecs:       validatorSurvivalSecs,
 		DelegatorUnbondReturnHeight: delegatorUnbondReturnHeight,
+		SlashFractionDoubleSign:     slashFractionDoubleSign,
+		SlashFractionDowntime:       slashFractionDowntime,
+		ValidatorJailSecs:           validatorJailSecs,
 	}
 }
 
 func DefaultStakeParams() StakeParams {
-	return NewStakeParams(10, 100, 50, 600, 10)
+	return NewStakeParams(10, 100, 50, 600, 10,
+		qtypes.NewFraction(int64(5), int64(100)), // 5%
+		qtypes.NewFraction(int64(1), int64(100)), // 1%
+		300)
 }
 
 func NewMintParams(phrases []InflationPhrase) MintParams {
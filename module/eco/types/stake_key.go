@@ -23,6 +23,8 @@ const (
 	Distribution        = "distribution"
 	ValidatorPeriodInfo = "validatorPeriodInfo"
 	DelegatorIncomeInfo = "delegatorIncomeInfo"
+	CommunityPoolSpends = "communityPoolSpends"
+	WithdrawAddress     = "withdrawAddress"
 )
 
 var (
@@ -31,6 +33,7 @@ var (
 	validatorByOwnerKey     = []byte{0x02} // 保存Owner与Validator的映射关系. key: OwnerAddress, value : ValidatorAddress
 	validatorByInactiveKey  = []byte{0x03} // 保存处于`inactive`状态的Validator. key: ValidatorInactiveTime + ValidatorAddress
 	validatorByVotePowerKey = []byte{0x04} // 按VotePower排序的Validator地址,不包含`pending`状态的Validator. key: VotePower + ValidatorAddress
+	validatorRotatedKey     = []byte{0x05} // 更换共识公钥前的Validator地址. key: 旧ValidatorAddress, value: 新ValidatorAddress
 
 	//keys see docs/spec/staking.md
 	validatorVoteInfoKey         = []byte{0x01} // 保存Validator在窗口的统计信息
@@ -39,6 +42,7 @@ var (
 	DelegationByDelValKey            = []byte{0x31} // key: delegator add + validator owner add, value: delegationInfo
 	DelegationByValDelKey            = []byte{0x32} // key: validator owner add + delegator add, value: nil
 	DelegatorUnbondingQOSatHeightKey = []byte{0x41} // key: height + delegator add, value: the amount of qos going to be unbonded on this height
+	ValidatorUnbondingQOSatHeightKey = []byte{0x42} // key: height + validator add + delegator add, value: UnbondingDelegationInfo
 
 	currentValidatorsAddressKey = []byte("currentValidatorsAddressKey")
 
@@ -62,6 +66,10 @@ func BulidValidatorPrefixKey() []byte {
 	return validatorKey
 }
 
+func BuildRotatedValidatorKey(oldValAddress btypes.Address) []byte {
+	return append(validatorRotatedKey, oldValAddress...)
+}
+
 func BuildOwnerWithValidatorKey(ownerAddress btypes.Address) []byte {
 
 	lenz := 1 + len(ownerAddress)
@@ -171,6 +179,33 @@ func GetUnbondingDelegationHeightAddress(key []byte) (height uint64, deleAddr bt
 	return
 }
 
+func BuildUnbondingDelegationByHeightValDelKey(height uint64, valAddr, delAddr btypes.Address) []byte {
+	bz := BuildUnbondingDelegationByHeightValPrefix(height)
+	bz = append(bz, valAddr...)
+	return append(bz, delAddr...)
+}
+
+func BuildUnbondingDelegationByHeightValPrefix(height uint64) []byte {
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, height)
+
+	bz := make([]byte, 0, 1+8+2*AddrLen)
+	bz = append(bz, ValidatorUnbondingQOSatHeightKey...)
+	return append(bz, heightBytes...)
+}
+
+func GetUnbondingDelegationHeightValDelAddress(key []byte) (height uint64, valAddr, deleAddr btypes.Address) {
+
+	if len(key) != (1 + 8 + 2*AddrLen) {
+		panic("invalid UnbondingDelegationByHeightValDelKey length")
+	}
+
+	height = binary.BigEndian.Uint64(key[1:9])
+	valAddr = btypes.Address(key[9 : 9+AddrLen])
+	deleAddr = btypes.Address(key[9+AddrLen:])
+	return
+}
+
 func BuildVoteInfoStoreQueryPath() []byte {
 	return []byte(fmt.Sprintf("/store/%s/key", VoteInfoMapperName))
 }
@@ -224,3 +259,11 @@ func BuildQueryValidatorPeriodInfoCustomQueryPath(owner btypes.Address) string {
 func BuildQueryDelegatorIncomeInfoCustomQueryPath(delegator, owner btypes.Address) string {
 	return fmt.Sprintf("custom/%s/%s/%s/%s", Distribution, DelegatorIncomeInfo, delegator.String(), owner.String())
 }
+
+func BuildQueryCommunityPoolSpendsCustomQueryPath() string {
+	return fmt.Sprintf("custom/%s/%s", Distribution, CommunityPoolSpends)
+}
+
+func BuildQueryWithdrawAddressCustomQueryPath(delegator btypes.Address) string {
+	return fmt.Sprintf("custom/%s/%s/%s", Distribution, WithdrawAddress, delegator.String())
+}
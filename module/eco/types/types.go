@@ -19,6 +19,19 @@ func NewDelegationInfo(delAddr btypes.Address, valAddr btypes.Address, amount ui
 	return DelegationInfo{delAddr, valAddr, amount, isCompound}
 }
 
+//UnbondingDelegationInfo delegator从validator解绑的QOS信息, validator作恶时按解绑高度惩罚
+type UnbondingDelegationInfo struct {
+	CreateHeight uint64 `json:"create_height"` // 解绑发生高度
+	Amount       uint64 `json:"amount"`        // 待返还QOS数量
+}
+
+func NewUnbondingDelegationInfo(createHeight, amount uint64) UnbondingDelegationInfo {
+	return UnbondingDelegationInfo{
+		CreateHeight: createHeight,
+		Amount:       amount,
+	}
+}
+
 //DelegatorEarningsStartInfo delegator计算收益信息
 type DelegatorEarningsStartInfo struct {
 	PreviousPeriod        uint64        `json:"previous_period"`
@@ -49,3 +62,14 @@ func NewValidatorVoteInfo(startHeight, indexOffset, missedBlocksCounter uint64)
 		MissedBlocksCounter: missedBlocksCounter,
 	}
 }
+
+//CommunityPoolSpend 社区奖励池支出记录
+type CommunityPoolSpend struct {
+	ID         uint64           `json:"id"`
+	Height     uint64           `json:"height"`
+	Receiver   btypes.Address   `json:"receiver"`
+	Amount     uint64           `json:"amount"`
+	Memo       string           `json:"memo"`
+	Approvers  []btypes.Address `json:"approvers"`   // 多签支出时的签名账户
+	ProposalID uint64           `json:"proposal_id"` // 治理提议支出时的提议ID
+}
@@ -1,11 +1,14 @@
 package types
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/tendermint/tendermint/crypto"
 
 	btypes "github.com/QOSGroup/qbase/types"
+	qtypes "github.com/QOSGroup/qos/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 	tmtypes "github.com/tendermint/tendermint/types"
 )
@@ -23,22 +26,96 @@ const (
 	Revoke        InactiveCode = iota // 2
 	MissVoteBlock                     // 3
 	MaxValidator                      // 4
+	DoubleSign                        // 5: 双签作恶, 不可再激活
 )
 
+//佣金比例两次修改之间的最短间隔
+const CommissionUpdateInterval = 24 * time.Hour
+
 type Validator struct {
 	Name            string         `json:"name"`
 	Owner           btypes.Address `json:"owner"`
 	ValidatorPubKey crypto.PubKey  `json:"pub_key"`
 	BondTokens      uint64         `json:"bond_tokens"` //不能超过int64最大值
 	Description     string         `json:"description"`
+	Website         string         `json:"website"`
+	Identity        string         `json:"identity"` // keybase id等身份标识
+	SecurityContact string         `json:"security_contact"`
 
 	Status         int8         `json:"status"`
 	InactiveCode   InactiveCode `json:"inactive_code"`
 	InactiveTime   time.Time    `json:"inactive_time"`
 	InactiveHeight uint64       `json:"inactive_height"`
+	JailedUntil    time.Time    `json:"jailed_until"` // 漏签被禁止激活的截止时间
 
 	MinPeriod  uint64 `json:"min_period"`
 	BondHeight uint64 `json:"bond_height"`
+
+	Commission Commission `json:"commission"` // 佣金设置
+}
+
+//Commission validator佣金设置, MaxRate和MaxChangeRate创建后不可修改
+type Commission struct {
+	Rate          qtypes.Fraction `json:"rate"`            // 当前佣金比例
+	MaxRate       qtypes.Fraction `json:"max_rate"`        // 佣金比例上限
+	MaxChangeRate qtypes.Fraction `json:"max_change_rate"` // 单次(24h内)佣金比例最大变化量
+	UpdateTime    time.Time       `json:"update_time"`     // 最近一次修改佣金比例时间
+}
+
+func NewCommission(rate, maxRate, maxChangeRate qtypes.Fraction, updateTime time.Time) Commission {
+	return Commission{
+		Rate:          rate,
+		MaxRate:       maxRate,
+		MaxChangeRate: maxChangeRate,
+		UpdateTime:    updateTime,
+	}
+}
+
+func DefaultCommission(updateTime time.Time) Commission {
+	return NewCommission(
+		qtypes.NewFraction(int64(1), int64(100)),  // 1%
+		qtypes.NewFraction(int64(20), int64(100)), // 20%
+		qtypes.NewFraction(int64(1), int64(100)),  // 1%
+		updateTime)
+}
+
+func (c Commission) IsNil() bool {
+	return c.Rate.Value.IsNil() || c.MaxRate.Value.IsNil() || c.MaxChangeRate.Value.IsNil()
+}
+
+//Validate 校验: 0 <= Rate <= MaxRate <= 1, 0 <= MaxChangeRate <= MaxRate
+func (c Commission) Validate() error {
+	if c.IsNil() {
+		return errors.New("commission rates must be set")
+	}
+	if !validFraction(c.MaxRate) {
+		return errors.New("max rate must be between 0 and 1")
+	}
+	if !validFraction(c.Rate) || c.Rate.Value.GT(c.MaxRate.Value) {
+		return errors.New("rate must be between 0 and max rate")
+	}
+	if !validFraction(c.MaxChangeRate) || c.MaxChangeRate.Value.GT(c.MaxRate.Value) {
+		return errors.New("max change rate must be between 0 and max rate")
+	}
+	return nil
+}
+
+//ValidateNewRate 校验新佣金比例: 距上次修改不少于24h, 不超过MaxRate, 变化量不超过MaxChangeRate
+func (c Commission) ValidateNewRate(newRate qtypes.Fraction, now time.Time) error {
+	if now.UTC().Sub(c.UpdateTime) < CommissionUpdateInterval {
+		return fmt.Errorf("commission can be changed only once every %s, last update: %s", CommissionUpdateInterval, c.UpdateTime)
+	}
+	if !validFraction(newRate) || newRate.Value.GT(c.MaxRate.Value) {
+		return fmt.Errorf("commission rate must be between 0 and max rate %s", c.MaxRate.Value)
+	}
+	if newRate.Value.Sub(c.Rate.Value).Abs().GT(c.MaxChangeRate.Value) {
+		return fmt.Errorf("commission rate change exceeds max change rate %s", c.MaxChangeRate.Value)
+	}
+	return nil
+}
+
+func validFraction(f qtypes.Fraction) bool {
+	return !f.Value.IsNil() && !f.Value.IsNegative() && !f.Value.GT(qtypes.OneDec())
 }
 
 func (val Validator) GetValidatorAddress() btypes.Address {
@@ -65,3 +142,13 @@ func (val Validator) ToABCIValidatorUpdate(isRemoved bool) (abciVal abci.Validat
 func (val Validator) IsActive() bool {
 	return val.Status == Active
 }
+
+//漏签的validator在JailedUntil之前不能激活
+func (val Validator) IsJailed(now time.Time) bool {
+	return val.Status == Inactive && now.UTC().Before(val.JailedUntil)
+}
+
+//双签作恶的validator被永久标记,不能再激活
+func (val Validator) IsTombstoned() bool {
+	return val.Status == Inactive && val.InactiveCode == DoubleSign
+}
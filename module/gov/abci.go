@@ -0,0 +1,196 @@
+package gov
+
+import (
+	"github.com/QOSGroup/qbase/context"
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/module/distribution"
+	"github.com/QOSGroup/qos/module/eco"
+	ecomapper "github.com/QOSGroup/qos/module/eco/mapper"
+	ecotypes "github.com/QOSGroup/qos/module/eco/types"
+	"github.com/QOSGroup/qos/module/gov/types"
+	"github.com/QOSGroup/qos/module/supply"
+	supplytypes "github.com/QOSGroup/qos/module/supply/types"
+	qtypes "github.com/QOSGroup/qos/types"
+)
+
+// 1. 抵押期结束仍未进入投票期的proposal: 删除proposal, 抵押进入社区奖励池
+// 2. 投票期结束的proposal: 统计投票, 通过则执行提议内容并返还抵押, 未通过则返还抵押, 被否决则抵押进入社区奖励池
+func EndBlocker(ctx context.Context) {
+	log := ctx.Logger()
+	govMapper := GetGovMapper(ctx)
+	blockTime := ctx.BlockHeader().Time.UTC()
+
+	for _, proposal := range govMapper.GetExpiredInactiveProposals(blockTime) {
+		govMapper.RemoveFromInactiveProposalQueue(proposal.DepositEndTime, proposal.ProposalID)
+		if proposal.Status != types.StatusDepositPeriod {
+			continue
+		}
+
+		burnDeposits(ctx, proposal.ProposalID)
+		govMapper.DeleteProposal(proposal.ProposalID)
+
+		log.Info("proposal dropped", "proposal", proposal.ProposalID, "title", proposal.GetTitle(),
+			"total deposit", proposal.TotalDeposit, "min deposit", govMapper.GetParams().MinDeposit)
+	}
+
+	for _, proposal := range govMapper.GetExpiredActiveProposals(blockTime) {
+		govMapper.RemoveFromActiveProposalQueue(proposal.VotingEndTime, proposal.ProposalID)
+		if proposal.Status != types.StatusVotingPeriod {
+			continue
+		}
+
+		passed, vetoed, tallyResult := tally(ctx, proposal)
+
+		if vetoed {
+			burnDeposits(ctx, proposal.ProposalID)
+		} else {
+			refundDeposits(ctx, proposal.ProposalID)
+		}
+
+		if passed {
+			if err := executeProposal(ctx, proposal); err != nil {
+				passed = false
+				log.Error("execute proposal failed", "proposal", proposal.ProposalID, "err", err.Error())
+			}
+		}
+
+		if passed {
+			proposal.Status = types.StatusPassed
+		} else {
+			proposal.Status = types.StatusRejected
+		}
+		proposal.FinalTallyResult = tallyResult
+		govMapper.SetProposal(proposal)
+
+		log.Info("proposal tallied", "proposal", proposal.ProposalID, "title", proposal.GetTitle(),
+			"result", proposal.Status.String())
+	}
+}
+
+// 执行通过的提议
+func executeProposal(ctx context.Context, proposal types.Proposal) error {
+	switch content := proposal.ProposalContent.(type) {
+	case types.ParameterProposal:
+		return applyParamChanges(ctx, content.Params)
+	case types.CommunityPoolSpendProposal:
+		_, err := distribution.SpendCommunityFeePool(ctx, content.Receiver, content.Amount, content.Title, nil, proposal.ProposalID)
+		return err
+	}
+
+	return nil
+}
+
+// 返还抵押
+func refundDeposits(ctx context.Context, proposalID uint64) {
+	govMapper := GetGovMapper(ctx)
+	for _, deposit := range govMapper.GetDeposits(proposalID) {
+		err := eco.IncrAccountQOS(ctx, deposit.Depositor, btypes.NewInt(int64(deposit.Amount)))
+		if err != nil {
+			panic(err)
+		}
+		supply.GetSupplyMapper(ctx).UnlockQOS(supplytypes.Deposits, btypes.NewInt(int64(deposit.Amount)))
+	}
+	govMapper.DeleteDeposits(proposalID)
+}
+
+// 抵押进入社区奖励池
+func burnDeposits(ctx context.Context, proposalID uint64) {
+	govMapper := GetGovMapper(ctx)
+	distributionMapper := ecomapper.GetDistributionMapper(ctx)
+
+	total := uint64(0)
+	for _, deposit := range govMapper.GetDeposits(proposalID) {
+		total += deposit.Amount
+	}
+	if total > 0 {
+		pool := distributionMapper.GetCommunityFeePool()
+		distributionMapper.SetCommunityFeePool(pool.Add(btypes.NewInt(int64(total))))
+		supply.GetSupplyMapper(ctx).MoveLockedQOS(supplytypes.Deposits, supplytypes.CommunityPool, btypes.NewInt(int64(total)))
+	}
+	govMapper.DeleteDeposits(proposalID)
+}
+
+type validatorGovInfo struct {
+	bondTokens uint64           // validator绑定的QOS总量
+	deduction  uint64           // 自行投票的delegator绑定的QOS
+	option     types.VoteOption // validator owner的投票
+}
+
+// 按绑定的QOS统计投票:
+// 1. 投票账户按其在各活跃validator上的delegation计票
+// 2. validator owner投票时, 代表未投票的delegator, 按validator剩余绑定的QOS计票
+func tally(ctx context.Context, proposal types.Proposal) (passed bool, vetoed bool, tallyResult types.TallyResult) {
+	govMapper := GetGovMapper(ctx)
+	e := eco.GetEco(ctx)
+	params := govMapper.GetParams()
+
+	validators := make(map[string]*validatorGovInfo)
+	ownerValidators := make(map[string]string)
+	totalBonded := uint64(0)
+	e.ValidatorMapper.IterateValidators(func(validator ecotypes.Validator) {
+		if !validator.IsActive() {
+			return
+		}
+		valAddr := validator.GetValidatorAddress().String()
+		validators[valAddr] = &validatorGovInfo{bondTokens: validator.BondTokens}
+		ownerValidators[validator.Owner.String()] = valAddr
+		totalBonded += validator.BondTokens
+	})
+
+	results := make(map[types.VoteOption]uint64)
+	for _, vote := range govMapper.GetVotes(proposal.ProposalID) {
+		if valAddr, ok := ownerValidators[vote.Voter.String()]; ok {
+			validators[valAddr].option = vote.Option
+		}
+
+		e.DelegationMapper.IterateDelegationsInfo(vote.Voter, func(info ecotypes.DelegationInfo) {
+			val, ok := validators[info.ValidatorAddr.String()]
+			if !ok {
+				return
+			}
+			val.deduction += info.Amount
+			results[vote.Option] += info.Amount
+		})
+	}
+
+	for _, val := range validators {
+		if val.option == types.OptionEmpty || val.bondTokens <= val.deduction {
+			continue
+		}
+		results[val.option] += val.bondTokens - val.deduction
+	}
+
+	tallyResult = types.TallyResult{
+		Yes:        results[types.OptionYes],
+		Abstain:    results[types.OptionAbstain],
+		No:         results[types.OptionNo],
+		NoWithVeto: results[types.OptionNoWithVeto],
+	}
+
+	totalVoted := tallyResult.Total()
+	if totalBonded == 0 || totalVoted == 0 {
+		return false, false, tallyResult
+	}
+
+	// 参与投票比例未达到quorum
+	if qtypes.NewFraction(int64(totalVoted), int64(totalBonded)).Value.LT(params.Quorum.Value) {
+		return false, false, tallyResult
+	}
+
+	// 全部为Abstain
+	if totalVoted == tallyResult.Abstain {
+		return false, false, tallyResult
+	}
+
+	// NoWithVeto超过veto比例
+	if qtypes.NewFraction(int64(tallyResult.NoWithVeto), int64(totalVoted)).Value.GT(params.Veto.Value) {
+		return false, true, tallyResult
+	}
+
+	// Yes超过threshold比例
+	if qtypes.NewFraction(int64(tallyResult.Yes), int64(totalVoted-tallyResult.Abstain)).Value.GT(params.Threshold.Value) {
+		return true, false, tallyResult
+	}
+
+	return false, false, tallyResult
+}
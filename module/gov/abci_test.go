@@ -0,0 +1,198 @@
+package gov
+
+import (
+	"testing"
+	"time"
+
+	"github.com/QOSGroup/qbase/account"
+	"github.com/QOSGroup/qbase/context"
+	"github.com/QOSGroup/qbase/mapper"
+	"github.com/QOSGroup/qbase/store"
+	btypes "github.com/QOSGroup/qbase/types"
+	ecomapper "github.com/QOSGroup/qos/module/eco/mapper"
+	ecotypes "github.com/QOSGroup/qos/module/eco/types"
+	"github.com/QOSGroup/qos/module/gov/types"
+	"github.com/QOSGroup/qos/module/supply"
+	supplytypes "github.com/QOSGroup/qos/module/supply/types"
+	qtypes "github.com/QOSGroup/qos/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestParameterProposal(t *testing.T) {
+	startTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := defaultContext().WithBlockHeader(abci.Header{Time: startTime})
+
+	govMapper := GetGovMapper(ctx)
+	validatorMapper := ecomapper.GetValidatorMapper(ctx)
+	validatorMapper.SetParams(ecotypes.DefaultStakeParams())
+	params := govMapper.GetParams()
+
+	ownerA := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	ownerB := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	delegator := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	createValidator(ctx, ownerA, 600, nil, 0)
+	createValidator(ctx, ownerB, 500, delegator, 100)
+
+	proposer := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	createAccount(ctx, proposer, params.MinDeposit)
+
+	//参数校验
+	invalid := NewSubmitProposalTx(types.NewParameterProposal("title", "desc",
+		[]types.Param{types.NewParam(ParamModuleStake, "max_validator_cnt", "0")}), proposer, params.MinDeposit)
+	require.NotNil(t, invalid.ValidateData(ctx))
+	invalid = NewSubmitProposalTx(types.NewParameterProposal("title", "desc",
+		[]types.Param{types.NewParam(ParamModuleStake, "not_exists", "1")}), proposer, params.MinDeposit)
+	require.NotNil(t, invalid.ValidateData(ctx))
+
+	tx := NewSubmitProposalTx(types.NewParameterProposal("title", "desc",
+		[]types.Param{types.NewParam(ParamModuleStake, "max_validator_cnt", "20")}), proposer, params.MinDeposit)
+	require.Nil(t, tx.ValidateData(ctx))
+	result, _ := tx.Exec(ctx)
+	require.True(t, result.IsOK())
+
+	proposal, exists := govMapper.GetProposal(1)
+	require.True(t, exists)
+	require.Equal(t, types.StatusVotingPeriod, proposal.Status)
+	require.Equal(t, uint64(0), getQOS(ctx, proposer))
+
+	//A: 600 yes, delegator: 100 yes, B代表剩余的400投no
+	for voter, option := range map[string]types.VoteOption{
+		ownerA.String():    types.OptionYes,
+		ownerB.String():    types.OptionNo,
+		delegator.String(): types.OptionYes,
+	} {
+		addr, _ := btypes.GetAddrFromBech32(voter)
+		voteTx := NewVoteTx(1, addr, option)
+		require.Nil(t, voteTx.ValidateData(ctx))
+		voteTx.Exec(ctx)
+	}
+
+	_, _, tallyResult := tally(ctx, proposal)
+	require.Equal(t, types.TallyResult{Yes: 700, No: 400}, tallyResult)
+
+	ctx = ctx.WithBlockHeader(abci.Header{Time: proposal.VotingEndTime})
+	EndBlocker(ctx)
+
+	proposal, _ = govMapper.GetProposal(1)
+	require.Equal(t, types.StatusPassed, proposal.Status)
+	require.Equal(t, uint32(20), validatorMapper.GetParams().MaxValidatorCnt)
+	require.Equal(t, params.MinDeposit, getQOS(ctx, proposer))
+	require.Equal(t, 0, len(govMapper.GetDeposits(1)))
+}
+
+func TestDropProposal(t *testing.T) {
+	startTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := defaultContext().WithBlockHeader(abci.Header{Time: startTime})
+
+	govMapper := GetGovMapper(ctx)
+	distributionMapper := ecomapper.GetDistributionMapper(ctx)
+
+	proposer := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	createAccount(ctx, proposer, 100)
+
+	tx := NewSubmitProposalTx(types.NewTextProposal("title", "desc"), proposer, 100)
+	require.Nil(t, tx.ValidateData(ctx))
+	tx.Exec(ctx)
+
+	supplyMapper := supply.GetSupplyMapper(ctx)
+	require.Equal(t, btypes.NewInt(100), supplyMapper.GetLockedQOS(supplytypes.Deposits))
+
+	proposal, exists := govMapper.GetProposal(1)
+	require.True(t, exists)
+	require.Equal(t, types.StatusDepositPeriod, proposal.Status)
+
+	voteTx := NewVoteTx(1, proposer, types.OptionYes)
+	require.NotNil(t, voteTx.ValidateData(ctx))
+
+	ctx = ctx.WithBlockHeader(abci.Header{Time: proposal.DepositEndTime})
+	EndBlocker(ctx)
+
+	_, exists = govMapper.GetProposal(1)
+	require.False(t, exists)
+	require.Equal(t, btypes.NewInt(100), distributionMapper.GetCommunityFeePool())
+	require.Equal(t, btypes.NewInt(0), supplyMapper.GetLockedQOS(supplytypes.Deposits))
+	require.Equal(t, btypes.NewInt(100), supplyMapper.GetLockedQOS(supplytypes.CommunityPool))
+	require.Equal(t, uint64(2), govMapper.GetNextProposalID())
+}
+
+func createValidator(ctx context.Context, owner btypes.Address, bondTokens uint64, delegator btypes.Address, delegateTokens uint64) {
+	validator := ecotypes.Validator{
+		Name:            "test",
+		Owner:           owner,
+		ValidatorPubKey: ed25519.GenPrivKey().PubKey(),
+		BondTokens:      bondTokens,
+		Status:          ecotypes.Active,
+		BondHeight:      1,
+	}
+	valAddr := validator.GetValidatorAddress()
+
+	ecomapper.GetValidatorMapper(ctx).CreateValidator(validator)
+	delegationMapper := ecomapper.GetDelegationMapper(ctx)
+	delegationMapper.SetDelegationInfo(ecotypes.NewDelegationInfo(owner, valAddr, bondTokens-delegateTokens, false))
+	if delegateTokens > 0 {
+		delegationMapper.SetDelegationInfo(ecotypes.NewDelegationInfo(delegator, valAddr, delegateTokens, false))
+	}
+}
+
+func createAccount(ctx context.Context, addr btypes.Address, qos uint64) {
+	accountMapper := ctx.Mapper(account.AccountMapperName).(*account.AccountMapper)
+	acc := accountMapper.NewAccountWithAddress(addr).(*qtypes.QOSAccount)
+	acc.QOS = btypes.NewInt(int64(qos))
+	accountMapper.SetAccount(acc)
+}
+
+func getQOS(ctx context.Context, addr btypes.Address) uint64 {
+	accountMapper := ctx.Mapper(account.AccountMapperName).(*account.AccountMapper)
+	return uint64(accountMapper.GetAccount(addr).(*qtypes.QOSAccount).QOS.Int64())
+}
+
+func defaultContext() context.Context {
+
+	mapperMap := make(map[string]mapper.IMapper)
+
+	accountMapper := account.NewAccountMapper(cdc, qtypes.ProtoQOSAccount)
+	mapperMap[account.AccountMapperName] = accountMapper
+
+	validatorMapper := ecomapper.NewValidatorMapper()
+	validatorMapper.SetCodec(cdc)
+	mapperMap[ecotypes.ValidatorMapperName] = validatorMapper
+
+	delegationMapper := ecomapper.NewDelegationMapper()
+	delegationMapper.SetCodec(cdc)
+	mapperMap[ecotypes.DelegationMapperName] = delegationMapper
+
+	distributionMapper := ecomapper.NewDistributionMapper()
+	distributionMapper.SetCodec(cdc)
+	mapperMap[ecotypes.DistributionMapperName] = distributionMapper
+
+	voteInfoMapper := ecomapper.NewVoteInfoMapper()
+	voteInfoMapper.SetCodec(cdc)
+	mapperMap[ecotypes.VoteInfoMapperName] = voteInfoMapper
+
+	mintMapper := ecomapper.NewMintMapper()
+	mintMapper.SetCodec(cdc)
+	mapperMap[ecotypes.MintMapperName] = mintMapper
+
+	govMapper := NewGovMapper()
+	govMapper.SetCodec(cdc)
+	mapperMap[types.GovMapperName] = govMapper
+
+	supplyMapper := supply.NewSupplyMapper()
+	supplyMapper.SetCodec(cdc)
+	mapperMap[supplytypes.SupplyMapperName] = supplyMapper
+
+	db := dbm.NewMemDB()
+	cms := store.NewCommitMultiStore(db)
+
+	for _, v := range mapperMap {
+		cms.MountStoreWithDB(v.GetStoreKey(), store.StoreTypeIAVL, db)
+	}
+	cms.LoadLatestVersion()
+
+	ctx := context.NewContext(cms, abci.Header{}, false, log.NewNopLogger(), mapperMap)
+	return ctx
+}
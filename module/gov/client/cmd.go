@@ -0,0 +1,28 @@
+package gov
+
+import (
+	bctypes "github.com/QOSGroup/qbase/client/types"
+	"github.com/spf13/cobra"
+	"github.com/tendermint/go-amino"
+)
+
+func TxCommands(cdc *amino.Codec) []*cobra.Command {
+	return bctypes.PostCommands(
+		SubmitProposalCmd(cdc),
+		DepositCmd(cdc),
+		VoteCmd(cdc),
+	)
+}
+
+func QueryCommands(cdc *amino.Codec) []*cobra.Command {
+	return bctypes.GetCommands(
+		queryProposalCommand(cdc),
+		queryProposalsCommand(cdc),
+		queryDepositCommand(cdc),
+		queryDepositsCommand(cdc),
+		queryVoteCommand(cdc),
+		queryVotesCommand(cdc),
+		queryTallyCommand(cdc),
+		queryGovParamsCommand(cdc),
+	)
+}
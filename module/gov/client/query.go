@@ -0,0 +1,225 @@
+package gov
+
+import (
+	"strconv"
+
+	qcliacc "github.com/QOSGroup/qbase/client/account"
+	"github.com/QOSGroup/qbase/client/context"
+	"github.com/QOSGroup/qos/module/gov/types"
+	"github.com/spf13/cobra"
+	"github.com/tendermint/go-amino"
+)
+
+func queryProposalCommand(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "proposal [proposal-id]",
+		Short: "Query details of a proposal",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposalID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.Query(types.BuildQueryProposalCustomQueryPath(proposalID), []byte(""))
+			if err != nil {
+				return err
+			}
+
+			var result types.Proposal
+			cliCtx.Codec.UnmarshalJSON(res, &result)
+			return cliCtx.PrintResult(result)
+		},
+	}
+
+	return cmd
+}
+
+func queryProposalsCommand(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "proposals",
+		Short: "Query all proposals",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.Query(types.BuildQueryProposalsCustomQueryPath(), []byte(""))
+			if err != nil {
+				return err
+			}
+
+			var result []types.Proposal
+			cliCtx.Codec.UnmarshalJSON(res, &result)
+			return cliCtx.PrintResult(result)
+		},
+	}
+
+	return cmd
+}
+
+func queryDepositCommand(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deposit [proposal-id] [depositor]",
+		Short: "Query deposit of a depositor on a proposal",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposalID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			depositor, err := qcliacc.GetAddrFromValue(cliCtx, args[1])
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.Query(types.BuildQueryDepositCustomQueryPath(proposalID, depositor), []byte(""))
+			if err != nil {
+				return err
+			}
+
+			var result types.Deposit
+			cliCtx.Codec.UnmarshalJSON(res, &result)
+			return cliCtx.PrintResult(result)
+		},
+	}
+
+	return cmd
+}
+
+func queryDepositsCommand(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deposits [proposal-id]",
+		Short: "Query deposits on a proposal",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposalID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.Query(types.BuildQueryDepositsCustomQueryPath(proposalID), []byte(""))
+			if err != nil {
+				return err
+			}
+
+			var result []types.Deposit
+			cliCtx.Codec.UnmarshalJSON(res, &result)
+			return cliCtx.PrintResult(result)
+		},
+	}
+
+	return cmd
+}
+
+func queryVoteCommand(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vote [proposal-id] [voter]",
+		Short: "Query vote of a voter on a proposal",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposalID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			voter, err := qcliacc.GetAddrFromValue(cliCtx, args[1])
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.Query(types.BuildQueryVoteCustomQueryPath(proposalID, voter), []byte(""))
+			if err != nil {
+				return err
+			}
+
+			var result types.Vote
+			cliCtx.Codec.UnmarshalJSON(res, &result)
+			return cliCtx.PrintResult(result)
+		},
+	}
+
+	return cmd
+}
+
+func queryVotesCommand(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "votes [proposal-id]",
+		Short: "Query votes on a proposal",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposalID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.Query(types.BuildQueryVotesCustomQueryPath(proposalID), []byte(""))
+			if err != nil {
+				return err
+			}
+
+			var result []types.Vote
+			cliCtx.Codec.UnmarshalJSON(res, &result)
+			return cliCtx.PrintResult(result)
+		},
+	}
+
+	return cmd
+}
+
+func queryTallyCommand(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tally [proposal-id]",
+		Short: "Query tally result of a proposal",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposalID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.Query(types.BuildQueryTallyCustomQueryPath(proposalID), []byte(""))
+			if err != nil {
+				return err
+			}
+
+			var result types.TallyResult
+			cliCtx.Codec.UnmarshalJSON(res, &result)
+			return cliCtx.PrintResult(result)
+		},
+	}
+
+	return cmd
+}
+
+func queryGovParamsCommand(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gov-params",
+		Short: "Query governance params",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.Query(types.BuildQueryParamsCustomQueryPath(), []byte(""))
+			if err != nil {
+				return err
+			}
+
+			var result types.GovParams
+			cliCtx.Codec.UnmarshalJSON(res, &result)
+			return cliCtx.PrintResult(result)
+		},
+	}
+
+	return cmd
+}
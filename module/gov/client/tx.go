@@ -0,0 +1,194 @@
+package gov
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	qcliacc "github.com/QOSGroup/qbase/client/account"
+	"github.com/QOSGroup/qbase/client/context"
+	"github.com/QOSGroup/qbase/txs"
+	distrcli "github.com/QOSGroup/qos/module/distribution/client"
+	"github.com/QOSGroup/qos/module/gov"
+	"github.com/QOSGroup/qos/module/gov/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/go-amino"
+)
+
+const (
+	flagTitle        = "title"
+	flagDescription  = "description"
+	flagProposalType = "proposal-type"
+	flagProposer     = "proposer"
+	flagDeposit      = "deposit"
+	flagParams       = "params"
+	flagDestAddress  = "dest-address"
+	flagAmount       = "amount"
+	flagProposalID   = "proposal-id"
+	flagDepositor    = "depositor"
+	flagVoter        = "voter"
+	flagOption       = "option"
+)
+
+func SubmitProposalCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "submit-proposal",
+		Short: "Submit a proposal along with an initial deposit",
+		Long: `
+proposal-type: Text, ParameterChange or CommunityPoolSpend.
+params: module:key:value, module is one of stake, distribution, mint, gov and qsc, value is in JSON format.
+
+example:
+
+	 qoscli tx submit-proposal --title "title" --description "description" --proposal-type Text --proposer proposerName --deposit 10000
+	 qoscli tx submit-proposal --title "title" --description "description" --proposal-type ParameterChange --proposer proposerName --deposit 10000 --params 'stake:max_validator_cnt:20'
+	 qoscli tx submit-proposal --title "title" --description "description" --proposal-type CommunityPoolSpend --proposer proposerName --deposit 10000 --dest-address address1xxx --amount 100
+
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+				title := viper.GetString(flagTitle)
+				desc := viper.GetString(flagDescription)
+
+				proposalType, err := types.ProposalTypeFromString(viper.GetString(flagProposalType))
+				if err != nil {
+					return nil, err
+				}
+
+				proposer, err := qcliacc.GetAddrFromFlag(ctx, flagProposer)
+				if err != nil {
+					return nil, err
+				}
+
+				deposit := viper.GetInt64(flagDeposit)
+				if deposit < 0 {
+					return nil, errors.New("deposit must not be negative")
+				}
+
+				var content types.ProposalContent
+				switch proposalType {
+				case types.ProposalTypeText:
+					content = types.NewTextProposal(title, desc)
+				case types.ProposalTypeParameterChange:
+					strs, err := cmd.Flags().GetStringArray(flagParams)
+					if err != nil {
+						return nil, err
+					}
+					params, err := parseParams(strs)
+					if err != nil {
+						return nil, err
+					}
+					content = types.NewParameterProposal(title, desc, params)
+				case types.ProposalTypeCommunityPoolSpend:
+					dest, err := qcliacc.GetAddrFromFlag(ctx, flagDestAddress)
+					if err != nil {
+						return nil, err
+					}
+					amount := viper.GetInt64(flagAmount)
+					if amount <= 0 {
+						return nil, errors.New("amount lte zero")
+					}
+					content = types.NewCommunityPoolSpendProposal(title, desc, dest, uint64(amount))
+				}
+
+				if err := content.ValidateBasic(); err != nil {
+					return nil, err
+				}
+
+				return gov.NewSubmitProposalTx(content, proposer, uint64(deposit)), nil
+			})
+		},
+	}
+
+	cmd.Flags().String(flagTitle, "", "proposal title")
+	cmd.Flags().String(flagDescription, "", "proposal description")
+	cmd.Flags().String(flagProposalType, "Text", "proposal type: Text, ParameterChange or CommunityPoolSpend")
+	cmd.Flags().String(flagProposer, "", "proposer keystore name or account address")
+	cmd.Flags().Int64(flagDeposit, 0, "initial deposit of QOS")
+	cmd.Flags().StringArray(flagParams, []string{}, "params to change, format: module:key:value")
+	cmd.Flags().String(flagDestAddress, "", "receiver keystore name or account address of community pool spend")
+	cmd.Flags().Int64(flagAmount, 0, "QOS amount of community pool spend")
+
+	cmd.MarkFlagRequired(flagTitle)
+	cmd.MarkFlagRequired(flagProposalType)
+	cmd.MarkFlagRequired(flagProposer)
+
+	return cmd
+}
+
+func parseParams(strs []string) ([]types.Param, error) {
+	params := make([]types.Param, 0, len(strs))
+	for _, str := range strs {
+		items := strings.SplitN(str, ":", 3)
+		if len(items) != 3 {
+			return nil, fmt.Errorf("invalid param: %s, format: module:key:value", str)
+		}
+		params = append(params, types.NewParam(items[0], items[1], items[2]))
+	}
+	return params, nil
+}
+
+func DepositCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deposit",
+		Short: "Deposit QOS for an active proposal",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+				depositor, err := qcliacc.GetAddrFromFlag(ctx, flagDepositor)
+				if err != nil {
+					return nil, err
+				}
+
+				amount := viper.GetInt64(flagAmount)
+				if amount <= 0 {
+					return nil, errors.New("amount lte zero")
+				}
+
+				return gov.NewDepositTx(uint64(viper.GetInt64(flagProposalID)), depositor, uint64(amount)), nil
+			})
+		},
+	}
+
+	cmd.Flags().Int64(flagProposalID, 0, "proposal id")
+	cmd.Flags().String(flagDepositor, "", "depositor keystore name or account address")
+	cmd.Flags().Int64(flagAmount, 0, "QOS amount of deposit")
+
+	cmd.MarkFlagRequired(flagProposalID)
+	cmd.MarkFlagRequired(flagDepositor)
+	cmd.MarkFlagRequired(flagAmount)
+
+	return cmd
+}
+
+func VoteCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vote",
+		Short: "Vote for an active proposal, options: Yes/Abstain/No/NoWithVeto",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+				voter, err := qcliacc.GetAddrFromFlag(ctx, flagVoter)
+				if err != nil {
+					return nil, err
+				}
+
+				option, err := types.VoteOptionFromString(viper.GetString(flagOption))
+				if err != nil {
+					return nil, err
+				}
+
+				return gov.NewVoteTx(uint64(viper.GetInt64(flagProposalID)), voter, option), nil
+			})
+		},
+	}
+
+	cmd.Flags().Int64(flagProposalID, 0, "proposal id")
+	cmd.Flags().String(flagVoter, "", "voter keystore name or account address")
+	cmd.Flags().String(flagOption, "", "vote option: Yes, Abstain, No or NoWithVeto")
+
+	cmd.MarkFlagRequired(flagProposalID)
+	cmd.MarkFlagRequired(flagVoter)
+	cmd.MarkFlagRequired(flagOption)
+
+	return cmd
+}
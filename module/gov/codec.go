@@ -0,0 +1,26 @@
+package gov
+
+import (
+	"github.com/QOSGroup/qbase/baseabci"
+	"github.com/QOSGroup/qos/module/gov/types"
+	qtypes "github.com/QOSGroup/qos/types"
+	"github.com/tendermint/go-amino"
+)
+
+var cdc = baseabci.MakeQBaseCodec()
+
+func init() {
+	qtypes.RegisterCodec(cdc)
+	RegisterCodec(cdc)
+}
+
+func RegisterCodec(cdc *amino.Codec) {
+	cdc.RegisterConcrete(&TxSubmitProposal{}, "qos/txs/TxSubmitProposal", nil)
+	cdc.RegisterConcrete(&TxDeposit{}, "qos/txs/TxDeposit", nil)
+	cdc.RegisterConcrete(&TxVote{}, "qos/txs/TxVote", nil)
+
+	cdc.RegisterInterface((*types.ProposalContent)(nil), nil)
+	cdc.RegisterConcrete(types.TextProposal{}, "gov/types/TextProposal", nil)
+	cdc.RegisterConcrete(types.ParameterProposal{}, "gov/types/ParameterProposal", nil)
+	cdc.RegisterConcrete(types.CommunityPoolSpendProposal{}, "gov/types/CommunityPoolSpendProposal", nil)
+}
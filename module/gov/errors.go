@@ -0,0 +1,80 @@
+package gov
+
+import (
+	btypes "github.com/QOSGroup/qbase/types"
+)
+
+// gov errors reserve 600 ~ 699.
+const (
+	DefaultCodeSpace btypes.CodespaceType = "gov"
+
+	CodeInvalidInput           btypes.CodeType = 601 // 输入有误
+	CodeAccountNotExists       btypes.CodeType = 602 // 账户不存在
+	CodeNoEnoughQOS            btypes.CodeType = 603 // 账户QOS不足
+	CodeProposalNotExists      btypes.CodeType = 604 // Proposal不存在
+	CodeWrongProposalStatus    btypes.CodeType = 605 // Proposal状态不符
+	CodeInvalidParam           btypes.CodeType = 606 // 参数修改有误
+	CodeCommunityPoolNotEnough btypes.CodeType = 607 // 社区奖励池余额不足
+)
+
+func msgOrDefaultMsg(msg string, code btypes.CodeType) string {
+	if msg != "" {
+		return msg
+	}
+	return codeToDefaultMsg(code)
+}
+
+func newError(codeSpace btypes.CodespaceType, code btypes.CodeType, msg string) btypes.Error {
+	msg = msgOrDefaultMsg(msg, code)
+	return btypes.NewError(codeSpace, code, msg)
+}
+
+// NOTE: Don't stringer this, we'll put better messages in later.
+func codeToDefaultMsg(code btypes.CodeType) string {
+	switch code {
+	case CodeInvalidInput:
+		return "invalid input"
+	case CodeAccountNotExists:
+		return "account not exists"
+	case CodeNoEnoughQOS:
+		return "account has no enough QOS"
+	case CodeProposalNotExists:
+		return "proposal not exists"
+	case CodeWrongProposalStatus:
+		return "wrong proposal status"
+	case CodeInvalidParam:
+		return "invalid param change"
+	case CodeCommunityPoolNotEnough:
+		return "community fee pool has no enough QOS"
+	default:
+		return btypes.CodeToDefaultMsg(code)
+	}
+}
+
+func ErrInvalidInput(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeInvalidInput, msg)
+}
+
+func ErrAccountNotExists(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeAccountNotExists, msg)
+}
+
+func ErrNoEnoughQOS(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeNoEnoughQOS, msg)
+}
+
+func ErrProposalNotExists(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeProposalNotExists, msg)
+}
+
+func ErrWrongProposalStatus(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeWrongProposalStatus, msg)
+}
+
+func ErrInvalidParam(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeInvalidParam, msg)
+}
+
+func ErrCommunityPoolNotEnough(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeCommunityPoolNotEnough, msg)
+}
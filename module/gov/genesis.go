@@ -0,0 +1,81 @@
+package gov
+
+import (
+	"github.com/QOSGroup/qbase/context"
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/module/gov/types"
+	"github.com/QOSGroup/qos/module/supply"
+	supplytypes "github.com/QOSGroup/qos/module/supply/types"
+)
+
+type GenesisState struct {
+	StartingProposalID uint64           `json:"starting_proposal_id"`
+	Params             types.GovParams  `json:"params"`
+	Proposals          []types.Proposal `json:"proposals"`
+	Deposits           []types.Deposit  `json:"deposits"`
+	Votes              []types.Vote     `json:"votes"`
+}
+
+func NewGenesisState(startingProposalID uint64, params types.GovParams, proposals []types.Proposal,
+	deposits []types.Deposit, votes []types.Vote) GenesisState {
+	return GenesisState{
+		StartingProposalID: startingProposalID,
+		Params:             params,
+		Proposals:          proposals,
+		Deposits:           deposits,
+		Votes:              votes,
+	}
+}
+
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		StartingProposalID: 1,
+		Params:             types.DefaultGovParams(),
+	}
+}
+
+func ValidateGenesis(data GenesisState) error {
+	return data.Params.Validate()
+}
+
+func InitGenesis(ctx context.Context, data GenesisState) {
+	govMapper := GetGovMapper(ctx)
+
+	govMapper.SetParams(data.Params)
+	govMapper.SetNextProposalID(data.StartingProposalID)
+
+	for _, proposal := range data.Proposals {
+		switch proposal.Status {
+		case types.StatusDepositPeriod:
+			govMapper.InsertInactiveProposalQueue(proposal.DepositEndTime, proposal.ProposalID)
+		case types.StatusVotingPeriod:
+			govMapper.InsertActiveProposalQueue(proposal.VotingEndTime, proposal.ProposalID)
+		}
+		govMapper.SetProposal(proposal)
+	}
+
+	deposits := uint64(0)
+	for _, deposit := range data.Deposits {
+		govMapper.SetDeposit(deposit)
+		deposits += deposit.Amount
+	}
+	supply.GetSupplyMapper(ctx).SetLockedQOS(supplytypes.Deposits, btypes.NewInt(int64(deposits)))
+
+	for _, vote := range data.Votes {
+		govMapper.SetVote(vote)
+	}
+}
+
+func ExportGenesis(ctx context.Context) GenesisState {
+	govMapper := GetGovMapper(ctx)
+
+	var deposits []types.Deposit
+	var votes []types.Vote
+	proposals := govMapper.GetProposals()
+	for _, proposal := range proposals {
+		deposits = append(deposits, govMapper.GetDeposits(proposal.ProposalID)...)
+		votes = append(votes, govMapper.GetVotes(proposal.ProposalID)...)
+	}
+
+	return NewGenesisState(govMapper.GetNextProposalID(), govMapper.GetParams(), proposals, deposits, votes)
+}
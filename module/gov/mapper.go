@@ -0,0 +1,220 @@
+package gov
+
+import (
+	"time"
+
+	"github.com/QOSGroup/qbase/context"
+	"github.com/QOSGroup/qbase/mapper"
+	"github.com/QOSGroup/qbase/store"
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/module/gov/types"
+)
+
+type GovMapper struct {
+	*mapper.BaseMapper
+}
+
+func NewGovMapper() *GovMapper {
+	var govMapper = GovMapper{}
+	govMapper.BaseMapper = mapper.NewBaseMapper(nil, types.GovMapperName)
+	return &govMapper
+}
+
+func GetGovMapper(ctx context.Context) *GovMapper {
+	return ctx.Mapper(types.GovMapperName).(*GovMapper)
+}
+
+func (mapper *GovMapper) Copy() mapper.IMapper {
+	govMapper := &GovMapper{}
+	govMapper.BaseMapper = mapper.BaseMapper.Copy()
+	return govMapper
+}
+
+func (mapper *GovMapper) SetParams(params types.GovParams) {
+	mapper.Set(types.BuildGovParamsKey(), params)
+}
+
+func (mapper *GovMapper) GetParams() types.GovParams {
+	params := types.GovParams{}
+	exists := mapper.Get(types.BuildGovParamsKey(), &params)
+	if !exists {
+		return types.DefaultGovParams()
+	}
+	return params
+}
+
+// 下一个ProposalID, 从1开始
+func (mapper *GovMapper) GetNextProposalID() uint64 {
+	var id uint64
+	exists := mapper.Get(types.BuildNextProposalIDKey(), &id)
+	if !exists || id == 0 {
+		return 1
+	}
+	return id
+}
+
+func (mapper *GovMapper) SetNextProposalID(id uint64) {
+	mapper.Set(types.BuildNextProposalIDKey(), id)
+}
+
+//-------------------------proposal
+
+func (mapper *GovMapper) GetProposal(proposalID uint64) (proposal types.Proposal, exists bool) {
+	exists = mapper.Get(types.BuildProposalKey(proposalID), &proposal)
+	return
+}
+
+func (mapper *GovMapper) SetProposal(proposal types.Proposal) {
+	mapper.Set(types.BuildProposalKey(proposal.ProposalID), proposal)
+}
+
+func (mapper *GovMapper) DeleteProposal(proposalID uint64) {
+	mapper.Del(types.BuildProposalKey(proposalID))
+}
+
+func (mapper *GovMapper) IterateProposals(fn func(types.Proposal)) {
+	iter := store.KVStorePrefixIterator(mapper.GetStore(), types.GetProposalPrefixKey())
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var proposal types.Proposal
+		mapper.DecodeObject(iter.Value(), &proposal)
+		fn(proposal)
+	}
+}
+
+func (mapper *GovMapper) GetProposals() []types.Proposal {
+	proposals := make([]types.Proposal, 0)
+	mapper.IterateProposals(func(proposal types.Proposal) {
+		proposals = append(proposals, proposal)
+	})
+	return proposals
+}
+
+//-------------------------deposit
+
+func (mapper *GovMapper) GetDeposit(proposalID uint64, depositor btypes.Address) (deposit types.Deposit, exists bool) {
+	exists = mapper.Get(types.BuildDepositKey(proposalID, depositor), &deposit)
+	return
+}
+
+func (mapper *GovMapper) SetDeposit(deposit types.Deposit) {
+	mapper.Set(types.BuildDepositKey(deposit.ProposalID, deposit.Depositor), deposit)
+}
+
+// 增加抵押, 返回抵押后proposal的抵押总量
+func (mapper *GovMapper) AddDeposit(proposal *types.Proposal, depositor btypes.Address, amount uint64) uint64 {
+	deposit, exists := mapper.GetDeposit(proposal.ProposalID, depositor)
+	if !exists {
+		deposit = types.NewDeposit(depositor, proposal.ProposalID, 0)
+	}
+	deposit.Amount += amount
+	mapper.SetDeposit(deposit)
+
+	proposal.TotalDeposit += amount
+	mapper.SetProposal(*proposal)
+
+	return proposal.TotalDeposit
+}
+
+func (mapper *GovMapper) GetDeposits(proposalID uint64) []types.Deposit {
+	deposits := make([]types.Deposit, 0)
+	iter := store.KVStorePrefixIterator(mapper.GetStore(), types.BuildDepositPrefixKey(proposalID))
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var deposit types.Deposit
+		mapper.DecodeObject(iter.Value(), &deposit)
+		deposits = append(deposits, deposit)
+	}
+	return deposits
+}
+
+func (mapper *GovMapper) DeleteDeposits(proposalID uint64) {
+	for _, deposit := range mapper.GetDeposits(proposalID) {
+		mapper.Del(types.BuildDepositKey(proposalID, deposit.Depositor))
+	}
+}
+
+//-------------------------vote
+
+func (mapper *GovMapper) GetVote(proposalID uint64, voter btypes.Address) (vote types.Vote, exists bool) {
+	exists = mapper.Get(types.BuildVoteKey(proposalID, voter), &vote)
+	return
+}
+
+func (mapper *GovMapper) SetVote(vote types.Vote) {
+	mapper.Set(types.BuildVoteKey(vote.ProposalID, vote.Voter), vote)
+}
+
+func (mapper *GovMapper) GetVotes(proposalID uint64) []types.Vote {
+	votes := make([]types.Vote, 0)
+	iter := store.KVStorePrefixIterator(mapper.GetStore(), types.BuildVotePrefixKey(proposalID))
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var vote types.Vote
+		mapper.DecodeObject(iter.Value(), &vote)
+		votes = append(votes, vote)
+	}
+	return votes
+}
+
+func (mapper *GovMapper) DeleteVotes(proposalID uint64) {
+	for _, vote := range mapper.GetVotes(proposalID) {
+		mapper.Del(types.BuildVoteKey(proposalID, vote.Voter))
+	}
+}
+
+//-------------------------proposal queue
+
+func (mapper *GovMapper) InsertInactiveProposalQueue(endTime time.Time, proposalID uint64) {
+	mapper.Set(types.BuildInactiveProposalQueueKey(endTime, proposalID), proposalID)
+}
+
+func (mapper *GovMapper) RemoveFromInactiveProposalQueue(endTime time.Time, proposalID uint64) {
+	mapper.Del(types.BuildInactiveProposalQueueKey(endTime, proposalID))
+}
+
+func (mapper *GovMapper) InsertActiveProposalQueue(endTime time.Time, proposalID uint64) {
+	mapper.Set(types.BuildActiveProposalQueueKey(endTime, proposalID), proposalID)
+}
+
+func (mapper *GovMapper) RemoveFromActiveProposalQueue(endTime time.Time, proposalID uint64) {
+	mapper.Del(types.BuildActiveProposalQueueKey(endTime, proposalID))
+}
+
+// 抵押期结束时间不晚于endTime的proposal
+func (mapper *GovMapper) GetExpiredInactiveProposals(endTime time.Time) []types.Proposal {
+	return mapper.getProposalsInQueue(types.GetInactiveProposalQueueKey(), endTime)
+}
+
+// 投票期结束时间不晚于endTime的proposal
+func (mapper *GovMapper) GetExpiredActiveProposals(endTime time.Time) []types.Proposal {
+	return mapper.getProposalsInQueue(types.GetActiveProposalQueueKey(), endTime)
+}
+
+func (mapper *GovMapper) getProposalsInQueue(prefix []byte, endTime time.Time) []types.Proposal {
+	iter := mapper.GetStore().Iterator(prefix, types.BuildProposalQueueEndKey(prefix, endTime))
+	defer iter.Close()
+
+	var proposals []types.Proposal
+	for ; iter.Valid(); iter.Next() {
+		proposalID := types.GetProposalIDFromQueueKey(iter.Key())
+		if proposal, exists := mapper.GetProposal(proposalID); exists {
+			proposals = append(proposals, proposal)
+		}
+	}
+	return proposals
+}
+
+// 激活proposal, 进入投票期
+func (mapper *GovMapper) ActivateVotingPeriod(proposal *types.Proposal, votingStartTime time.Time) {
+	proposal.VotingStartTime = votingStartTime.UTC()
+	proposal.VotingEndTime = proposal.VotingStartTime.Add(time.Duration(mapper.GetParams().VotingPeriod) * time.Second)
+	proposal.Status = types.StatusVotingPeriod
+	mapper.SetProposal(*proposal)
+
+	mapper.RemoveFromInactiveProposalQueue(proposal.DepositEndTime, proposal.ProposalID)
+	mapper.InsertActiveProposalQueue(proposal.VotingEndTime, proposal.ProposalID)
+}
@@ -0,0 +1,201 @@
+package gov
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/QOSGroup/qbase/context"
+	ecomapper "github.com/QOSGroup/qos/module/eco/mapper"
+	ecotypes "github.com/QOSGroup/qos/module/eco/types"
+	"github.com/QOSGroup/qos/module/gov/types"
+	"github.com/QOSGroup/qos/module/qsc"
+	qsctypes "github.com/QOSGroup/qos/module/qsc/types"
+	qtypes "github.com/QOSGroup/qos/types"
+	"github.com/tendermint/go-amino"
+)
+
+// 可通过提议修改参数的模块
+const (
+	ParamModuleStake        = "stake"
+	ParamModuleDistribution = "distribution"
+	ParamModuleMint         = "mint"
+	ParamModuleGov          = "gov"
+	ParamModuleQSC          = "qsc"
+)
+
+// 参数JSON编解码, 不注册类型以避免输出type/value包装
+var paramCdc = amino.NewCodec()
+
+// 校验参数修改, 不修改状态
+func validateParamChanges(ctx context.Context, changes []types.Param) error {
+	_, err := buildParamChanges(ctx, changes)
+	return err
+}
+
+// 执行参数修改. 所有修改项校验通过后才会写入
+func applyParamChanges(ctx context.Context, changes []types.Param) error {
+	modules, err := buildParamChanges(ctx, changes)
+	if err != nil {
+		return err
+	}
+
+	for module, params := range modules {
+		switch module {
+		case ParamModuleStake:
+			ecomapper.GetValidatorMapper(ctx).SetParams(*params.(*ecotypes.StakeParams))
+		case ParamModuleDistribution:
+			ecomapper.GetDistributionMapper(ctx).SetParams(*params.(*ecotypes.DistributionParams))
+		case ParamModuleMint:
+			mintMapper := ecomapper.GetMintMapper(ctx)
+			for _, phrase := range mintMapper.GetMintParams().Phrases {
+				mintMapper.DelInflationPhrase(phrase)
+			}
+			mintMapper.SetMintParams(*params.(*ecotypes.MintParams))
+		case ParamModuleGov:
+			GetGovMapper(ctx).SetParams(*params.(*types.GovParams))
+		case ParamModuleQSC:
+			ctx.Mapper(qsc.QSCMapperName).(*qsc.QSCMapper).SetParams(*params.(*qsctypes.QSCParams))
+		}
+	}
+
+	return nil
+}
+
+// 按模块读取当前参数并应用修改, 返回修改后的参数
+func buildParamChanges(ctx context.Context, changes []types.Param) (map[string]interface{}, error) {
+	modules := make(map[string]interface{})
+
+	for _, change := range changes {
+		params, ok := modules[change.Module]
+		if !ok {
+			switch change.Module {
+			case ParamModuleStake:
+				p := ecomapper.GetValidatorMapper(ctx).GetParams()
+				params = &p
+			case ParamModuleDistribution:
+				p := ecomapper.GetDistributionMapper(ctx).GetParams()
+				params = &p
+			case ParamModuleMint:
+				p := ecomapper.GetMintMapper(ctx).GetMintParams()
+				params = &p
+			case ParamModuleGov:
+				p := GetGovMapper(ctx).GetParams()
+				params = &p
+			case ParamModuleQSC:
+				p := ctx.Mapper(qsc.QSCMapperName).(*qsc.QSCMapper).GetParams()
+				params = &p
+			default:
+				return nil, fmt.Errorf("unknown param module: %s", change.Module)
+			}
+			modules[change.Module] = params
+		}
+
+		if err := changeParam(params, change.Key, change.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	for module, params := range modules {
+		var err error
+		switch module {
+		case ParamModuleStake:
+			err = validateStakeParams(*params.(*ecotypes.StakeParams))
+		case ParamModuleDistribution:
+			err = validateDistributionParams(*params.(*ecotypes.DistributionParams))
+		case ParamModuleMint:
+			err = validateMintParams(*params.(*ecotypes.MintParams))
+		case ParamModuleGov:
+			err = params.(*types.GovParams).Validate()
+		case ParamModuleQSC:
+			err = params.(*qsctypes.QSCParams).Validate()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s params: %s", module, err.Error())
+		}
+	}
+
+	return modules, nil
+}
+
+// 将params中JSON字段名为key的值替换为value
+func changeParam(params interface{}, key, value string) error {
+	bz, err := paramCdc.MarshalJSON(params)
+	if err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(bz, &fields); err != nil {
+		return err
+	}
+
+	if _, ok := fields[key]; !ok {
+		return fmt.Errorf("unknown param key: %s", key)
+	}
+	fields[key] = json.RawMessage(value)
+
+	bz, err = json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("invalid value of %s: %s", key, value)
+	}
+
+	if err = paramCdc.UnmarshalJSON(bz, params); err != nil {
+		return fmt.Errorf("invalid value of %s: %s", key, value)
+	}
+
+	return nil
+}
+
+func validFraction(frac qtypes.Fraction) bool {
+	return !frac.Value.IsNil() && frac.Value.GTE(qtypes.ZeroDec()) && frac.Value.LTE(qtypes.OneDec())
+}
+
+func validateStakeParams(params ecotypes.StakeParams) error {
+	if params.MaxValidatorCnt == 0 ||
+		params.ValidatorVotingStatusLen == 0 ||
+		params.ValidatorVotingStatusLeast == 0 ||
+		params.ValidatorVotingStatusLeast > params.ValidatorVotingStatusLen {
+		return errors.New("max_validator_cnt, voting_status_len and voting_status_least must be positive and voting_status_least must not exceed voting_status_len")
+	}
+	if !validFraction(params.SlashFractionDoubleSign) || !validFraction(params.SlashFractionDowntime) {
+		return errors.New("slash fraction must be between 0 and 1")
+	}
+	return nil
+}
+
+func validateDistributionParams(params ecotypes.DistributionParams) error {
+	if !validFraction(params.ProposerRewardRate) ||
+		!validFraction(params.CommunityRewardRate) ||
+		!validFraction(params.ProposerRewardRate.Add(params.CommunityRewardRate)) {
+		return errors.New("reward rates must be between 0 and 1")
+	}
+	if params.DelegatorsIncomePeriodHeight == 0 || params.GasPerUnitCost == 0 {
+		return errors.New("delegator_income_period_height and gas_per_unit_cost must be positive")
+	}
+	if int(params.CommunityPoolSpendThreshold) > len(params.CommunityPoolSpenders) {
+		return errors.New("community_pool_spend_threshold must not exceed the number of community_pool_spenders")
+	}
+	txTypes := make(map[string]bool)
+	for _, txGas := range params.GasSchedule.Txs {
+		if txGas.TxType == "" || txTypes[txGas.TxType] {
+			return errors.New("empty or repeated tx_type in gas_schedule")
+		}
+		txTypes[txGas.TxType] = true
+	}
+	return nil
+}
+
+func validateMintParams(params ecotypes.MintParams) error {
+	endTimes := make(map[int64]bool)
+	for _, phrase := range params.Phrases {
+		if phrase.AppliedAmount > phrase.TotalAmount {
+			return errors.New("applied_amount must not exceed total_amount")
+		}
+		if endTimes[phrase.EndTime.Unix()] {
+			return errors.New("repeated inflation phrase endtime")
+		}
+		endTimes[phrase.EndTime.Unix()] = true
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package gov
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strconv"
+
+	"github.com/QOSGroup/qbase/context"
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/module/gov/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+/*
+
+custom path:
+/custom/gov/$query path
+
+query path:
+	/proposal/:proposalID : 查询proposal
+	/proposals : 查询所有proposal
+	/deposit/:proposalID/:depositorAddr : 查询抵押
+	/deposits/:proposalID : 查询proposal的所有抵押
+	/vote/:proposalID/:voterAddr : 查询投票
+	/votes/:proposalID : 查询proposal的所有投票
+	/tally/:proposalID : 查询proposal当前投票统计
+	/params : 查询gov参数
+
+	xxx为bech32 address
+
+return:
+  json字节数组
+*/
+
+func Query(ctx context.Context, route []string, req abci.RequestQuery) (res []byte, err btypes.Error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = btypes.ErrInternal(string(debug.Stack()))
+			return
+		}
+	}()
+
+	if len(route) < 1 {
+		return nil, btypes.ErrInternal("custom query miss parameters")
+	}
+
+	govMapper := GetGovMapper(ctx)
+
+	var result interface{}
+	var e error
+
+	switch route[0] {
+	case types.QueryProposals:
+		result = govMapper.GetProposals()
+	case types.QueryParams:
+		result = govMapper.GetParams()
+	case types.QueryProposal, types.QueryDeposits, types.QueryVotes, types.QueryTally:
+		if len(route) < 2 {
+			return nil, btypes.ErrInternal("custom query miss parameters")
+		}
+		proposalID, pe := strconv.ParseUint(route[1], 10, 64)
+		if pe != nil {
+			return nil, btypes.ErrInternal(pe.Error())
+		}
+		result, e = queryProposalInfo(ctx, route[0], proposalID)
+	case types.QueryDeposit, types.QueryVote:
+		if len(route) < 3 {
+			return nil, btypes.ErrInternal("custom query miss parameters")
+		}
+		proposalID, pe := strconv.ParseUint(route[1], 10, 64)
+		if pe != nil {
+			return nil, btypes.ErrInternal(pe.Error())
+		}
+		addr, ae := btypes.GetAddrFromBech32(route[2])
+		if ae != nil {
+			return nil, btypes.ErrInternal(ae.Error())
+		}
+		result, e = queryAddressInfo(ctx, route[0], proposalID, addr)
+	default:
+		e = errors.New("not found match path")
+	}
+
+	if e != nil {
+		return nil, btypes.ErrInternal(e.Error())
+	}
+
+	data, e := govMapper.GetCodec().MarshalJSON(result)
+	if e != nil {
+		return nil, btypes.ErrInternal(e.Error())
+	}
+
+	return data, nil
+}
+
+func queryProposalInfo(ctx context.Context, path string, proposalID uint64) (interface{}, error) {
+	govMapper := GetGovMapper(ctx)
+
+	proposal, exists := govMapper.GetProposal(proposalID)
+	if !exists {
+		return nil, fmt.Errorf("proposal %d not exists", proposalID)
+	}
+
+	switch path {
+	case types.QueryDeposits:
+		return govMapper.GetDeposits(proposalID), nil
+	case types.QueryVotes:
+		return govMapper.GetVotes(proposalID), nil
+	case types.QueryTally:
+		if proposal.Status != types.StatusVotingPeriod {
+			return proposal.FinalTallyResult, nil
+		}
+		_, _, tallyResult := tally(ctx, proposal)
+		return tallyResult, nil
+	default:
+		return proposal, nil
+	}
+}
+
+func queryAddressInfo(ctx context.Context, path string, proposalID uint64, addr btypes.Address) (interface{}, error) {
+	govMapper := GetGovMapper(ctx)
+
+	if path == types.QueryDeposit {
+		deposit, exists := govMapper.GetDeposit(proposalID, addr)
+		if !exists {
+			return nil, fmt.Errorf("deposit not exists. proposal: %d, depositor: %s", proposalID, addr.String())
+		}
+		return deposit, nil
+	}
+
+	vote, exists := govMapper.GetVote(proposalID, addr)
+	if !exists {
+		return nil, fmt.Errorf("vote not exists. proposal: %d, voter: %s", proposalID, addr.String())
+	}
+	return vote, nil
+}
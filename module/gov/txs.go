@@ -0,0 +1,283 @@
+package gov
+
+import (
+	"fmt"
+	"time"
+
+	bacc "github.com/QOSGroup/qbase/account"
+	"github.com/QOSGroup/qbase/context"
+	"github.com/QOSGroup/qbase/txs"
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/module/eco"
+	ecomapper "github.com/QOSGroup/qos/module/eco/mapper"
+	"github.com/QOSGroup/qos/module/gov/types"
+	"github.com/QOSGroup/qos/module/supply"
+	supplytypes "github.com/QOSGroup/qos/module/supply/types"
+	qtypes "github.com/QOSGroup/qos/types"
+)
+
+// 提交提议
+type TxSubmitProposal struct {
+	Content        types.ProposalContent //提议内容
+	Proposer       btypes.Address        //提议人
+	InitialDeposit uint64                //初始抵押
+}
+
+var _ txs.ITx = (*TxSubmitProposal)(nil)
+
+func NewSubmitProposalTx(content types.ProposalContent, proposer btypes.Address, initialDeposit uint64) *TxSubmitProposal {
+	return &TxSubmitProposal{
+		Content:        content,
+		Proposer:       proposer,
+		InitialDeposit: initialDeposit,
+	}
+}
+
+func (tx *TxSubmitProposal) ValidateData(ctx context.Context) error {
+	if tx.Content == nil || len(tx.Proposer) == 0 {
+		return ErrInvalidInput(DefaultCodeSpace, "")
+	}
+
+	if err := tx.Content.ValidateBasic(); err != nil {
+		return ErrInvalidInput(DefaultCodeSpace, err.Error())
+	}
+
+	if err := validateQOSAccount(ctx, tx.Proposer, tx.InitialDeposit); err != nil {
+		return err
+	}
+
+	switch content := tx.Content.(type) {
+	case types.TextProposal:
+	case types.ParameterProposal:
+		if err := validateParamChanges(ctx, content.Params); err != nil {
+			return ErrInvalidParam(DefaultCodeSpace, err.Error())
+		}
+	case types.CommunityPoolSpendProposal:
+		pool := ecomapper.GetDistributionMapper(ctx).GetCommunityFeePool()
+		if pool.LT(btypes.NewInt(int64(content.Amount))) {
+			return ErrCommunityPoolNotEnough(DefaultCodeSpace, "")
+		}
+	default:
+		return ErrInvalidInput(DefaultCodeSpace, "unknown proposal type")
+	}
+
+	return nil
+}
+
+func (tx *TxSubmitProposal) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	govMapper := GetGovMapper(ctx)
+	params := govMapper.GetParams()
+
+	proposalID := govMapper.GetNextProposalID()
+	submitTime := ctx.BlockHeader().Time.UTC()
+	proposal := types.Proposal{
+		ProposalContent: tx.Content,
+		ProposalID:      proposalID,
+		Proposer:        tx.Proposer,
+		Status:          types.StatusDepositPeriod,
+		SubmitTime:      submitTime,
+		DepositEndTime:  submitTime.Add(time.Duration(params.MaxDepositPeriod) * time.Second),
+	}
+	govMapper.SetProposal(proposal)
+	govMapper.InsertInactiveProposalQueue(proposal.DepositEndTime, proposalID)
+	govMapper.SetNextProposalID(proposalID + 1)
+
+	if tx.InitialDeposit > 0 {
+		if err := addDeposit(ctx, &proposal, tx.Proposer, tx.InitialDeposit); err != nil {
+			return btypes.Result{Code: btypes.CodeInternal, Codespace: btypes.CodespaceType(err.Error())}, nil
+		}
+	}
+
+	return btypes.Result{Code: btypes.CodeOK, Data: []byte(fmt.Sprintf("%d", proposalID))}, nil
+}
+
+func (tx *TxSubmitProposal) GetSigner() []btypes.Address {
+	return []btypes.Address{tx.Proposer}
+}
+
+func (tx *TxSubmitProposal) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx *TxSubmitProposal) GasItems() uint64 {
+	return 0
+}
+
+func (tx *TxSubmitProposal) GetGasPayer() btypes.Address {
+	return tx.Proposer
+}
+
+func (tx *TxSubmitProposal) GetSignData() (ret []byte) {
+	ret = append(ret, cdc.MustMarshalBinaryBare(tx.Content)...)
+	ret = append(ret, tx.Proposer...)
+	ret = append(ret, btypes.Int2Byte(int64(tx.InitialDeposit))...)
+
+	return
+}
+
+// 提议抵押
+type TxDeposit struct {
+	ProposalID uint64         //ProposalID
+	Depositor  btypes.Address //抵押账户
+	Amount     uint64         //抵押QOS数量
+}
+
+var _ txs.ITx = (*TxDeposit)(nil)
+
+func NewDepositTx(proposalID uint64, depositor btypes.Address, amount uint64) *TxDeposit {
+	return &TxDeposit{
+		ProposalID: proposalID,
+		Depositor:  depositor,
+		Amount:     amount,
+	}
+}
+
+func (tx *TxDeposit) ValidateData(ctx context.Context) error {
+	if len(tx.Depositor) == 0 || tx.Amount == 0 {
+		return ErrInvalidInput(DefaultCodeSpace, "")
+	}
+
+	proposal, exists := GetGovMapper(ctx).GetProposal(tx.ProposalID)
+	if !exists {
+		return ErrProposalNotExists(DefaultCodeSpace, "")
+	}
+	if proposal.Status != types.StatusDepositPeriod && proposal.Status != types.StatusVotingPeriod {
+		return ErrWrongProposalStatus(DefaultCodeSpace, fmt.Sprintf("proposal %d is %s", tx.ProposalID, proposal.Status))
+	}
+
+	return validateQOSAccount(ctx, tx.Depositor, tx.Amount)
+}
+
+func (tx *TxDeposit) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	proposal, exists := GetGovMapper(ctx).GetProposal(tx.ProposalID)
+	if !exists {
+		return btypes.Result{Code: btypes.CodeInternal}, nil
+	}
+
+	if err := addDeposit(ctx, &proposal, tx.Depositor, tx.Amount); err != nil {
+		return btypes.Result{Code: btypes.CodeInternal, Codespace: btypes.CodespaceType(err.Error())}, nil
+	}
+
+	return btypes.Result{Code: btypes.CodeOK}, nil
+}
+
+func (tx *TxDeposit) GetSigner() []btypes.Address {
+	return []btypes.Address{tx.Depositor}
+}
+
+func (tx *TxDeposit) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx *TxDeposit) GasItems() uint64 {
+	return 0
+}
+
+func (tx *TxDeposit) GetGasPayer() btypes.Address {
+	return tx.Depositor
+}
+
+func (tx *TxDeposit) GetSignData() (ret []byte) {
+	ret = append(ret, btypes.Int2Byte(int64(tx.ProposalID))...)
+	ret = append(ret, tx.Depositor...)
+	ret = append(ret, btypes.Int2Byte(int64(tx.Amount))...)
+
+	return
+}
+
+// 提议投票
+type TxVote struct {
+	ProposalID uint64           //ProposalID
+	Voter      btypes.Address   //投票账户
+	Option     types.VoteOption //投票选项
+}
+
+var _ txs.ITx = (*TxVote)(nil)
+
+func NewVoteTx(proposalID uint64, voter btypes.Address, option types.VoteOption) *TxVote {
+	return &TxVote{
+		ProposalID: proposalID,
+		Voter:      voter,
+		Option:     option,
+	}
+}
+
+func (tx *TxVote) ValidateData(ctx context.Context) error {
+	if len(tx.Voter) == 0 || !types.ValidVoteOption(tx.Option) {
+		return ErrInvalidInput(DefaultCodeSpace, "")
+	}
+
+	proposal, exists := GetGovMapper(ctx).GetProposal(tx.ProposalID)
+	if !exists {
+		return ErrProposalNotExists(DefaultCodeSpace, "")
+	}
+	if proposal.Status != types.StatusVotingPeriod {
+		return ErrWrongProposalStatus(DefaultCodeSpace, fmt.Sprintf("proposal %d is not in voting period", tx.ProposalID))
+	}
+
+	return nil
+}
+
+func (tx *TxVote) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	GetGovMapper(ctx).SetVote(types.NewVote(tx.Voter, tx.ProposalID, tx.Option))
+
+	return btypes.Result{Code: btypes.CodeOK}, nil
+}
+
+func (tx *TxVote) GetSigner() []btypes.Address {
+	return []btypes.Address{tx.Voter}
+}
+
+func (tx *TxVote) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx *TxVote) GasItems() uint64 {
+	return 0
+}
+
+func (tx *TxVote) GetGasPayer() btypes.Address {
+	return tx.Voter
+}
+
+func (tx *TxVote) GetSignData() (ret []byte) {
+	ret = append(ret, btypes.Int2Byte(int64(tx.ProposalID))...)
+	ret = append(ret, tx.Voter...)
+	ret = append(ret, byte(tx.Option))
+
+	return
+}
+
+// 扣除账户QOS作为抵押, 抵押总量达到MinDeposit时proposal进入投票期
+func addDeposit(ctx context.Context, proposal *types.Proposal, depositor btypes.Address, amount uint64) error {
+	err := eco.DecrAccountQOS(ctx, depositor, btypes.NewInt(int64(amount)))
+	if err != nil {
+		return err
+	}
+	supply.GetSupplyMapper(ctx).LockQOS(supplytypes.Deposits, btypes.NewInt(int64(amount)))
+
+	govMapper := GetGovMapper(ctx)
+	totalDeposit := govMapper.AddDeposit(proposal, depositor, amount)
+
+	if proposal.Status == types.StatusDepositPeriod && totalDeposit >= govMapper.GetParams().MinDeposit {
+		govMapper.ActivateVotingPeriod(proposal, ctx.BlockHeader().Time)
+	}
+
+	return nil
+}
+
+func validateQOSAccount(ctx context.Context, addr btypes.Address, toPay uint64) error {
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	acc := accountMapper.GetAccount(addr)
+	if acc == nil {
+		return ErrAccountNotExists(DefaultCodeSpace, addr.String())
+	}
+
+	if toPay > 0 {
+		_, ok := qtypes.ToQOSAccount(acc)
+		if !ok || qtypes.SpendableQOS(acc, ctx.BlockHeader().Time.UTC()).LT(btypes.NewInt(int64(toPay))) {
+			return ErrNoEnoughQOS(DefaultCodeSpace, "No enough QOS in account: "+addr.String())
+		}
+	}
+	return nil
+}
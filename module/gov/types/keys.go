@@ -0,0 +1,152 @@
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	btypes "github.com/QOSGroup/qbase/types"
+)
+
+const (
+	GovMapperName = "governance"
+
+	//------query-------
+	Gov            = "gov"
+	QueryProposal  = "proposal"
+	QueryProposals = "proposals"
+	QueryDeposit   = "deposit"
+	QueryDeposits  = "deposits"
+	QueryVote      = "vote"
+	QueryVotes     = "votes"
+	QueryTally     = "tally"
+	QueryParams    = "params"
+)
+
+var (
+	proposalKey              = []byte{0x01} // 保存Proposal. key: ProposalID
+	depositKey               = []byte{0x02} // 保存Deposit. key: ProposalID + DepositorAddress
+	voteKey                  = []byte{0x03} // 保存Vote. key: ProposalID + VoterAddress
+	inactiveProposalQueueKey = []byte{0x11} // 处于抵押期的Proposal. key: DepositEndTime + ProposalID
+	activeProposalQueueKey   = []byte{0x12} // 处于投票期的Proposal. key: VotingEndTime + ProposalID
+
+	nextProposalIDKey = []byte("next_proposal_id")
+
+	// params
+	govParamsKey = []byte("gov_params")
+)
+
+func BuildGovParamsKey() []byte {
+	return govParamsKey
+}
+
+func BuildNextProposalIDKey() []byte {
+	return nextProposalIDKey
+}
+
+func BuildProposalKey(proposalID uint64) []byte {
+	return append(proposalKey, uint64ToBigEndian(proposalID)...)
+}
+
+func GetProposalPrefixKey() []byte {
+	return proposalKey
+}
+
+func BuildDepositPrefixKey(proposalID uint64) []byte {
+	return append(depositKey, uint64ToBigEndian(proposalID)...)
+}
+
+func BuildDepositKey(proposalID uint64, depositor btypes.Address) []byte {
+	return append(BuildDepositPrefixKey(proposalID), depositor...)
+}
+
+func BuildVotePrefixKey(proposalID uint64) []byte {
+	return append(voteKey, uint64ToBigEndian(proposalID)...)
+}
+
+func BuildVoteKey(proposalID uint64, voter btypes.Address) []byte {
+	return append(BuildVotePrefixKey(proposalID), voter...)
+}
+
+func GetInactiveProposalQueueKey() []byte {
+	return inactiveProposalQueueKey
+}
+
+func GetActiveProposalQueueKey() []byte {
+	return activeProposalQueueKey
+}
+
+func BuildInactiveProposalQueueKey(endTime time.Time, proposalID uint64) []byte {
+	return buildProposalQueueKey(inactiveProposalQueueKey, endTime, proposalID)
+}
+
+func BuildActiveProposalQueueKey(endTime time.Time, proposalID uint64) []byte {
+	return buildProposalQueueKey(activeProposalQueueKey, endTime, proposalID)
+}
+
+// 以结束时间秒数作为前缀, 保证队列按结束时间有序
+func buildProposalQueueKey(prefix []byte, endTime time.Time, proposalID uint64) []byte {
+	bz := make([]byte, 1+8+8)
+
+	copy(bz[0:1], prefix)
+	binary.BigEndian.PutUint64(bz[1:9], uint64(endTime.UTC().Unix()))
+	binary.BigEndian.PutUint64(bz[9:17], proposalID)
+
+	return bz
+}
+
+// 构建结束时间不晚于endTime的队列遍历上限(不包含)
+func BuildProposalQueueEndKey(prefix []byte, endTime time.Time) []byte {
+	bz := make([]byte, 1+8)
+
+	copy(bz[0:1], prefix)
+	binary.BigEndian.PutUint64(bz[1:9], uint64(endTime.UTC().Unix())+1)
+
+	return bz
+}
+
+func GetProposalIDFromQueueKey(key []byte) uint64 {
+	if len(key) != 1+8+8 {
+		panic("invalid proposal queue key length")
+	}
+
+	return binary.BigEndian.Uint64(key[9:])
+}
+
+func BuildQueryProposalCustomQueryPath(proposalID uint64) string {
+	return fmt.Sprintf("custom/%s/%s/%d", Gov, QueryProposal, proposalID)
+}
+
+func BuildQueryProposalsCustomQueryPath() string {
+	return fmt.Sprintf("custom/%s/%s", Gov, QueryProposals)
+}
+
+func BuildQueryDepositCustomQueryPath(proposalID uint64, depositor btypes.Address) string {
+	return fmt.Sprintf("custom/%s/%s/%d/%s", Gov, QueryDeposit, proposalID, depositor.String())
+}
+
+func BuildQueryDepositsCustomQueryPath(proposalID uint64) string {
+	return fmt.Sprintf("custom/%s/%s/%d", Gov, QueryDeposits, proposalID)
+}
+
+func BuildQueryVoteCustomQueryPath(proposalID uint64, voter btypes.Address) string {
+	return fmt.Sprintf("custom/%s/%s/%d/%s", Gov, QueryVote, proposalID, voter.String())
+}
+
+func BuildQueryVotesCustomQueryPath(proposalID uint64) string {
+	return fmt.Sprintf("custom/%s/%s/%d", Gov, QueryVotes, proposalID)
+}
+
+func BuildQueryTallyCustomQueryPath(proposalID uint64) string {
+	return fmt.Sprintf("custom/%s/%s/%d", Gov, QueryTally, proposalID)
+}
+
+func BuildQueryParamsCustomQueryPath() string {
+	return fmt.Sprintf("custom/%s/%s", Gov, QueryParams)
+}
+
+func uint64ToBigEndian(i uint64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, i)
+	return bz
+}
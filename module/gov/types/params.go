@@ -0,0 +1,49 @@
+package types
+
+import (
+	"errors"
+
+	qtypes "github.com/QOSGroup/qos/types"
+)
+
+type GovParams struct {
+	MinDeposit       uint64          `json:"min_deposit"`        // 进入投票期所需最低抵押QOS
+	MaxDepositPeriod uint32          `json:"max_deposit_period"` // 抵押期时长, 单位秒
+	VotingPeriod     uint32          `json:"voting_period"`      // 投票期时长, 单位秒
+	Quorum           qtypes.Fraction `json:"quorum"`             // 参与投票的绑定QOS占比下限
+	Threshold        qtypes.Fraction `json:"threshold"`          // 通过所需Yes占比(不含Abstain)
+	Veto             qtypes.Fraction `json:"veto"`               // 否决所需NoWithVeto占比
+}
+
+func NewGovParams(minDeposit uint64, maxDepositPeriod, votingPeriod uint32, quorum, threshold, veto qtypes.Fraction) GovParams {
+	return GovParams{
+		MinDeposit:       minDeposit,
+		MaxDepositPeriod: maxDepositPeriod,
+		VotingPeriod:     votingPeriod,
+		Quorum:           quorum,
+		Threshold:        threshold,
+		Veto:             veto,
+	}
+}
+
+func DefaultGovParams() GovParams {
+	return NewGovParams(10000, 86400*2, 86400*2,
+		qtypes.NewFraction(int64(334), int64(1000)), // 33.4%
+		qtypes.NewFraction(int64(50), int64(100)),   // 50%
+		qtypes.NewFraction(int64(334), int64(1000)), // 33.4%
+	)
+}
+
+func (params GovParams) Validate() error {
+	if params.MaxDepositPeriod == 0 || params.VotingPeriod == 0 {
+		return errors.New("max_deposit_period and voting_period must be positive")
+	}
+
+	for _, frac := range []qtypes.Fraction{params.Quorum, params.Threshold, params.Veto} {
+		if frac.Value.IsNil() || frac.Value.LT(qtypes.ZeroDec()) || frac.Value.GT(qtypes.OneDec()) {
+			return errors.New("quorum, threshold and veto must be between 0 and 1")
+		}
+	}
+
+	return nil
+}
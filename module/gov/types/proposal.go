@@ -0,0 +1,247 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	btypes "github.com/QOSGroup/qbase/types"
+)
+
+const (
+	MaxTitleLen       = 200
+	MaxDescriptionLen = 1000
+)
+
+type ProposalType byte
+
+const (
+	ProposalTypeNil                ProposalType = 0x00
+	ProposalTypeText               ProposalType = 0x01 // 文本提议
+	ProposalTypeParameterChange    ProposalType = 0x02 // 参数修改提议
+	ProposalTypeCommunityPoolSpend ProposalType = 0x03 // 社区奖励池使用提议
+)
+
+func ProposalTypeFromString(str string) (ProposalType, error) {
+	switch strings.ToLower(str) {
+	case "text":
+		return ProposalTypeText, nil
+	case "parameterchange":
+		return ProposalTypeParameterChange, nil
+	case "communitypoolspend":
+		return ProposalTypeCommunityPoolSpend, nil
+	default:
+		return ProposalTypeNil, fmt.Errorf("'%s' is not a valid proposal type", str)
+	}
+}
+
+func (pt ProposalType) String() string {
+	switch pt {
+	case ProposalTypeText:
+		return "Text"
+	case ProposalTypeParameterChange:
+		return "ParameterChange"
+	case ProposalTypeCommunityPoolSpend:
+		return "CommunityPoolSpend"
+	default:
+		return ""
+	}
+}
+
+// 提议内容
+type ProposalContent interface {
+	GetTitle() string
+	GetDescription() string
+	GetProposalType() ProposalType
+	ValidateBasic() error
+}
+
+func validateTitleAndDescription(title, description string) error {
+	if len(strings.TrimSpace(title)) == 0 || len(title) > MaxTitleLen {
+		return fmt.Errorf("title length must be between 1 and %d", MaxTitleLen)
+	}
+	if len(description) > MaxDescriptionLen {
+		return fmt.Errorf("description length must not exceed %d", MaxDescriptionLen)
+	}
+	return nil
+}
+
+// 文本提议
+type TextProposal struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+var _ ProposalContent = TextProposal{}
+
+func NewTextProposal(title, description string) TextProposal {
+	return TextProposal{
+		Title:       title,
+		Description: description,
+	}
+}
+
+func (tp TextProposal) GetTitle() string              { return tp.Title }
+func (tp TextProposal) GetDescription() string        { return tp.Description }
+func (tp TextProposal) GetProposalType() ProposalType { return ProposalTypeText }
+func (tp TextProposal) ValidateBasic() error {
+	return validateTitleAndDescription(tp.Title, tp.Description)
+}
+
+// 参数修改项, Value为参数值的JSON表示
+type Param struct {
+	Module string `json:"module"`
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+}
+
+func NewParam(module, key, value string) Param {
+	return Param{
+		Module: module,
+		Key:    key,
+		Value:  value,
+	}
+}
+
+func (p Param) String() string {
+	return fmt.Sprintf("%s/%s=%s", p.Module, p.Key, p.Value)
+}
+
+// 参数修改提议
+type ParameterProposal struct {
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Params      []Param `json:"params"`
+}
+
+var _ ProposalContent = ParameterProposal{}
+
+func NewParameterProposal(title, description string, params []Param) ParameterProposal {
+	return ParameterProposal{
+		Title:       title,
+		Description: description,
+		Params:      params,
+	}
+}
+
+func (pp ParameterProposal) GetTitle() string              { return pp.Title }
+func (pp ParameterProposal) GetDescription() string        { return pp.Description }
+func (pp ParameterProposal) GetProposalType() ProposalType { return ProposalTypeParameterChange }
+func (pp ParameterProposal) ValidateBasic() error {
+	if err := validateTitleAndDescription(pp.Title, pp.Description); err != nil {
+		return err
+	}
+	if len(pp.Params) == 0 {
+		return errors.New("params is empty")
+	}
+	for _, param := range pp.Params {
+		if len(param.Module) == 0 || len(param.Key) == 0 || len(param.Value) == 0 {
+			return fmt.Errorf("invalid param: %s", param)
+		}
+	}
+	return nil
+}
+
+// 社区奖励池使用提议
+type CommunityPoolSpendProposal struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Receiver    btypes.Address `json:"receiver"`
+	Amount      uint64         `json:"amount"`
+}
+
+var _ ProposalContent = CommunityPoolSpendProposal{}
+
+func NewCommunityPoolSpendProposal(title, description string, receiver btypes.Address, amount uint64) CommunityPoolSpendProposal {
+	return CommunityPoolSpendProposal{
+		Title:       title,
+		Description: description,
+		Receiver:    receiver,
+		Amount:      amount,
+	}
+}
+
+func (cp CommunityPoolSpendProposal) GetTitle() string       { return cp.Title }
+func (cp CommunityPoolSpendProposal) GetDescription() string { return cp.Description }
+func (cp CommunityPoolSpendProposal) GetProposalType() ProposalType {
+	return ProposalTypeCommunityPoolSpend
+}
+func (cp CommunityPoolSpendProposal) ValidateBasic() error {
+	if err := validateTitleAndDescription(cp.Title, cp.Description); err != nil {
+		return err
+	}
+	if len(cp.Receiver) == 0 || cp.Amount == 0 {
+		return errors.New("receiver is empty or amount is zero")
+	}
+	return nil
+}
+
+type ProposalStatus byte
+
+const (
+	StatusNil           ProposalStatus = 0x00
+	StatusDepositPeriod ProposalStatus = 0x01 // 抵押期
+	StatusVotingPeriod  ProposalStatus = 0x02 // 投票期
+	StatusPassed        ProposalStatus = 0x03 // 通过
+	StatusRejected      ProposalStatus = 0x04 // 未通过
+)
+
+func ProposalStatusFromString(str string) (ProposalStatus, error) {
+	switch strings.ToLower(str) {
+	case "depositperiod":
+		return StatusDepositPeriod, nil
+	case "votingperiod":
+		return StatusVotingPeriod, nil
+	case "passed":
+		return StatusPassed, nil
+	case "rejected":
+		return StatusRejected, nil
+	default:
+		return StatusNil, fmt.Errorf("'%s' is not a valid proposal status", str)
+	}
+}
+
+func (status ProposalStatus) String() string {
+	switch status {
+	case StatusDepositPeriod:
+		return "DepositPeriod"
+	case StatusVotingPeriod:
+		return "VotingPeriod"
+	case StatusPassed:
+		return "Passed"
+	case StatusRejected:
+		return "Rejected"
+	default:
+		return ""
+	}
+}
+
+// 投票统计结果, 按绑定的QOS计
+type TallyResult struct {
+	Yes        uint64 `json:"yes"`
+	Abstain    uint64 `json:"abstain"`
+	No         uint64 `json:"no"`
+	NoWithVeto uint64 `json:"no_with_veto"`
+}
+
+func (tr TallyResult) Total() uint64 {
+	return tr.Yes + tr.Abstain + tr.No + tr.NoWithVeto
+}
+
+type Proposal struct {
+	ProposalContent  ProposalContent `json:"proposal_content"`
+	ProposalID       uint64          `json:"proposal_id"`
+	Proposer         btypes.Address  `json:"proposer"`
+	Status           ProposalStatus  `json:"proposal_status"`
+	FinalTallyResult TallyResult     `json:"final_tally_result"`
+	SubmitTime       time.Time       `json:"submit_time"`
+	DepositEndTime   time.Time       `json:"deposit_end_time"`
+	TotalDeposit     uint64          `json:"total_deposit"`
+	VotingStartTime  time.Time       `json:"voting_start_time"`
+	VotingEndTime    time.Time       `json:"voting_end_time"`
+}
+
+func (p Proposal) GetTitle() string              { return p.ProposalContent.GetTitle() }
+func (p Proposal) GetDescription() string        { return p.ProposalContent.GetDescription() }
+func (p Proposal) GetProposalType() ProposalType { return p.ProposalContent.GetProposalType() }
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	btypes "github.com/QOSGroup/qbase/types"
+)
+
+type VoteOption byte
+
+const (
+	OptionEmpty      VoteOption = 0x00
+	OptionYes        VoteOption = 0x01
+	OptionAbstain    VoteOption = 0x02
+	OptionNo         VoteOption = 0x03
+	OptionNoWithVeto VoteOption = 0x04
+)
+
+func VoteOptionFromString(str string) (VoteOption, error) {
+	switch strings.ToLower(str) {
+	case "yes":
+		return OptionYes, nil
+	case "abstain":
+		return OptionAbstain, nil
+	case "no":
+		return OptionNo, nil
+	case "nowithveto":
+		return OptionNoWithVeto, nil
+	default:
+		return OptionEmpty, fmt.Errorf("'%s' is not a valid vote option", str)
+	}
+}
+
+func ValidVoteOption(option VoteOption) bool {
+	return option == OptionYes ||
+		option == OptionAbstain ||
+		option == OptionNo ||
+		option == OptionNoWithVeto
+}
+
+func (vo VoteOption) String() string {
+	switch vo {
+	case OptionYes:
+		return "Yes"
+	case OptionAbstain:
+		return "Abstain"
+	case OptionNo:
+		return "No"
+	case OptionNoWithVeto:
+		return "NoWithVeto"
+	default:
+		return ""
+	}
+}
+
+type Vote struct {
+	Voter      btypes.Address `json:"voter"`
+	ProposalID uint64         `json:"proposal_id"`
+	Option     VoteOption     `json:"option"`
+}
+
+func NewVote(voter btypes.Address, proposalID uint64, option VoteOption) Vote {
+	return Vote{
+		Voter:      voter,
+		ProposalID: proposalID,
+		Option:     option,
+	}
+}
+
+type Deposit struct {
+	Depositor  btypes.Address `json:"depositor"`
+	ProposalID uint64         `json:"proposal_id"`
+	Amount     uint64         `json:"amount"`
+}
+
+func NewDeposit(depositor btypes.Address, proposalID uint64, amount uint64) Deposit {
+	return Deposit{
+		Depositor:  depositor,
+		ProposalID: proposalID,
+		Amount:     amount,
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"github.com/QOSGroup/qbase/context"
 	btypes "github.com/QOSGroup/qbase/types"
 	ecomapper "github.com/QOSGroup/qos/module/eco/mapper"
+	"github.com/QOSGroup/qos/module/supply"
+	supplytypes "github.com/QOSGroup/qos/module/supply/types"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 )
@@ -51,6 +53,9 @@ func BeginBlocker(ctx context.Context, req abci.RequestBeginBlock) {
 			log.Debug("block mint", "height", height, "mint", rewardPerBlock)
 			distributionMapper := ecomapper.GetDistributionMapper(ctx)
 			distributionMapper.AddPreDistributionQOS(btypes.NewInt(int64(rewardPerBlock)))
+			supplyMapper := supply.GetSupplyMapper(ctx)
+			supplyMapper.IncrQOS(btypes.NewInt(int64(rewardPerBlock)))
+			supplyMapper.LockQOS(supplytypes.PreDistribution, btypes.NewInt(int64(rewardPerBlock)))
 		}
 	}
 }
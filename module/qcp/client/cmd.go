@@ -9,5 +9,28 @@ import (
 func TxCommands(cdc *amino.Codec) []*cobra.Command {
 	return bctypes.PostCommands(
 		InitQCPCmd(cdc),
+		UpdateQCPCmd(cdc),
+		PauseQCPCmd(cdc),
+		ResumeQCPCmd(cdc),
+		RemoveQCPCmd(cdc),
+		RevokeQCPCertsCmd(cdc),
+		RotateQCPRootCACmd(cdc),
+		CrossChainTransferCmd(cdc),
 	)
 }
+
+// 扩展qbase提供的qoscli query qcp指令: 保留out、in、tx, list替换为包含联盟链状态的查询
+func AddQueryCommands(cdc *amino.Codec, qcpCmd *cobra.Command) {
+	for _, cmd := range qcpCmd.Commands() {
+		if cmd.Name() == "list" {
+			qcpCmd.RemoveCommand(cmd)
+		}
+	}
+
+	qcpCmd.AddCommand(bctypes.GetCommands(
+		QueryQCPListCmd(cdc),
+		QueryQCPInfoCmd(cdc),
+		QueryQCPTxsCmd(cdc),
+	)...)
+	qcpCmd.AddCommand(QueryQCPSeqCmd(cdc))
+}
@@ -2,21 +2,41 @@ package qcp
 
 import (
 	"errors"
+	"fmt"
+	"io/ioutil"
+
 	"github.com/QOSGroup/kepler/cert"
 	qcliacc "github.com/QOSGroup/qbase/client/account"
 	"github.com/QOSGroup/qbase/client/context"
-	qclitx "github.com/QOSGroup/qbase/client/tx"
+	bctypes "github.com/QOSGroup/qbase/client/types"
 	"github.com/QOSGroup/qbase/txs"
+	btypes "github.com/QOSGroup/qbase/types"
+	distrcli "github.com/QOSGroup/qos/module/distribution/client"
 	"github.com/QOSGroup/qos/module/qcp"
+	qcptypes "github.com/QOSGroup/qos/module/qcp/types"
+	qscclient "github.com/QOSGroup/qos/module/qsc/client"
+	"github.com/QOSGroup/qos/types"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/tendermint/go-amino"
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/libs/common"
 )
 
 const (
-	flagCreator = "creator"
-	flagPathqcp = "qcp.crt"
+	flagCreator    = "creator"
+	flagPathqcp    = "qcp.crt"
+	flagSender     = "sender"
+	flagReceiver   = "receiver"
+	flagToChain    = "to-chain"
+	flagCoins      = "coins"
+	flagQCPChain   = "qcp-chain"
+	flagLimit      = "limit"
+	flagAsc        = "asc"
+	flagRoot       = "root"
+	flagCertHashes = "cert-hashes"
+	flagCrts       = "crts"
+	flagNewRootCA  = "new-root-ca"
 )
 
 func InitQCPCmd(cdc *amino.Codec) *cobra.Command {
@@ -24,7 +44,7 @@ func InitQCPCmd(cdc *amino.Codec) *cobra.Command {
 		Use:   "init-qcp",
 		Short: "init qcp",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return qclitx.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
 				pathqcp := viper.GetString(flagPathqcp)
 
 				creatorAddr, err := qcliacc.GetAddrFromFlag(ctx, flagCreator)
@@ -55,3 +75,283 @@ func InitQCPCmd(cdc *amino.Codec) *cobra.Command {
 
 	return cmd
 }
+
+func CrossChainTransferCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cross-chain-transfer",
+		Short: "transfer QOS and QSCs to qcp chain",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+				sender, err := qcliacc.GetAddrFromFlag(ctx, flagSender)
+				if err != nil {
+					return nil, err
+				}
+
+				receiver, err := qcliacc.GetAddrFromFlag(ctx, flagReceiver)
+				if err != nil {
+					return nil, err
+				}
+
+				qos, qscs, err := types.ParseCoins(viper.GetString(flagCoins))
+				if err != nil {
+					return nil, err
+				}
+
+				return qcp.NewCrossChainTransferTx(sender, receiver, viper.GetString(flagToChain), qos, qscs), nil
+			})
+		},
+	}
+
+	cmd.Flags().String(flagSender, "", "address or name of sender")
+	cmd.Flags().String(flagReceiver, "", "address or name of receiver on qcp chain")
+	cmd.Flags().String(flagToChain, "", "chain id of qcp chain")
+	cmd.Flags().String(flagCoins, "", "coins to transfer, eg: 10qos,100qstar")
+	cmd.MarkFlagRequired(flagSender)
+	cmd.MarkFlagRequired(flagReceiver)
+	cmd.MarkFlagRequired(flagToChain)
+	cmd.MarkFlagRequired(flagCoins)
+
+	return cmd
+}
+
+func UpdateQCPCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-qcp",
+		Short: "update qcp trust pubkey with new CA(QCP)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+				creatorAddr, err := qcliacc.GetAddrFromFlag(ctx, flagCreator)
+				if err != nil {
+					return nil, err
+				}
+
+				var crt = cert.Certificate{}
+				err = cdc.UnmarshalJSON(common.MustReadFile(viper.GetString(flagPathqcp)), &crt)
+				if err != nil {
+					return nil, err
+				}
+
+				_, ok := crt.CSR.Subj.(cert.QCPSubject)
+				if !ok {
+					return nil, errors.New("invalid crt file")
+				}
+
+				return qcp.NewUpdateQCPTx(creatorAddr, &crt), nil
+			})
+		},
+	}
+
+	cmd.Flags().String(flagCreator, "", "address or name of creator")
+	cmd.Flags().String(flagPathqcp, "", "path of CA(QCP)")
+	cmd.MarkFlagRequired(flagCreator)
+	cmd.MarkFlagRequired(flagPathqcp)
+
+	return cmd
+}
+
+func PauseQCPCmd(cdc *amino.Codec) *cobra.Command {
+	return qcpCreatorCmd(cdc, "pause-qcp", "pause qcp", func(chainId string, creator btypes.Address) txs.ITx {
+		return qcp.NewPauseQCPTx(chainId, creator)
+	})
+}
+
+func ResumeQCPCmd(cdc *amino.Codec) *cobra.Command {
+	return qcpCreatorCmd(cdc, "resume-qcp", "resume paused qcp", func(chainId string, creator btypes.Address) txs.ITx {
+		return qcp.NewResumeQCPTx(chainId, creator)
+	})
+}
+
+func RemoveQCPCmd(cdc *amino.Codec) *cobra.Command {
+	return qcpCreatorCmd(cdc, "remove-qcp", "remove qcp", func(chainId string, creator btypes.Address) txs.ITx {
+		return qcp.NewRemoveQCPTx(chainId, creator)
+	})
+}
+
+// QCP管理账户签名的交易
+func qcpCreatorCmd(cdc *amino.Codec, use, short string, newTx func(chainId string, creator btypes.Address) txs.ITx) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+				creatorAddr, err := qcliacc.GetAddrFromFlag(ctx, flagCreator)
+				if err != nil {
+					return nil, err
+				}
+
+				return newTx(viper.GetString(flagQCPChain), creatorAddr), nil
+			})
+		},
+	}
+
+	cmd.Flags().String(flagCreator, "", "address or name of qcp creator")
+	cmd.Flags().String(flagQCPChain, "", "chain id of qcp chain")
+	cmd.MarkFlagRequired(flagCreator)
+	cmd.MarkFlagRequired(flagQCPChain)
+
+	return cmd
+}
+
+func QueryQCPInfoCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "info [chainId]",
+		Short: "query qcp info, including creator and status",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.Query(qcptypes.BuildQueryQCPInfoCustomQueryPath(args[0]), []byte(""))
+			if err != nil {
+				return err
+			}
+
+			var result qcptypes.QCPInfo
+			cliCtx.Codec.UnmarshalJSON(res, &result)
+			return cliCtx.PrintResult(result)
+		},
+	}
+
+	return cmd
+}
+
+func QueryQCPListCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "list qcp chains with sequences and status",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.Query(qcptypes.BuildQueryQCPListCustomQueryPath(), []byte(""))
+			if err != nil {
+				return err
+			}
+
+			var result []qcptypes.QCPSummary
+			cliCtx.Codec.UnmarshalJSON(res, &result)
+			return cliCtx.PrintResult(result)
+		},
+	}
+
+	return cmd
+}
+
+func QueryQCPSeqCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "seq",
+		Short: "query max in/out sequence of qcp chain",
+	}
+
+	cmd.AddCommand(bctypes.GetCommands(
+		querySeqCmd(cdc, qcptypes.SeqIn, "query max sequence of TxQcp received from qcp chain"),
+		querySeqCmd(cdc, qcptypes.SeqOut, "query max sequence of TxQcp sent to qcp chain"),
+	)...)
+
+	return cmd
+}
+
+func querySeqCmd(cdc *amino.Codec, inOut, short string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   inOut + " [chainId]",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.Query(qcptypes.BuildQueryQCPSeqCustomQueryPath(inOut, args[0]), []byte(""))
+			if err != nil {
+				return err
+			}
+
+			var result int64
+			cliCtx.Codec.UnmarshalJSON(res, &result)
+			return cliCtx.PrintResult(result)
+		},
+	}
+
+	return cmd
+}
+
+func QueryQCPTxsCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "txs [chainId]",
+		Short: "query TxQcps to qcp chain ordered by sequence",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.Query(qcptypes.BuildQueryQCPTxsCustomQueryPath(args[0], uint64(viper.GetInt64(flagLimit)), viper.GetBool(flagAsc)), []byte(""))
+			if err != nil {
+				return err
+			}
+
+			var result []txs.TxQcp
+			cliCtx.Codec.UnmarshalJSON(res, &result)
+			return cliCtx.PrintResult(result)
+		},
+	}
+
+	cmd.Flags().Uint64(flagLimit, 20, fmt.Sprintf("max number of TxQcps, at most %d", qcptypes.MaxQueryTxsLimit))
+	cmd.Flags().Bool(flagAsc, false, "order by sequence asc, latest first by default")
+
+	return cmd
+}
+
+func RevokeQCPCertsCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke-qcp-certs",
+		Short: "add qcp certs to crl, signed by root ca",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+				rootAddr, err := qcliacc.GetAddrFromFlag(ctx, flagRoot)
+				if err != nil {
+					return nil, err
+				}
+				hashes, err := qscclient.ParseCertHashes(cdc, viper.GetString(flagCertHashes), viper.GetString(flagCrts))
+				if err != nil {
+					return nil, err
+				}
+				return qcp.NewRevokeQCPCertsTx(rootAddr, hashes), nil
+			})
+		},
+	}
+
+	cmd.Flags().String(flagRoot, "", "address or name of root ca key")
+	cmd.Flags().String(flagCertHashes, "", "hashes of certs to revoke, separated by comma")
+	cmd.Flags().String(flagCrts, "", "paths of cert files to revoke, separated by comma")
+	cmd.MarkFlagRequired(flagRoot)
+
+	return cmd
+}
+
+func RotateQCPRootCACmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate-qcp-root-ca",
+		Short: "rotate qcp root ca, signed by current root ca",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+				rootAddr, err := qcliacc.GetAddrFromFlag(ctx, flagRoot)
+				if err != nil {
+					return nil, err
+				}
+				bz, err := ioutil.ReadFile(viper.GetString(flagNewRootCA))
+				if err != nil {
+					return nil, err
+				}
+				var pubKey crypto.PubKey
+				err = cdc.UnmarshalJSON(bz, &pubKey)
+				if err != nil {
+					return nil, err
+				}
+				return qcp.NewRotateQCPRootCATx(rootAddr, pubKey), nil
+			})
+		},
+	}
+
+	cmd.Flags().String(flagRoot, "", "address or name of current root ca key")
+	cmd.Flags().String(flagNewRootCA, "", "path of new root ca pubkey file")
+	cmd.MarkFlagRequired(flagRoot)
+	cmd.MarkFlagRequired(flagNewRootCA)
+
+	return cmd
+}
@@ -17,4 +17,12 @@ func init() {
 
 func RegisterCodec(cdc *amino.Codec) {
 	cdc.RegisterConcrete(&TxInitQCP{}, "qos/txs/TxInitQCP", nil)
+	cdc.RegisterConcrete(&TxUpdateQCP{}, "qos/txs/TxUpdateQCP", nil)
+	cdc.RegisterConcrete(&TxPauseQCP{}, "qos/txs/TxPauseQCP", nil)
+	cdc.RegisterConcrete(&TxResumeQCP{}, "qos/txs/TxResumeQCP", nil)
+	cdc.RegisterConcrete(&TxRemoveQCP{}, "qos/txs/TxRemoveQCP", nil)
+	cdc.RegisterConcrete(&TxRevokeQCPCerts{}, "qos/txs/TxRevokeQCPCerts", nil)
+	cdc.RegisterConcrete(&TxRotateQCPRootCA{}, "qos/txs/TxRotateQCPRootCA", nil)
+	cdc.RegisterConcrete(&TxCrossChainTransfer{}, "qos/txs/TxCrossChainTransfer", nil)
+	cdc.RegisterConcrete(&TxCrossChainReceive{}, "qos/txs/TxCrossChainReceive", nil)
 }
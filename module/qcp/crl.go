@@ -0,0 +1,160 @@
+package qcp
+
+import (
+	"github.com/QOSGroup/kepler/cert"
+	"github.com/QOSGroup/qbase/context"
+	"github.com/QOSGroup/qbase/txs"
+	btypes "github.com/QOSGroup/qbase/types"
+	qcptypes "github.com/QOSGroup/qos/module/qcp/types"
+	"github.com/QOSGroup/qos/types"
+	"github.com/tendermint/tendermint/crypto"
+)
+
+// 证书由当前root CA签发, 区块时间在有效期内, 且未被吊销
+func validateQCPCA(ctx context.Context, crt cert.Certificate) error {
+	err := types.VerifyCertWithCRL(GetQCPRootCA(ctx), crt, ctx.BlockHeader().Time.UTC(), func(hash string) bool {
+		return IsQCPCertRevoked(ctx, hash)
+	})
+	if err == types.ErrCertRevoked {
+		return ErrCertRevoked(DefaultCodeSpace, "")
+	}
+	if err != nil {
+		return ErrWrongQCPCA(DefaultCodeSpace, err.Error())
+	}
+
+	return nil
+}
+
+// 签名账户为当前root CA公钥对应地址
+func validateQCPRoot(ctx context.Context, root btypes.Address) error {
+	if !types.IsRootCA(GetQCPRootCA(ctx), root) {
+		return ErrWrongRootCA(DefaultCodeSpace, "")
+	}
+
+	return nil
+}
+
+// revoke QCP certs, 由root CA签名, 信任公钥由吊销证书设置的联盟链将被暂停
+type TxRevokeQCPCerts struct {
+	Root       btypes.Address `json:"root"`        //root CA公钥对应地址
+	CertHashes []string       `json:"cert_hashes"` //吊销的证书hash
+}
+
+func NewRevokeQCPCertsTx(root btypes.Address, certHashes []string) *TxRevokeQCPCerts {
+	return &TxRevokeQCPCerts{
+		Root:       root,
+		CertHashes: certHashes,
+	}
+}
+
+func (tx TxRevokeQCPCerts) ValidateData(ctx context.Context) error {
+	if _, err := types.ValidateCertHashes(tx.CertHashes); err != nil {
+		return ErrInvalidInput(DefaultCodeSpace, err.Error())
+	}
+
+	return validateQCPRoot(ctx, tx.Root)
+}
+
+func (tx TxRevokeQCPCerts) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	result = btypes.Result{
+		Code: btypes.CodeOK,
+	}
+
+	hashes, _ := types.ValidateCertHashes(tx.CertHashes)
+	for _, hash := range hashes {
+		RevokeQCPCert(ctx, hash)
+	}
+
+	// 暂停信任公钥证书被吊销的联盟链
+	for _, chainId := range GetQCPChains(ctx) {
+		config := GetQCPConfig(ctx, chainId)
+		if config.CertHash != "" && IsQCPCertRevoked(ctx, config.CertHash) {
+			config.Status = qcptypes.StatusPaused
+			SetQCPConfig(ctx, chainId, config)
+		}
+	}
+
+	return
+}
+
+func (tx TxRevokeQCPCerts) GetSigner() []btypes.Address {
+	return []btypes.Address{tx.Root}
+}
+
+func (tx TxRevokeQCPCerts) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx TxRevokeQCPCerts) GasItems() uint64 {
+	return 0
+}
+
+func (tx TxRevokeQCPCerts) GetGasPayer() btypes.Address {
+	return tx.Root
+}
+
+func (tx TxRevokeQCPCerts) GetSignData() (ret []byte) {
+	ret = append(ret, tx.Root...)
+	for _, hash := range tx.CertHashes {
+		ret = append(ret, hash...)
+	}
+
+	return
+}
+
+// rotate QCP root CA, 由当前root CA签名, 轮换后旧root CA签发的证书失效
+type TxRotateQCPRootCA struct {
+	Root      btypes.Address `json:"root"`        //当前root CA公钥对应地址
+	NewRootCA crypto.PubKey  `json:"new_root_ca"` //新root CA公钥
+}
+
+func NewRotateQCPRootCATx(root btypes.Address, newRootCA crypto.PubKey) *TxRotateQCPRootCA {
+	return &TxRotateQCPRootCA{
+		Root:      root,
+		NewRootCA: newRootCA,
+	}
+}
+
+func (tx TxRotateQCPRootCA) ValidateData(ctx context.Context) error {
+	if err := validateQCPRoot(ctx, tx.Root); err != nil {
+		return err
+	}
+	if tx.NewRootCA == nil || tx.NewRootCA.Equals(GetQCPRootCA(ctx)) {
+		return ErrInvalidInput(DefaultCodeSpace, "invalid new root ca")
+	}
+
+	return nil
+}
+
+func (tx TxRotateQCPRootCA) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	result = btypes.Result{
+		Code: btypes.CodeOK,
+	}
+
+	SetQCPRootCA(ctx, tx.NewRootCA)
+
+	return
+}
+
+func (tx TxRotateQCPRootCA) GetSigner() []btypes.Address {
+	return []btypes.Address{tx.Root}
+}
+
+func (tx TxRotateQCPRootCA) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx TxRotateQCPRootCA) GasItems() uint64 {
+	return 0
+}
+
+func (tx TxRotateQCPRootCA) GetGasPayer() btypes.Address {
+	return tx.Root
+}
+
+func (tx TxRotateQCPRootCA) GetSignData() (ret []byte) {
+	ret = append(ret, tx.Root...)
+	ret = append(ret, tx.NewRootCA.Bytes()...)
+
+	return
+}
@@ -13,6 +13,15 @@ const (
 	CodeWrongQCPCA       btypes.CodeType = 403 // 证书有误
 	CodeCreatorNotExists btypes.CodeType = 404 // 创建账户不存在
 	CodeQCPExists        btypes.CodeType = 405 // QCP已存在
+	CodeQCPNotExists     btypes.CodeType = 406 // QCP不存在
+	CodeSenderNotExists  btypes.CodeType = 407 // 转出账户不存在
+	CodeCoinsNotEnough   btypes.CodeType = 408 // 余额不足
+	CodeNotFromQCP       btypes.CodeType = 409 // 非跨链交易
+	CodeWrongQCPStatus   btypes.CodeType = 410 // QCP状态有误
+	CodeWrongCreator     btypes.CodeType = 411 // 非QCP管理账户
+	CodeQCPInUse         btypes.CodeType = 412 // QCP存在未完成的跨链转账或锁定的QOS
+	CodeWrongRootCA      btypes.CodeType = 413 // 签名账户非root CA
+	CodeCertRevoked      btypes.CodeType = 414 // 证书已吊销
 )
 
 func msgOrDefaultMsg(msg string, code btypes.CodeType) string {
@@ -40,6 +49,24 @@ func codeToDefaultMsg(code btypes.CodeType) string {
 		return "creator not exists"
 	case CodeQCPExists:
 		return "qcp exists"
+	case CodeQCPNotExists:
+		return "qcp not exists"
+	case CodeSenderNotExists:
+		return "sender not exists"
+	case CodeCoinsNotEnough:
+		return "coins not enough"
+	case CodeNotFromQCP:
+		return "tx not from qcp"
+	case CodeWrongQCPStatus:
+		return "wrong qcp status"
+	case CodeWrongCreator:
+		return "wrong creator"
+	case CodeQCPInUse:
+		return "qcp in use"
+	case CodeWrongRootCA:
+		return "wrong root ca"
+	case CodeCertRevoked:
+		return "cert revoked"
 	default:
 		return btypes.CodeToDefaultMsg(code)
 	}
@@ -64,3 +91,39 @@ func ErrCreatorNotExists(codeSpace btypes.CodespaceType, msg string) btypes.Erro
 func ErrQCPExists(codeSpace btypes.CodespaceType, msg string) btypes.Error {
 	return newError(codeSpace, CodeQCPExists, msg)
 }
+
+func ErrQCPNotExists(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeQCPNotExists, msg)
+}
+
+func ErrSenderNotExists(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeSenderNotExists, msg)
+}
+
+func ErrCoinsNotEnough(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeCoinsNotEnough, msg)
+}
+
+func ErrNotFromQCP(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeNotFromQCP, msg)
+}
+
+func ErrWrongQCPStatus(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeWrongQCPStatus, msg)
+}
+
+func ErrWrongCreator(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeWrongCreator, msg)
+}
+
+func ErrQCPInUse(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeQCPInUse, msg)
+}
+
+func ErrWrongRootCA(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeWrongRootCA, msg)
+}
+
+func ErrCertRevoked(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeCertRevoked, msg)
+}
@@ -2,19 +2,28 @@ package qcp
 
 import (
 	"github.com/QOSGroup/qbase/context"
+	btypes "github.com/QOSGroup/qbase/types"
 	qcptypes "github.com/QOSGroup/qos/module/qcp/types"
+	"github.com/QOSGroup/qos/module/supply"
+	supplytypes "github.com/QOSGroup/qos/module/supply/types"
 	"github.com/tendermint/tendermint/crypto"
 )
 
 type GenesisState struct {
-	RootPubKey crypto.PubKey      `json:"ca_root_pub_key"`
-	QCPs       []qcptypes.QCPInfo `json:"qcps""`
+	RootPubKey crypto.PubKey                 `json:"ca_root_pub_key"`
+	QCPs       []qcptypes.QCPInfo            `json:"qcps"`
+	Transfers  []qcptypes.CrossChainTransfer `json:"transfers"`
+	LockedQOS  []qcptypes.LockedQOS          `json:"locked_qos"`
+	LockedQSCs []qcptypes.LockedQSCs         `json:"locked_qscs"`
+	CRL        []string                      `json:"crl"` //吊销证书hash
 }
 
-func NewGenesisState(pubKey crypto.PubKey, qcps []qcptypes.QCPInfo) GenesisState {
+func NewGenesisState(pubKey crypto.PubKey, qcps []qcptypes.QCPInfo, transfers []qcptypes.CrossChainTransfer, lockedQOS []qcptypes.LockedQOS) GenesisState {
 	return GenesisState{
 		RootPubKey: pubKey,
 		QCPs:       qcps,
+		Transfers:  transfers,
+		LockedQOS:  lockedQOS,
 	}
 }
 
@@ -28,12 +37,40 @@ func InitGenesis(ctx context.Context, data GenesisState) {
 		qcpMapper.SetMaxChainInSequence(qcp.ChainId, qcp.SequenceIn)
 		qcpMapper.SetMaxChainOutSequence(qcp.ChainId, qcp.SequenceOut)
 		qcpMapper.SetChainInTrustPubKey(qcp.ChainId, qcp.PubKey)
+		SetQCPConfig(ctx, qcp.ChainId, qcp.GetConfig())
 		for _, tx := range qcp.OutTxs {
 			qcpMapper.SetChainOutTxs(qcp.ChainId, tx.Sequence, &tx)
 		}
 	}
+
+	escrow := btypes.ZeroInt()
+	for _, transfer := range data.Transfers {
+		SetCrossChainTransfer(ctx, transfer)
+		escrow = escrow.Add(transfer.QOS.NilToZero())
+	}
+
+	locked := btypes.ZeroInt()
+	for _, lockedQOS := range data.LockedQOS {
+		SetLockedQOS(ctx, lockedQOS.ChainId, lockedQOS.Amount)
+		locked = locked.Add(lockedQOS.Amount.NilToZero())
+	}
+
+	supplyMapper := supply.GetSupplyMapper(ctx)
+	supplyMapper.SetLockedQOS(supplytypes.QCPEscrow, escrow)
+	supplyMapper.SetLockedQOS(supplytypes.QCPLocked, locked)
+
+	for _, locked := range data.LockedQSCs {
+		SetLockedQSCs(ctx, locked.ChainId, locked.QSCs)
+	}
+
+	for _, hash := range data.CRL {
+		RevokeQCPCert(ctx, hash)
+	}
 }
 
 func ExportGenesis(ctx context.Context) GenesisState {
-	return NewGenesisState(GetQCPRootCA(ctx), ExportQCPs(ctx))
+	state := NewGenesisState(GetQCPRootCA(ctx), ExportQCPs(ctx), GetCrossChainTransfers(ctx), GetAllLockedQOS(ctx))
+	state.LockedQSCs = GetAllLockedQSCs(ctx)
+	state.CRL = GetQCPCRL(ctx)
+	return state
 }
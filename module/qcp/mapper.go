@@ -1,18 +1,36 @@
 package qcp
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
+
 	"github.com/QOSGroup/qbase/context"
 	"github.com/QOSGroup/qbase/qcp"
 	"github.com/QOSGroup/qbase/store"
 	"github.com/QOSGroup/qbase/txs"
+	btypes "github.com/QOSGroup/qbase/types"
 	qcptypes "github.com/QOSGroup/qos/module/qcp/types"
+	"github.com/QOSGroup/qos/types"
 	"github.com/tendermint/tendermint/crypto"
 )
 
 const (
 	QCPRootCAKey = "rootca"
 
+	CrossChainTransferPrefix = "transfer/"
+	CrossChainTransferKey    = "transfer/%s/%d" // key，目标链、out sequence，保存qcptypes.CrossChainTransfer
+	LockedQOSPrefix          = "locked/"
+	LockedQOSKey             = "locked/%s" // key，联盟链，保存转出至该链的QOS
+	LockedQSCsPrefix         = "lockedqscs/"
+	LockedQSCsKey            = "lockedqscs/%s" // key，联盟链，保存转出至该链的QSCs
+	QCPConfigKey             = "config/%s" // key，联盟链，保存qcptypes.QCPConfig
+	CRLPrefix                = "crl/"
+	CRLKey                   = "crl/%s" // key，证书hash，保存已吊销的QCP证书
+
 	QCPTxExportLimit = 100 // QCP TX 导出条数限制
+
+	CrossChainReceiveMaxGas = 100000 // 发往联盟链的TxCrossChainReceive max-gas
 )
 
 func GetQCPMapper(ctx context.Context) *qcp.QcpMapper {
@@ -33,11 +51,35 @@ func GetQCPRootCA(ctx context.Context) crypto.PubKey {
 	return pubKey
 }
 
+// 吊销证书, hash为types.CertHash
+func RevokeQCPCert(ctx context.Context, hash string) {
+	qcpMapper := GetQCPMapper(ctx)
+	qcpMapper.Set([]byte(fmt.Sprintf(CRLKey, hash)), true)
+}
+
+func IsQCPCertRevoked(ctx context.Context, hash string) bool {
+	qcpMapper := GetQCPMapper(ctx)
+	var revoked bool
+	qcpMapper.Get([]byte(fmt.Sprintf(CRLKey, hash)), &revoked)
+	return revoked
+}
+
+// 吊销列表
+func GetQCPCRL(ctx context.Context) []string {
+	qcpMapper := GetQCPMapper(ctx)
+	crl := make([]string, 0)
+	qcpMapper.IteratorWithKV([]byte(CRLPrefix), func(key []byte, value []byte) (stop bool) {
+		crl = append(crl, string(key[len(CRLPrefix):]))
+		return false
+	})
+	return crl
+}
+
 // TODO prefix定义到qbase中
 func GetQCPTxs(ctx context.Context, chainId string) []txs.TxQcp {
 	qcpMapper := ctx.Mapper(qcp.QcpMapperName).(*qcp.QcpMapper)
 	qcpTxs := make([]txs.TxQcp, 0)
-	qcpMapper.Iterator([]byte("tx/out/"+chainId), func(bz []byte) (stop bool) {
+	qcpMapper.Iterator([]byte("tx/out/"+chainId+"/"), func(bz []byte) (stop bool) {
 		tx := txs.TxQcp{}
 		qcpMapper.DecodeObject(bz, &tx)
 		qcpTxs = append(qcpTxs, tx)
@@ -47,24 +89,35 @@ func GetQCPTxs(ctx context.Context, chainId string) []txs.TxQcp {
 	return qcpTxs
 }
 
+// 按sequence排序返回待输出TxQcp, limit为0时返回全部
 // TODO prefix定义到qbase中
 func GetQCPTxsWithLimit(ctx context.Context, chainId string, limit uint64, asc bool) []txs.TxQcp {
 	qcpMapper := ctx.Mapper(qcp.QcpMapperName).(*qcp.QcpMapper)
-	qcpTxs := make([]txs.TxQcp, 0)
-	var iterator store.Iterator
-	if asc {
-		iterator = store.KVStorePrefixIterator(qcpMapper.GetStore(), []byte("tx/out/"+chainId))
-	} else {
-		iterator = store.KVStoreReversePrefixIterator(qcpMapper.GetStore(), []byte("tx/out/"+chainId))
+
+	// key中sequence为十进制字符串, 字节序与数值序不一致, 需按数值排序
+	prefix := "tx/out/" + chainId + "/"
+	sequences := make([]int64, 0)
+	qcpMapper.IteratorWithKV([]byte(prefix), func(key []byte, value []byte) (stop bool) {
+		if sequence, err := strconv.ParseInt(string(key[len(prefix):]), 10, 64); err == nil {
+			sequences = append(sequences, sequence)
+		}
+		return false
+	})
+	sort.Slice(sequences, func(i, j int) bool {
+		if asc {
+			return sequences[i] < sequences[j]
+		}
+		return sequences[i] > sequences[j]
+	})
+	if limit > 0 && uint64(len(sequences)) > limit {
+		sequences = sequences[:limit]
 	}
-	defer iterator.Close()
-	for ; iterator.Valid(); iterator.Next() {
+
+	qcpTxs := make([]txs.TxQcp, 0, len(sequences))
+	for _, sequence := range sequences {
 		tx := txs.TxQcp{}
-		qcpMapper.DecodeObject(iterator.Value(), &tx)
+		qcpMapper.Get(qcp.BuildOutSequenceTxKey(chainId, sequence), &tx)
 		qcpTxs = append(qcpTxs, tx)
-		if uint64(len(qcpTxs)) == limit {
-			break
-		}
 	}
 
 	return qcpTxs
@@ -73,6 +126,24 @@ func GetQCPTxsWithLimit(ctx context.Context, chainId string, limit uint64, asc b
 // TODO prefix定义到qbase中
 func ExportQCPs(ctx context.Context) []qcptypes.QCPInfo {
 	qcpMapper := ctx.Mapper(qcp.QcpMapperName).(*qcp.QcpMapper)
+
+	qcps := make([]qcptypes.QCPInfo, 0)
+	for _, chaiId := range GetQCPChains(ctx) {
+		qcp := qcptypes.NewQCPInfo(chaiId,
+			qcpMapper.GetMaxChainOutSequence(chaiId),
+			qcpMapper.GetMaxChainInSequence(chaiId),
+			qcpMapper.GetChainInTrustPubKey(chaiId),
+			GetQCPConfig(ctx, chaiId),
+			GetQCPTxsWithLimit(ctx, chaiId, QCPTxExportLimit, false))
+		qcps = append(qcps, *qcp)
+	}
+
+	return qcps
+}
+
+// 已初始化的联盟链
+func GetQCPChains(ctx context.Context) []string {
+	qcpMapper := ctx.Mapper(qcp.QcpMapperName).(*qcp.QcpMapper)
 	qcpChains := make([]string, 0)
 
 	prefix := []byte("sequence/in/")
@@ -87,15 +158,131 @@ func ExportQCPs(ctx context.Context) []qcptypes.QCPInfo {
 		iter.Next()
 	}
 
-	qcps := make([]qcptypes.QCPInfo, 0)
-	for _, chaiId := range qcpChains {
-		qcp := qcptypes.NewQCPInfo(chaiId,
-			qcpMapper.GetMaxChainOutSequence(chaiId),
-			qcpMapper.GetMaxChainInSequence(chaiId),
-			qcpMapper.GetChainInTrustPubKey(chaiId),
-			GetQCPTxsWithLimit(ctx, chaiId, QCPTxExportLimit, false))
-		qcps = append(qcps, *qcp)
+	return qcpChains
+}
+
+// 保存QCP管理信息
+func SetQCPConfig(ctx context.Context, chainId string, config qcptypes.QCPConfig) {
+	qcpMapper := GetQCPMapper(ctx)
+	qcpMapper.Set([]byte(fmt.Sprintf(QCPConfigKey, chainId)), config)
+}
+
+// 获取QCP管理信息, 不存在时返回StatusActive
+func GetQCPConfig(ctx context.Context, chainId string) (config qcptypes.QCPConfig) {
+	qcpMapper := GetQCPMapper(ctx)
+	qcpMapper.Get([]byte(fmt.Sprintf(QCPConfigKey, chainId)), &config)
+	return
+}
+
+func IsQCPPaused(ctx context.Context, chainId string) bool {
+	return GetQCPConfig(ctx, chainId).Status == qcptypes.StatusPaused
+}
+
+// 删除QCP信任公钥、sequence、待输出TxQcp及管理信息
+func RemoveQCP(ctx context.Context, chainId string) {
+	qcpMapper := GetQCPMapper(ctx)
+	qcpMapper.Del(qcp.BuildInPubkeyKey(chainId))
+	qcpMapper.Del(qcp.BuildInSequenceKey(chainId))
+	qcpMapper.Del(qcp.BuildOutSequenceKey(chainId))
+	qcpMapper.Del([]byte(fmt.Sprintf(QCPConfigKey, chainId)))
+
+	var keys [][]byte
+	qcpMapper.IteratorWithKV([]byte("tx/out/"+chainId+"/"), func(key []byte, value []byte) (stop bool) {
+		keys = append(keys, key)
+		return false
+	})
+	for _, key := range keys {
+		qcpMapper.Del(key)
 	}
+}
 
-	return qcps
+// 保存跨链转账记录
+func SetCrossChainTransfer(ctx context.Context, transfer qcptypes.CrossChainTransfer) {
+	qcpMapper := GetQCPMapper(ctx)
+	qcpMapper.Set([]byte(fmt.Sprintf(CrossChainTransferKey, transfer.ChainId, transfer.Sequence)), transfer)
+}
+
+// 获取跨链转账记录
+func GetCrossChainTransfer(ctx context.Context, chainId string, sequence int64) (transfer qcptypes.CrossChainTransfer, exists bool) {
+	qcpMapper := GetQCPMapper(ctx)
+	exists = qcpMapper.Get([]byte(fmt.Sprintf(CrossChainTransferKey, chainId, sequence)), &transfer)
+	return
+}
+
+// 删除跨链转账记录
+func DeleteCrossChainTransfer(ctx context.Context, chainId string, sequence int64) {
+	qcpMapper := GetQCPMapper(ctx)
+	qcpMapper.Del([]byte(fmt.Sprintf(CrossChainTransferKey, chainId, sequence)))
+}
+
+// 所有等待执行结果的跨链转账记录
+func GetCrossChainTransfers(ctx context.Context) []qcptypes.CrossChainTransfer {
+	qcpMapper := GetQCPMapper(ctx)
+	transfers := make([]qcptypes.CrossChainTransfer, 0)
+	qcpMapper.Iterator([]byte(CrossChainTransferPrefix), func(bz []byte) (stop bool) {
+		transfer := qcptypes.CrossChainTransfer{}
+		qcpMapper.DecodeObject(bz, &transfer)
+		transfers = append(transfers, transfer)
+		return false
+	})
+
+	return transfers
+}
+
+// 转出至联盟链的QOS
+func GetLockedQOS(ctx context.Context, chainId string) btypes.BigInt {
+	qcpMapper := GetQCPMapper(ctx)
+	amount := btypes.ZeroInt()
+	qcpMapper.Get([]byte(fmt.Sprintf(LockedQOSKey, chainId)), &amount)
+	return amount.NilToZero()
+}
+
+func SetLockedQOS(ctx context.Context, chainId string, amount btypes.BigInt) {
+	qcpMapper := GetQCPMapper(ctx)
+	qcpMapper.Set([]byte(fmt.Sprintf(LockedQOSKey, chainId)), amount)
+}
+
+// 各联盟链锁定的QOS
+func GetAllLockedQOS(ctx context.Context) []qcptypes.LockedQOS {
+	qcpMapper := GetQCPMapper(ctx)
+	locked := make([]qcptypes.LockedQOS, 0)
+	qcpMapper.IteratorWithKV([]byte(LockedQOSPrefix), func(key []byte, value []byte) (stop bool) {
+		amount := btypes.ZeroInt()
+		qcpMapper.DecodeObject(value, &amount)
+		locked = append(locked, qcptypes.LockedQOS{ChainId: string(key[len(LockedQOSPrefix):]), Amount: amount})
+		return false
+	})
+
+	return locked
+}
+
+// 转出至联盟链的QSCs
+func GetLockedQSCs(ctx context.Context, chainId string) types.QSCs {
+	qcpMapper := GetQCPMapper(ctx)
+	var qscs types.QSCs
+	qcpMapper.Get([]byte(fmt.Sprintf(LockedQSCsKey, chainId)), &qscs)
+	return qscs
+}
+
+func SetLockedQSCs(ctx context.Context, chainId string, qscs types.QSCs) {
+	qcpMapper := GetQCPMapper(ctx)
+	if qscs.IsZero() {
+		qcpMapper.Del([]byte(fmt.Sprintf(LockedQSCsKey, chainId)))
+		return
+	}
+	qcpMapper.Set([]byte(fmt.Sprintf(LockedQSCsKey, chainId)), qscs)
+}
+
+// 各联盟链锁定的QSCs
+func GetAllLockedQSCs(ctx context.Context) []qcptypes.LockedQSCs {
+	qcpMapper := GetQCPMapper(ctx)
+	locked := make([]qcptypes.LockedQSCs, 0)
+	qcpMapper.IteratorWithKV([]byte(LockedQSCsPrefix), func(key []byte, value []byte) (stop bool) {
+		var qscs types.QSCs
+		qcpMapper.DecodeObject(value, &qscs)
+		locked = append(locked, qcptypes.LockedQSCs{ChainId: string(key[len(LockedQSCsPrefix):]), QSCs: qscs})
+		return false
+	})
+
+	return locked
 }
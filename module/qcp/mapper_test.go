@@ -0,0 +1,49 @@
+package qcp
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/QOSGroup/qbase/txs"
+	"github.com/QOSGroup/qos/module/qcp/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+func TestGetQCPTxsWithLimit(t *testing.T) {
+	ctx := defaultContext()
+	qcpMapper := GetQCPMapper(ctx)
+
+	for i := int64(1); i <= 12; i++ {
+		qcpMapper.SetChainOutTxs("aoe", i, &txs.TxQcp{From: "qos", To: "aoe", Sequence: i})
+	}
+	//前缀相同的其他联盟链
+	qcpMapper.SetChainOutTxs("aoe-1000", 1, &txs.TxQcp{From: "qos", To: "aoe-1000", Sequence: 1})
+
+	//按sequence数值排序
+	qcpTxs := GetQCPTxsWithLimit(ctx, "aoe", 3, false)
+	require.Equal(t, 3, len(qcpTxs))
+	require.Equal(t, int64(12), qcpTxs[0].Sequence)
+	require.Equal(t, int64(10), qcpTxs[2].Sequence)
+
+	qcpTxs = GetQCPTxsWithLimit(ctx, "aoe", 3, true)
+	require.Equal(t, int64(1), qcpTxs[0].Sequence)
+	require.Equal(t, int64(3), qcpTxs[2].Sequence)
+
+	require.Equal(t, 12, len(GetQCPTxsWithLimit(ctx, "aoe", 0, true)))
+	require.Equal(t, 12, len(GetQCPTxs(ctx, "aoe")))
+
+	//查询每次最多返回MaxQueryTxsLimit条
+	for i := int64(13); i <= types.MaxQueryTxsLimit+10; i++ {
+		qcpMapper.SetChainOutTxs("aoe", i, &txs.TxQcp{From: "qos", To: "aoe", Sequence: i})
+	}
+	qcpMapper.SetChainInTrustPubKey("aoe", ed25519.GenPrivKey().PubKey())
+	_, err := Query(ctx, []string{types.QueryTxs, "aoe", fmt.Sprint(types.MaxQueryTxsLimit + 1), "true"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+	bz, err := Query(ctx, []string{types.QueryTxs, "aoe", "0", "true"}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var result []txs.TxQcp
+	require.Nil(t, qcpMapper.GetCodec().UnmarshalJSON(bz, &result))
+	require.Equal(t, types.MaxQueryTxsLimit, len(result))
+}
@@ -0,0 +1,144 @@
+package qcp
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strconv"
+
+	"github.com/QOSGroup/qbase/context"
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/module/qcp/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+/*
+
+custom path:
+/custom/qcp/$query path
+
+query path:
+	/list : 查询联盟链列表
+	/info/:chainId : 查询QCP信息
+	/seq/in/:chainId : 查询in sequence
+	/seq/out/:chainId : 查询out sequence
+	/txs/:chainId/:limit/:asc : 按sequence排序查询待输出的TxQcp, limit不超过MaxQueryTxsLimit, 为0时返回MaxQueryTxsLimit条
+
+return:
+  json字节数组
+*/
+
+func Query(ctx context.Context, route []string, req abci.RequestQuery) (res []byte, err btypes.Error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = btypes.ErrInternal(string(debug.Stack()))
+			return
+		}
+	}()
+
+	if len(route) == 0 || (route[0] != types.QueryList && len(route) < 2) {
+		return nil, btypes.ErrInternal("custom query miss parameters")
+	}
+
+	var result interface{}
+	var e error
+
+	switch route[0] {
+	case types.QueryList:
+		result = queryQCPList(ctx)
+	case types.QueryInfo:
+		result, e = queryQCPInfo(ctx, route[1])
+	case types.QuerySeq:
+		if len(route) < 3 {
+			return nil, btypes.ErrInternal("custom query miss parameters")
+		}
+		result, e = queryQCPSeq(ctx, route[1], route[2])
+	case types.QueryTxs:
+		if len(route) < 4 {
+			return nil, btypes.ErrInternal("custom query miss parameters")
+		}
+		result, e = queryQCPTxs(ctx, route[1], route[2], route[3])
+	default:
+		e = errors.New("not found match path")
+	}
+
+	if e != nil {
+		return nil, btypes.ErrInternal(e.Error())
+	}
+
+	data, e := GetQCPMapper(ctx).GetCodec().MarshalJSON(result)
+	if e != nil {
+		return nil, btypes.ErrInternal(e.Error())
+	}
+
+	return data, nil
+}
+
+func queryQCPInfo(ctx context.Context, chainId string) (interface{}, error) {
+	qcpMapper := GetQCPMapper(ctx)
+	pubKey := qcpMapper.GetChainInTrustPubKey(chainId)
+	if pubKey == nil {
+		return nil, fmt.Errorf("qcp %s not exists", chainId)
+	}
+
+	return types.NewQCPInfo(chainId,
+		qcpMapper.GetMaxChainOutSequence(chainId),
+		qcpMapper.GetMaxChainInSequence(chainId),
+		pubKey,
+		GetQCPConfig(ctx, chainId),
+		nil), nil
+}
+
+func queryQCPList(ctx context.Context) interface{} {
+	qcpMapper := GetQCPMapper(ctx)
+	list := make([]types.QCPSummary, 0)
+	for _, chainId := range GetQCPChains(ctx) {
+		list = append(list, types.QCPSummary{
+			ChainId:     chainId,
+			SequenceOut: qcpMapper.GetMaxChainOutSequence(chainId),
+			SequenceIn:  qcpMapper.GetMaxChainInSequence(chainId),
+			Status:      GetQCPConfig(ctx, chainId).Status,
+		})
+	}
+
+	return list
+}
+
+func queryQCPSeq(ctx context.Context, inOut, chainId string) (interface{}, error) {
+	qcpMapper := GetQCPMapper(ctx)
+	if qcpMapper.GetChainInTrustPubKey(chainId) == nil {
+		return nil, fmt.Errorf("qcp %s not exists", chainId)
+	}
+
+	switch inOut {
+	case types.SeqIn:
+		return qcpMapper.GetMaxChainInSequence(chainId), nil
+	case types.SeqOut:
+		return qcpMapper.GetMaxChainOutSequence(chainId), nil
+	}
+
+	return nil, fmt.Errorf("invalid sequence type %s", inOut)
+}
+
+func queryQCPTxs(ctx context.Context, chainId, limitStr, ascStr string) (interface{}, error) {
+	if GetQCPMapper(ctx).GetChainInTrustPubKey(chainId) == nil {
+		return nil, fmt.Errorf("qcp %s not exists", chainId)
+	}
+	limit, err := strconv.ParseUint(limitStr, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	if limit > types.MaxQueryTxsLimit {
+		return nil, fmt.Errorf("limit must not exceed %d", types.MaxQueryTxsLimit)
+	}
+	if limit == 0 {
+		limit = types.MaxQueryTxsLimit
+	}
+	asc, err := strconv.ParseBool(ascStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetQCPTxsWithLimit(ctx, chainId, limit, asc), nil
+}
@@ -0,0 +1,279 @@
+package qcp
+
+import (
+	"fmt"
+
+	bacc "github.com/QOSGroup/qbase/account"
+	"github.com/QOSGroup/qbase/context"
+	"github.com/QOSGroup/qbase/txs"
+	btypes "github.com/QOSGroup/qbase/types"
+	qcptypes "github.com/QOSGroup/qos/module/qcp/types"
+	"github.com/QOSGroup/qos/module/qsc"
+	"github.com/QOSGroup/qos/module/supply"
+	supplytypes "github.com/QOSGroup/qos/module/supply/types"
+	"github.com/QOSGroup/qos/types"
+)
+
+// cross chain transfer, 转出QOS、QSCs至联盟链
+type TxCrossChainTransfer struct {
+	Sender   btypes.Address `json:"sender"`   //转出账户
+	Receiver btypes.Address `json:"receiver"` //目标链接收账户
+	ChainId  string         `json:"chain_id"` //目标链
+	QOS      btypes.BigInt  `json:"qos"`      //QOS
+	QSCs     types.QSCs     `json:"qscs"`     //QSCs
+}
+
+func NewCrossChainTransferTx(sender, receiver btypes.Address, chainId string, qos btypes.BigInt, qscs types.QSCs) *TxCrossChainTransfer {
+	return &TxCrossChainTransfer{
+		Sender:   sender,
+		Receiver: receiver,
+		ChainId:  chainId,
+		QOS:      qos,
+		QSCs:     qscs,
+	}
+}
+
+func (tx TxCrossChainTransfer) ValidateData(ctx context.Context) error {
+	if len(tx.Sender) == 0 || len(tx.Receiver) == 0 {
+		return ErrInvalidInput(DefaultCodeSpace, "empty sender or receiver")
+	}
+	if err := validateCoins(tx.QOS, tx.QSCs); err != nil {
+		return err
+	}
+
+	// 目标链已初始化且未暂停
+	if tx.ChainId == "" || tx.ChainId == ctx.ChainID() {
+		return ErrInvalidInput(DefaultCodeSpace, "invalid chain id")
+	}
+	if GetQCPMapper(ctx).GetChainInTrustPubKey(tx.ChainId) == nil {
+		return ErrQCPNotExists(DefaultCodeSpace, "")
+	}
+	if IsQCPPaused(ctx, tx.ChainId) {
+		return ErrWrongQCPStatus(DefaultCodeSpace, "qcp paused")
+	}
+
+	// sender余额
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	a := accountMapper.GetAccount(tx.Sender)
+	if a == nil {
+		return ErrSenderNotExists(DefaultCodeSpace, "")
+	}
+	acc, _ := types.ToQOSAccount(a)
+	if !acc.EnoughOf(tx.QOS.NilToZero(), tx.QSCs) {
+		return ErrCoinsNotEnough(DefaultCodeSpace, "")
+	}
+	if types.SpendableQOS(a, ctx.BlockHeader().Time.UTC()).LT(tx.QOS.NilToZero()) {
+		return ErrCoinsNotEnough(DefaultCodeSpace, "vesting QOS cannot be transferred")
+	}
+
+	return qsc.ValidateNotFrozen(ctx, tx.Sender, tx.QSCs)
+}
+
+// 扣除sender QOS、QSCs并托管, 生成发往目标链的TxQcp
+func (tx TxCrossChainTransfer) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	result = btypes.Result{
+		Code: btypes.CodeOK,
+	}
+
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	a := accountMapper.GetAccount(tx.Sender)
+	acc, _ := types.ToQOSAccount(a)
+	acc.MustMinus(tx.QOS.NilToZero(), tx.QSCs)
+	accountMapper.SetAccount(a)
+	supply.GetSupplyMapper(ctx).LockQOS(supplytypes.QCPEscrow, tx.QOS.NilToZero())
+
+	// TxQcp保存时sequence为当前out sequence + 1
+	sequence := GetQCPMapper(ctx).GetMaxChainOutSequence(tx.ChainId) + 1
+	SetCrossChainTransfer(ctx, qcptypes.NewCrossChainTransfer(tx.ChainId, sequence, tx.Sender, tx.Receiver, tx.QOS.NilToZero(), tx.QSCs))
+
+	receiveTx := NewCrossChainReceiveTx(tx.Sender, tx.Receiver, tx.QOS.NilToZero(), tx.QSCs)
+	crossTxQcp = &txs.TxQcp{
+		TxStd: txs.NewTxStd(receiveTx, tx.ChainId, btypes.NewInt(CrossChainReceiveMaxGas)),
+		To:    tx.ChainId,
+	}
+
+	return
+}
+
+func (tx TxCrossChainTransfer) GetSigner() []btypes.Address {
+	return []btypes.Address{tx.Sender}
+}
+
+func (tx TxCrossChainTransfer) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx TxCrossChainTransfer) GasItems() uint64 {
+	return 0
+}
+
+func (tx TxCrossChainTransfer) GetGasPayer() btypes.Address {
+	return tx.Sender
+}
+
+func (tx TxCrossChainTransfer) GetSignData() (ret []byte) {
+	ret = append(ret, tx.Sender...)
+	ret = append(ret, tx.Receiver...)
+	ret = append(ret, tx.ChainId...)
+	ret = append(ret, tx.QOS.NilToZero().String()...)
+	ret = append(ret, tx.QSCs.String()...)
+
+	return
+}
+
+// cross chain receive, 联盟链转入QOS、QSCs, 仅可包含在联盟链发来的TxQcp中
+type TxCrossChainReceive struct {
+	Sender   btypes.Address `json:"sender"`   //源链转出账户
+	Receiver btypes.Address `json:"receiver"` //接收账户
+	QOS      btypes.BigInt  `json:"qos"`      //QOS
+	QSCs     types.QSCs     `json:"qscs"`     //QSCs
+}
+
+func NewCrossChainReceiveTx(sender, receiver btypes.Address, qos btypes.BigInt, qscs types.QSCs) *TxCrossChainReceive {
+	return &TxCrossChainReceive{
+		Sender:   sender,
+		Receiver: receiver,
+		QOS:      qos,
+		QSCs:     qscs,
+	}
+}
+
+func (tx TxCrossChainReceive) ValidateData(ctx context.Context) error {
+	if len(tx.Receiver) == 0 {
+		return ErrInvalidInput(DefaultCodeSpace, "empty receiver")
+	}
+	if err := validateCoins(tx.QOS, tx.QSCs); err != nil {
+		return err
+	}
+
+	txQcp := getTxQcp(ctx)
+	if txQcp == nil || txQcp.IsResult {
+		return ErrNotFromQCP(DefaultCodeSpace, "")
+	}
+
+	// 仅可转入已创建且此前转出至源链的QSC, 数量不能超过转出至源链的数量
+	qscMapper := ctx.Mapper(qsc.QSCMapperName).(*qsc.QSCMapper)
+	lockedQSCs := GetLockedQSCs(ctx, txQcp.From)
+	for _, coin := range tx.QSCs {
+		if !qscMapper.Exists(coin.Name) {
+			return ErrInvalidInput(DefaultCodeSpace, fmt.Sprintf("qsc %s not exists", coin.Name))
+		}
+		if lockedQSCs.AmountOf(coin.Name).LT(coin.Amount) {
+			return ErrCoinsNotEnough(DefaultCodeSpace, fmt.Sprintf("locked %s of %s not enough", coin.Name, txQcp.From))
+		}
+	}
+
+	// 转入的QOS不能超过转出至源链的QOS
+	if GetLockedQOS(ctx, txQcp.From).LT(tx.QOS.NilToZero()) {
+		return ErrCoinsNotEnough(DefaultCodeSpace, fmt.Sprintf("locked QOS of %s not enough", txQcp.From))
+	}
+
+	return nil
+}
+
+// 释放锁定的QOS、QSCs, 转入receiver
+func (tx TxCrossChainReceive) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	result = btypes.Result{
+		Code: btypes.CodeOK,
+	}
+
+	txQcp := getTxQcp(ctx)
+	SetLockedQOS(ctx, txQcp.From, GetLockedQOS(ctx, txQcp.From).Sub(tx.QOS.NilToZero()))
+	SetLockedQSCs(ctx, txQcp.From, GetLockedQSCs(ctx, txQcp.From).Minus(tx.QSCs))
+
+	plusAccountCoins(ctx, tx.Receiver, tx.QOS.NilToZero(), tx.QSCs)
+	supply.GetSupplyMapper(ctx).UnlockQOS(supplytypes.QCPLocked, tx.QOS.NilToZero())
+
+	return
+}
+
+// 跨链交易签名由TxQcp保证
+func (tx TxCrossChainReceive) GetSigner() []btypes.Address {
+	return nil
+}
+
+func (tx TxCrossChainReceive) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+// gas由源链中继方负责
+func (tx TxCrossChainReceive) GetGasPayer() btypes.Address {
+	return nil
+}
+
+func (tx TxCrossChainReceive) GetSignData() (ret []byte) {
+	ret = append(ret, tx.Sender...)
+	ret = append(ret, tx.Receiver...)
+	ret = append(ret, tx.QOS.NilToZero().String()...)
+	ret = append(ret, tx.QSCs.String()...)
+
+	return
+}
+
+// TxQcp执行结果回调: 目标链执行成功时确认转出, 失败时退回sender
+func HandleQcpTxResult(ctx context.Context, itx interface{}) {
+	qcpResult, ok := itx.(*txs.QcpTxResult)
+	if !ok {
+		return
+	}
+	txQcp := getTxQcp(ctx)
+	if txQcp == nil || !txQcp.IsResult {
+		return
+	}
+
+	transfer, exists := GetCrossChainTransfer(ctx, txQcp.From, qcpResult.QcpOriginalSequence)
+	if !exists {
+		return
+	}
+	DeleteCrossChainTransfer(ctx, transfer.ChainId, transfer.Sequence)
+
+	if qcpResult.IsOk() {
+		// QOS、QSCs锁定在QOS上, 转回时释放, 总量不变
+		SetLockedQOS(ctx, transfer.ChainId, GetLockedQOS(ctx, transfer.ChainId).Add(transfer.QOS))
+		SetLockedQSCs(ctx, transfer.ChainId, GetLockedQSCs(ctx, transfer.ChainId).Plus(transfer.QSCs))
+		supply.GetSupplyMapper(ctx).MoveLockedQOS(supplytypes.QCPEscrow, supplytypes.QCPLocked, transfer.QOS)
+		ctx.Logger().Info("cross chain transfer released", "chain", transfer.ChainId, "sequence", transfer.Sequence)
+	} else {
+		plusAccountCoins(ctx, transfer.Sender, transfer.QOS, transfer.QSCs)
+		supply.GetSupplyMapper(ctx).UnlockQOS(supplytypes.QCPEscrow, transfer.QOS)
+		ctx.Logger().Info("cross chain transfer refunded", "chain", transfer.ChainId, "sequence", transfer.Sequence,
+			"log", qcpResult.Result.Log)
+	}
+}
+
+func validateCoins(qos btypes.BigInt, qscs types.QSCs) error {
+	qos = qos.NilToZero()
+	if qos.IsZero() && qscs.IsZero() {
+		return ErrInvalidInput(DefaultCodeSpace, "QOS and QSCs are zero")
+	}
+	if btypes.ZeroInt().GT(qos) || !qscs.IsNotNegative() {
+		return ErrInvalidInput(DefaultCodeSpace, "QOS or QSCs lt zero")
+	}
+	// QSCs需按名称排序, 不重复且数量大于0
+	if len(qscs) > 0 && (!qscs.IsValid() || !qscs.IsPositive()) {
+		return ErrInvalidInput(DefaultCodeSpace, "invalid QSCs")
+	}
+
+	return nil
+}
+
+func plusAccountCoins(ctx context.Context, addr btypes.Address, qos btypes.BigInt, qscs types.QSCs) {
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	a := accountMapper.GetAccount(addr)
+	if a == nil {
+		a = types.NewQOSAccountWithAddress(addr)
+	}
+	acc, _ := types.ToQOSAccount(a)
+	acc.MustPlus(qos, qscs)
+	accountMapper.SetAccount(a)
+}
+
+// 当前执行的TxQcp, 非跨链交易返回nil
+func getTxQcp(ctx context.Context) *txs.TxQcp {
+	tx, err := btypes.DecoderTx(cdc, ctx.TxBytes())
+	if err != nil {
+		return nil
+	}
+	txQcp, _ := tx.(*txs.TxQcp)
+	return txQcp
+}
@@ -0,0 +1,181 @@
+package qcp
+
+import (
+	"testing"
+
+	bacc "github.com/QOSGroup/qbase/account"
+	"github.com/QOSGroup/qbase/context"
+	bmapper "github.com/QOSGroup/qbase/mapper"
+	"github.com/QOSGroup/qbase/qcp"
+	"github.com/QOSGroup/qbase/store"
+	"github.com/QOSGroup/qbase/txs"
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/module/qsc"
+	qsctypes "github.com/QOSGroup/qos/module/qsc/types"
+	"github.com/QOSGroup/qos/module/supply"
+	supplytypes "github.com/QOSGroup/qos/module/supply/types"
+	"github.com/QOSGroup/qos/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func defaultContext() context.Context {
+	mapperMap := make(map[string]bmapper.IMapper)
+
+	qcpMapper := qcp.NewQcpMapper(cdc)
+	qcpKey := qcpMapper.GetStoreKey()
+	mapperMap[qcp.QcpMapperName] = qcpMapper
+
+	accountMapper := bacc.NewAccountMapper(nil, types.ProtoQOSAccount)
+	accountMapper.SetCodec(cdc)
+	accountKey := accountMapper.GetStoreKey()
+	mapperMap[bacc.AccountMapperName] = accountMapper
+
+	qscMapper := qsc.NewQSCMapper()
+	qscMapper.SetCodec(cdc)
+	qscKey := qscMapper.GetStoreKey()
+	mapperMap[qsc.QSCMapperName] = qscMapper
+
+	supplyMapper := supply.NewSupplyMapper()
+	supplyMapper.SetCodec(cdc)
+	supplyKey := supplyMapper.GetStoreKey()
+	mapperMap[supplytypes.SupplyMapperName] = supplyMapper
+
+	db := dbm.NewMemDB()
+	cms := store.NewCommitMultiStore(db)
+	cms.MountStoreWithDB(qcpKey, store.StoreTypeIAVL, db)
+	cms.MountStoreWithDB(accountKey, store.StoreTypeIAVL, db)
+	cms.MountStoreWithDB(qscKey, store.StoreTypeIAVL, db)
+	cms.MountStoreWithDB(supplyKey, store.StoreTypeIAVL, db)
+	cms.LoadLatestVersion()
+
+	return context.NewContext(cms, abci.Header{}, false, log.NewNopLogger(), mapperMap).WithChainID("qos")
+}
+
+// 模拟联盟链发来的TxQcp
+func withTxQcp(ctx context.Context, from string, sequence int64, itx txs.ITx, isResult bool) context.Context {
+	txQcp := txs.NewTxQCP(txs.NewTxStd(itx, "qos", btypes.ZeroInt()), from, "qos", sequence, 1, 0, isResult, "")
+	return ctx.WithTxBytes(cdc.MustMarshalBinaryBare(txQcp))
+}
+
+func TestTxCrossChainTransfer(t *testing.T) {
+	ctx := defaultContext()
+	chain := "aoe-1000"
+
+	sender := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	receiver := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	acc := types.NewQOSAccount(sender, btypes.NewInt(100), types.QSCs{btypes.NewBaseCoin("star", btypes.NewInt(100))})
+	accountMapper.SetAccount(acc)
+
+	qscMapper := ctx.Mapper(qsc.QSCMapperName).(*qsc.QSCMapper)
+	qscMapper.SaveQsc(&qsctypes.QSCInfo{Name: "star"})
+	supplyMapper := supply.GetSupplyMapper(ctx)
+	supplyMapper.IncrQSC("star", btypes.NewInt(100))
+
+	qscs := types.QSCs{btypes.NewBaseCoin("star", btypes.NewInt(10))}
+	tx := NewCrossChainTransferTx(sender, receiver, chain, btypes.NewInt(10), qscs)
+
+	//目标链未初始化
+	require.NotNil(t, tx.ValidateData(ctx))
+	qcpMapper := GetQCPMapper(ctx)
+	qcpMapper.SetChainInTrustPubKey(chain, ed25519.GenPrivKey().PubKey())
+
+	//余额不足
+	require.NotNil(t, NewCrossChainTransferTx(sender, receiver, chain, btypes.NewInt(200), nil).ValidateData(ctx))
+
+	//冻结账户
+	qscMapper.FreezeAccount("star", sender)
+	require.NotNil(t, tx.ValidateData(ctx))
+	qscMapper.UnfreezeAccount("star", sender)
+
+	//目标链执行失败, 退回sender
+	require.Nil(t, tx.ValidateData(ctx))
+	result, crossTxQcp := tx.Exec(ctx)
+	require.True(t, result.IsOK())
+	require.NotNil(t, crossTxQcp)
+	require.Equal(t, chain, crossTxQcp.To)
+	require.Equal(t, int64(CrossChainReceiveMaxGas), crossTxQcp.TxStd.MaxGas.Int64())
+	qcpMapper.SetMaxChainOutSequence(chain, 1)
+
+	a, _ := types.ToQOSAccount(accountMapper.GetAccount(sender))
+	require.Equal(t, int64(90), a.QOS.Int64())
+	_, exists := GetCrossChainTransfer(ctx, chain, 1)
+	require.True(t, exists)
+	require.Equal(t, int64(10), supplyMapper.GetLockedQOS(supplytypes.QCPEscrow).Int64())
+
+	failed := txs.NewQcpTxResult(btypes.ErrInternal("failed").Result(), 1, "", "")
+	HandleQcpTxResult(withTxQcp(ctx, chain, 1, failed, true), failed)
+	_, exists = GetCrossChainTransfer(ctx, chain, 1)
+	require.False(t, exists)
+	a, _ = types.ToQOSAccount(accountMapper.GetAccount(sender))
+	require.Equal(t, int64(100), a.QOS.Int64())
+	require.Equal(t, int64(100), a.QSCs.AmountOf("star").Int64())
+	require.True(t, supplyMapper.GetLockedQOS(supplytypes.QCPEscrow).IsZero())
+
+	//目标链执行成功, QOS、QSC锁定, 总量不变
+	result, _ = tx.Exec(ctx)
+	require.True(t, result.IsOK())
+	qcpMapper.SetMaxChainOutSequence(chain, 2)
+
+	succeeded := txs.NewQcpTxResult(btypes.Result{}, 2, "", "")
+	HandleQcpTxResult(withTxQcp(ctx, chain, 2, succeeded, true), succeeded)
+	_, exists = GetCrossChainTransfer(ctx, chain, 2)
+	require.False(t, exists)
+	require.Equal(t, int64(10), GetLockedQOS(ctx, chain).Int64())
+	require.Equal(t, int64(10), GetLockedQSCs(ctx, chain).AmountOf("star").Int64())
+	require.Equal(t, int64(100), supplyMapper.GetSupply().QSCs.AmountOf("star").Int64())
+	require.True(t, supplyMapper.GetLockedQOS(supplytypes.QCPEscrow).IsZero())
+	require.Equal(t, int64(10), supplyMapper.GetLockedQOS(supplytypes.QCPLocked).Int64())
+	a, _ = types.ToQOSAccount(accountMapper.GetAccount(sender))
+	require.Equal(t, int64(90), a.QOS.Int64())
+}
+
+func TestTxCrossChainReceive(t *testing.T) {
+	ctx := defaultContext()
+	chain := "aoe-1000"
+
+	sender := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	receiver := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	qscMapper := ctx.Mapper(qsc.QSCMapperName).(*qsc.QSCMapper)
+	qscMapper.SaveQsc(&qsctypes.QSCInfo{Name: "star"})
+	qscMapper.SaveQsc(&qsctypes.QSCInfo{Name: "moon"})
+	supply.GetSupplyMapper(ctx).IncrQSC("star", btypes.NewInt(100))
+	SetLockedQOS(ctx, chain, btypes.NewInt(10))
+
+	tx := NewCrossChainReceiveTx(sender, receiver, btypes.NewInt(10), types.QSCs{btypes.NewBaseCoin("star", btypes.NewInt(5))})
+
+	//非跨链交易
+	stdCtx := ctx.WithTxBytes(cdc.MustMarshalBinaryBare(txs.NewTxStd(tx, "qos", btypes.ZeroInt())))
+	require.NotNil(t, tx.ValidateData(stdCtx))
+
+	//超过锁定的QOS
+	qcpCtx := withTxQcp(ctx, chain, 1, tx, false)
+	require.NotNil(t, NewCrossChainReceiveTx(sender, receiver, btypes.NewInt(11), nil).ValidateData(qcpCtx))
+
+	//QSC不存在
+	require.NotNil(t, NewCrossChainReceiveTx(sender, receiver, btypes.ZeroInt(), types.QSCs{btypes.NewBaseCoin("sun", btypes.NewInt(5))}).ValidateData(qcpCtx))
+
+	//未转出至源链的QSC
+	require.NotNil(t, tx.ValidateData(qcpCtx))
+	SetLockedQSCs(ctx, chain, types.QSCs{btypes.NewBaseCoin("star", btypes.NewInt(8))})
+	require.NotNil(t, NewCrossChainReceiveTx(sender, receiver, btypes.ZeroInt(), types.QSCs{btypes.NewBaseCoin("moon", btypes.NewInt(5))}).ValidateData(qcpCtx))
+
+	//超过锁定的QSC
+	require.NotNil(t, NewCrossChainReceiveTx(sender, receiver, btypes.ZeroInt(), types.QSCs{btypes.NewBaseCoin("star", btypes.NewInt(9))}).ValidateData(qcpCtx))
+
+	require.Nil(t, tx.ValidateData(qcpCtx))
+	result, _ := tx.Exec(qcpCtx)
+	require.True(t, result.IsOK())
+
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	a, _ := types.ToQOSAccount(accountMapper.GetAccount(receiver))
+	require.Equal(t, int64(10), a.QOS.Int64())
+	require.Equal(t, int64(5), a.QSCs.AmountOf("star").Int64())
+	require.True(t, GetLockedQOS(ctx, chain).IsZero())
+	require.Equal(t, int64(3), GetLockedQSCs(ctx, chain).AmountOf("star").Int64())
+	require.Equal(t, int64(100), supply.GetSupplyMapper(ctx).GetSupply().QSCs.AmountOf("star").Int64())
+}
@@ -1,13 +1,16 @@
 package qcp
 
 import (
+	"bytes"
+
 	"github.com/QOSGroup/kepler/cert"
 	bacc "github.com/QOSGroup/qbase/account"
 	"github.com/QOSGroup/qbase/context"
 	"github.com/QOSGroup/qbase/qcp"
 	"github.com/QOSGroup/qbase/txs"
 	btypes "github.com/QOSGroup/qbase/types"
-	"github.com/tendermint/tendermint/crypto"
+	qcptypes "github.com/QOSGroup/qos/module/qcp/types"
+	"github.com/QOSGroup/qos/types"
 )
 
 // init QCP
@@ -42,9 +45,8 @@ func (tx TxInitQCP) ValidateData(ctx context.Context) error {
 	if subj.QCPChain == "" {
 		return ErrInvalidQCPCA(DefaultCodeSpace, "")
 	}
-	rootCA := GetQCPRootCA(ctx)
-	if !cert.VerityCrt([]crypto.PubKey{rootCA}, *tx.QCPCA) {
-		return ErrWrongQCPCA(DefaultCodeSpace, "")
+	if err := validateQCPCA(ctx, *tx.QCPCA); err != nil {
+		return err
 	}
 
 	// 不存在初始化过的QCP信息
@@ -68,6 +70,12 @@ func (tx TxInitQCP) Exec(ctx context.Context) (result btypes.Result, crossTxQcp
 	qcpMapper.SetChainInTrustPubKey(subj.QCPChain, tx.QCPCA.CSR.PublicKey)
 	qcpMapper.SetMaxChainInSequence(subj.QCPChain, 0)
 	qcpMapper.SetMaxChainOutSequence(subj.QCPChain, 0)
+	SetQCPConfig(ctx, subj.QCPChain, qcptypes.QCPConfig{
+		Creator:    tx.Creator,
+		Status:     qcptypes.StatusActive,
+		UpdateTime: ctx.BlockHeader().Time.UTC(),
+		CertHash:   types.CertHash(*tx.QCPCA),
+	})
 
 	return
 }
@@ -80,6 +88,10 @@ func (tx TxInitQCP) CalcGas() btypes.BigInt {
 	return btypes.ZeroInt()
 }
 
+func (tx TxInitQCP) GasItems() uint64 {
+	return 0
+}
+
 func (tx TxInitQCP) GetGasPayer() btypes.Address {
 	return tx.Creator
 }
@@ -90,3 +102,301 @@ func (tx TxInitQCP) GetSignData() (ret []byte) {
 
 	return
 }
+
+// update QCP trust pubkey
+type TxUpdateQCP struct {
+	Creator btypes.Address    `json:"creator"` //更新账户, 更新后成为QCP管理账户
+	QCPCA   *cert.Certificate `json:"ca_qcp"`  //QCP root CA新签发的证书
+}
+
+func NewUpdateQCPTx(creator btypes.Address, crt *cert.Certificate) *TxUpdateQCP {
+	return &TxUpdateQCP{
+		Creator: creator,
+		QCPCA:   crt,
+	}
+}
+
+func (tx TxUpdateQCP) ValidateData(ctx context.Context) error {
+	if len(tx.Creator) == 0 {
+		return ErrInvalidInput(DefaultCodeSpace, "")
+	}
+
+	// creator账户存在
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	if nil == accountMapper.GetAccount(tx.Creator) {
+		return ErrCreatorNotExists(DefaultCodeSpace, "")
+	}
+
+	// CA 校验
+	if tx.QCPCA == nil {
+		return ErrInvalidQCPCA(DefaultCodeSpace, "")
+	}
+	subj, ok := tx.QCPCA.CSR.Subj.(cert.QCPSubject)
+	if !ok || subj.ChainId != ctx.ChainID() || subj.QCPChain == "" {
+		return ErrInvalidQCPCA(DefaultCodeSpace, "")
+	}
+	if err := validateQCPCA(ctx, *tx.QCPCA); err != nil {
+		return err
+	}
+
+	// QCP已初始化, 证书为最近一次更新后签发的新公钥
+	pubKey := GetQCPMapper(ctx).GetChainInTrustPubKey(subj.QCPChain)
+	if pubKey == nil {
+		return ErrQCPNotExists(DefaultCodeSpace, "")
+	}
+	if bytes.Equal(pubKey.Bytes(), tx.QCPCA.CSR.PublicKey.Bytes()) {
+		return ErrWrongQCPCA(DefaultCodeSpace, "same pubkey")
+	}
+	if !tx.QCPCA.CSR.NotBefore.After(GetQCPConfig(ctx, subj.QCPChain).UpdateTime) {
+		return ErrWrongQCPCA(DefaultCodeSpace, "crt issued before last update")
+	}
+
+	return nil
+}
+
+func (tx TxUpdateQCP) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	result = btypes.Result{
+		Code: btypes.CodeOK,
+	}
+
+	subj := tx.QCPCA.CSR.Subj.(cert.QCPSubject)
+
+	GetQCPMapper(ctx).SetChainInTrustPubKey(subj.QCPChain, tx.QCPCA.CSR.PublicKey)
+	config := GetQCPConfig(ctx, subj.QCPChain)
+	config.Creator = tx.Creator
+	config.UpdateTime = ctx.BlockHeader().Time.UTC()
+	config.CertHash = types.CertHash(*tx.QCPCA)
+	SetQCPConfig(ctx, subj.QCPChain, config)
+
+	return
+}
+
+func (tx TxUpdateQCP) GetSigner() []btypes.Address {
+	return []btypes.Address{tx.Creator}
+}
+
+func (tx TxUpdateQCP) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx TxUpdateQCP) GasItems() uint64 {
+	return 0
+}
+
+func (tx TxUpdateQCP) GetGasPayer() btypes.Address {
+	return tx.Creator
+}
+
+func (tx TxUpdateQCP) GetSignData() (ret []byte) {
+	ret = append(ret, tx.Creator...)
+	ret = append(ret, cdc.MustMarshalBinaryBare(tx.QCPCA)...)
+
+	return
+}
+
+// pause QCP
+type TxPauseQCP struct {
+	ChainId string         `json:"chain_id"` //联盟链
+	Creator btypes.Address `json:"creator"`  //QCP管理账户
+}
+
+// resume QCP
+type TxResumeQCP struct {
+	ChainId string         `json:"chain_id"` //联盟链
+	Creator btypes.Address `json:"creator"`  //QCP管理账户
+}
+
+// remove QCP
+type TxRemoveQCP struct {
+	ChainId string         `json:"chain_id"` //联盟链
+	Creator btypes.Address `json:"creator"`  //QCP管理账户
+}
+
+func NewPauseQCPTx(chainId string, creator btypes.Address) *TxPauseQCP {
+	return &TxPauseQCP{
+		ChainId: chainId,
+		Creator: creator,
+	}
+}
+
+func NewResumeQCPTx(chainId string, creator btypes.Address) *TxResumeQCP {
+	return &TxResumeQCP{
+		ChainId: chainId,
+		Creator: creator,
+	}
+}
+
+func NewRemoveQCPTx(chainId string, creator btypes.Address) *TxRemoveQCP {
+	return &TxRemoveQCP{
+		ChainId: chainId,
+		Creator: creator,
+	}
+}
+
+// QCP已初始化, creator为QCP管理账户
+func validateQCPCreator(ctx context.Context, chainId string, creator btypes.Address) (qcptypes.QCPConfig, error) {
+	if chainId == "" || len(creator) == 0 {
+		return qcptypes.QCPConfig{}, ErrInvalidInput(DefaultCodeSpace, "")
+	}
+	if GetQCPMapper(ctx).GetChainInTrustPubKey(chainId) == nil {
+		return qcptypes.QCPConfig{}, ErrQCPNotExists(DefaultCodeSpace, "")
+	}
+	config := GetQCPConfig(ctx, chainId)
+	if !bytes.Equal(config.Creator, creator) {
+		return config, ErrWrongCreator(DefaultCodeSpace, "")
+	}
+
+	return config, nil
+}
+
+func (tx TxPauseQCP) ValidateData(ctx context.Context) error {
+	config, err := validateQCPCreator(ctx, tx.ChainId, tx.Creator)
+	if err != nil {
+		return err
+	}
+	if config.Status != qcptypes.StatusActive {
+		return ErrWrongQCPStatus(DefaultCodeSpace, "qcp already paused")
+	}
+
+	return nil
+}
+
+func (tx TxPauseQCP) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	result = btypes.Result{
+		Code: btypes.CodeOK,
+	}
+
+	config := GetQCPConfig(ctx, tx.ChainId)
+	config.Status = qcptypes.StatusPaused
+	SetQCPConfig(ctx, tx.ChainId, config)
+
+	return
+}
+
+func (tx TxPauseQCP) GetSigner() []btypes.Address {
+	return []btypes.Address{tx.Creator}
+}
+
+func (tx TxPauseQCP) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx TxPauseQCP) GasItems() uint64 {
+	return 0
+}
+
+func (tx TxPauseQCP) GetGasPayer() btypes.Address {
+	return tx.Creator
+}
+
+func (tx TxPauseQCP) GetSignData() (ret []byte) {
+	ret = append(ret, tx.ChainId...)
+	ret = append(ret, tx.Creator...)
+
+	return
+}
+
+func (tx TxResumeQCP) ValidateData(ctx context.Context) error {
+	config, err := validateQCPCreator(ctx, tx.ChainId, tx.Creator)
+	if err != nil {
+		return err
+	}
+	if config.Status != qcptypes.StatusPaused {
+		return ErrWrongQCPStatus(DefaultCodeSpace, "qcp not paused")
+	}
+	// 信任公钥的证书已吊销时需先更新信任公钥
+	if config.CertHash != "" && IsQCPCertRevoked(ctx, config.CertHash) {
+		return ErrCertRevoked(DefaultCodeSpace, "trust pubkey cert revoked, update qcp first")
+	}
+
+	return nil
+}
+
+func (tx TxResumeQCP) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	result = btypes.Result{
+		Code: btypes.CodeOK,
+	}
+
+	config := GetQCPConfig(ctx, tx.ChainId)
+	config.Status = qcptypes.StatusActive
+	SetQCPConfig(ctx, tx.ChainId, config)
+
+	return
+}
+
+func (tx TxResumeQCP) GetSigner() []btypes.Address {
+	return []btypes.Address{tx.Creator}
+}
+
+func (tx TxResumeQCP) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx TxResumeQCP) GasItems() uint64 {
+	return 0
+}
+
+func (tx TxResumeQCP) GetGasPayer() btypes.Address {
+	return tx.Creator
+}
+
+func (tx TxResumeQCP) GetSignData() (ret []byte) {
+	ret = append(ret, tx.ChainId...)
+	ret = append(ret, tx.Creator...)
+
+	return
+}
+
+func (tx TxRemoveQCP) ValidateData(ctx context.Context) error {
+	if _, err := validateQCPCreator(ctx, tx.ChainId, tx.Creator); err != nil {
+		return err
+	}
+
+	// 不存在未完成的跨链转账及转出至该链的QOS
+	for _, transfer := range GetCrossChainTransfers(ctx) {
+		if transfer.ChainId == tx.ChainId {
+			return ErrQCPInUse(DefaultCodeSpace, "pending cross chain transfers exist")
+		}
+	}
+	if !GetLockedQOS(ctx, tx.ChainId).IsZero() {
+		return ErrQCPInUse(DefaultCodeSpace, "locked QOS exists")
+	}
+	if !GetLockedQSCs(ctx, tx.ChainId).IsZero() {
+		return ErrQCPInUse(DefaultCodeSpace, "locked QSCs exist")
+	}
+
+	return nil
+}
+
+func (tx TxRemoveQCP) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	result = btypes.Result{
+		Code: btypes.CodeOK,
+	}
+
+	RemoveQCP(ctx, tx.ChainId)
+
+	return
+}
+
+func (tx TxRemoveQCP) GetSigner() []btypes.Address {
+	return []btypes.Address{tx.Creator}
+}
+
+func (tx TxRemoveQCP) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx TxRemoveQCP) GasItems() uint64 {
+	return 0
+}
+
+func (tx TxRemoveQCP) GetGasPayer() btypes.Address {
+	return tx.Creator
+}
+
+func (tx TxRemoveQCP) GetSignData() (ret []byte) {
+	ret = append(ret, tx.ChainId...)
+	ret = append(ret, tx.Creator...)
+
+	return
+}
@@ -0,0 +1,235 @@
+package qcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/QOSGroup/kepler/cert"
+	bacc "github.com/QOSGroup/qbase/account"
+	btypes "github.com/QOSGroup/qbase/types"
+	qcptypes "github.com/QOSGroup/qos/module/qcp/types"
+	"github.com/QOSGroup/qos/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+func newQCPCert(rootKey crypto.PrivKey, chainID, qcpChain string, pubKey crypto.PubKey, notBefore time.Time) *cert.Certificate {
+	csr := cert.CertificateSigningRequest{
+		Subj:      cert.QCPSubject{ChainId: chainID, QCPChain: qcpChain},
+		NotBefore: notBefore,
+		NotAfter:  notBefore.Add(24 * time.Hour),
+		PublicKey: pubKey,
+	}
+	signature, _ := rootKey.Sign(cert.MustMarshalJson(csr))
+	return &cert.Certificate{
+		CSR:       csr,
+		CA:        cert.Issuer{Subj: cert.CommonSubject{CN: "QCP"}, PublicKey: rootKey.PubKey()},
+		Signature: signature,
+	}
+}
+
+func TestTxUpdateQCP(t *testing.T) {
+	blockTime := time.Now().UTC()
+	ctx := defaultContext().WithBlockHeader(abci.Header{Time: blockTime.Add(-2 * time.Hour)})
+	chain := "aoe-1000"
+
+	rootKey := ed25519.GenPrivKey()
+	SetQCPRootCA(ctx, rootKey.PubKey())
+
+	creator := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	updater := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	accountMapper.SetAccount(types.NewQOSAccountWithAddress(creator))
+	accountMapper.SetAccount(types.NewQOSAccountWithAddress(updater))
+
+	oldKey := ed25519.GenPrivKey().PubKey()
+	initTx := TxInitQCP{creator, newQCPCert(rootKey, "qos", chain, oldKey, blockTime.Add(-3*time.Hour))}
+	require.Nil(t, initTx.ValidateData(ctx))
+	result, _ := initTx.Exec(ctx)
+	require.True(t, result.IsOK())
+	config := GetQCPConfig(ctx, chain)
+	require.Equal(t, creator, config.Creator)
+	require.Equal(t, qcptypes.StatusActive, config.Status)
+	SetQCPConfig(ctx, chain, qcptypes.QCPConfig{Creator: creator, Status: qcptypes.StatusPaused, UpdateTime: config.UpdateTime})
+	ctx = ctx.WithBlockHeader(abci.Header{Time: blockTime})
+
+	newKey := ed25519.GenPrivKey().PubKey()
+
+	//非root CA签发
+	require.NotNil(t, NewUpdateQCPTx(updater, newQCPCert(ed25519.GenPrivKey(), "qos", chain, newKey, blockTime.Add(-time.Hour))).ValidateData(ctx))
+
+	//QCP未初始化
+	require.NotNil(t, NewUpdateQCPTx(updater, newQCPCert(rootKey, "qos", "other", newKey, blockTime.Add(-time.Hour))).ValidateData(ctx))
+
+	//公钥未变化
+	require.NotNil(t, NewUpdateQCPTx(updater, newQCPCert(rootKey, "qos", chain, oldKey, blockTime.Add(-time.Hour))).ValidateData(ctx))
+
+	//证书签发时间早于最近一次更新
+	require.NotNil(t, NewUpdateQCPTx(updater, newQCPCert(rootKey, "qos", chain, newKey, blockTime.Add(-3*time.Hour))).ValidateData(ctx))
+
+	tx := NewUpdateQCPTx(updater, newQCPCert(rootKey, "qos", chain, newKey, blockTime.Add(-time.Hour)))
+	require.Nil(t, tx.ValidateData(ctx))
+	result, _ = tx.Exec(ctx)
+	require.True(t, result.IsOK())
+
+	require.True(t, newKey.Equals(GetQCPMapper(ctx).GetChainInTrustPubKey(chain)))
+	config = GetQCPConfig(ctx, chain)
+	require.Equal(t, updater, config.Creator)
+	//状态不变
+	require.Equal(t, qcptypes.StatusPaused, config.Status)
+	require.Equal(t, blockTime, config.UpdateTime)
+
+	//同一证书不能重复使用
+	require.NotNil(t, tx.ValidateData(ctx))
+}
+
+func TestTxPauseResumeRemoveQCP(t *testing.T) {
+	ctx := defaultContext()
+	chain := "aoe-1000"
+
+	creator := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	other := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	qcpMapper := GetQCPMapper(ctx)
+
+	//QCP未初始化
+	require.NotNil(t, NewPauseQCPTx(chain, creator).ValidateData(ctx))
+
+	qcpMapper.SetChainInTrustPubKey(chain, ed25519.GenPrivKey().PubKey())
+	qcpMapper.SetMaxChainInSequence(chain, 3)
+	qcpMapper.SetMaxChainOutSequence(chain, 2)
+	SetQCPConfig(ctx, chain, qcptypes.QCPConfig{Creator: creator})
+
+	//非管理账户
+	require.NotNil(t, NewPauseQCPTx(chain, other).ValidateData(ctx))
+
+	//未暂停时不能恢复
+	require.NotNil(t, NewResumeQCPTx(chain, creator).ValidateData(ctx))
+
+	pauseTx := NewPauseQCPTx(chain, creator)
+	require.Nil(t, pauseTx.ValidateData(ctx))
+	result, _ := pauseTx.Exec(ctx)
+	require.True(t, result.IsOK())
+	require.True(t, IsQCPPaused(ctx, chain))
+	require.NotNil(t, pauseTx.ValidateData(ctx))
+
+	//暂停后不能跨链转账
+	require.NotNil(t, NewCrossChainTransferTx(creator, other, chain, btypes.NewInt(1), nil).ValidateData(ctx))
+
+	resumeTx := NewResumeQCPTx(chain, creator)
+	require.Nil(t, resumeTx.ValidateData(ctx))
+	result, _ = resumeTx.Exec(ctx)
+	require.True(t, result.IsOK())
+	require.False(t, IsQCPPaused(ctx, chain))
+
+	//存在锁定的QOS时不能删除
+	removeTx := NewRemoveQCPTx(chain, creator)
+	SetLockedQOS(ctx, chain, btypes.NewInt(10))
+	require.NotNil(t, removeTx.ValidateData(ctx))
+	SetLockedQOS(ctx, chain, btypes.ZeroInt())
+
+	//存在未完成的跨链转账时不能删除
+	SetCrossChainTransfer(ctx, qcptypes.NewCrossChainTransfer(chain, 2, creator, other, btypes.NewInt(1), nil))
+	require.NotNil(t, removeTx.ValidateData(ctx))
+	DeleteCrossChainTransfer(ctx, chain, 2)
+
+	require.Nil(t, removeTx.ValidateData(ctx))
+	result, _ = removeTx.Exec(ctx)
+	require.True(t, result.IsOK())
+
+	require.Nil(t, qcpMapper.GetChainInTrustPubKey(chain))
+	require.Equal(t, int64(0), qcpMapper.GetMaxChainInSequence(chain))
+	require.Equal(t, int64(0), qcpMapper.GetMaxChainOutSequence(chain))
+	require.Equal(t, 0, len(ExportQCPs(ctx)))
+}
+
+func TestTxRevokeQCPCerts(t *testing.T) {
+	now := time.Now().UTC()
+	ctx := defaultContext().WithBlockHeader(abci.Header{Time: now})
+	chain := "aoe-1000"
+
+	rootKey := ed25519.GenPrivKey()
+	root := btypes.Address(rootKey.PubKey().Address())
+	SetQCPRootCA(ctx, rootKey.PubKey())
+
+	creator := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	accountMapper.SetAccount(types.NewQOSAccountWithAddress(creator))
+
+	crt := newQCPCert(rootKey, "qos", chain, ed25519.GenPrivKey().PubKey(), now.Add(-time.Hour))
+	initTx := TxInitQCP{creator, crt}
+	require.Nil(t, initTx.ValidateData(ctx))
+
+	//证书已过期
+	require.NotNil(t, initTx.ValidateData(ctx.WithBlockHeader(abci.Header{Time: now.Add(24 * time.Hour)})))
+
+	//非root CA签名
+	hash := types.CertHash(*crt)
+	require.NotNil(t, NewRevokeQCPCertsTx(creator, []string{hash}).ValidateData(ctx))
+	//重复的hash
+	require.NotNil(t, NewRevokeQCPCertsTx(root, []string{hash, hash}).ValidateData(ctx))
+
+	revokeTx := NewRevokeQCPCertsTx(root, []string{hash})
+	require.Nil(t, revokeTx.ValidateData(ctx))
+	result, _ := revokeTx.Exec(ctx)
+	require.True(t, result.IsOK())
+	require.Equal(t, []string{hash}, GetQCPCRL(ctx))
+	require.NotNil(t, initTx.ValidateData(ctx))
+
+	//root CA轮换
+	newRootKey := ed25519.GenPrivKey()
+	rotateTx := NewRotateQCPRootCATx(root, newRootKey.PubKey())
+	require.Nil(t, rotateTx.ValidateData(ctx))
+	result, _ = rotateTx.Exec(ctx)
+	require.True(t, result.IsOK())
+
+	initTx.QCPCA = newQCPCert(rootKey, "qos", chain, ed25519.GenPrivKey().PubKey(), now.Add(-time.Hour))
+	require.NotNil(t, initTx.ValidateData(ctx))
+	initTx.QCPCA = newQCPCert(newRootKey, "qos", chain, ed25519.GenPrivKey().PubKey(), now.Add(-time.Hour))
+	require.Nil(t, initTx.ValidateData(ctx))
+}
+
+func TestTxRevokeQCPCertsPauseQCP(t *testing.T) {
+	now := time.Now().UTC()
+	ctx := defaultContext().WithBlockHeader(abci.Header{Time: now})
+	chain := "aoe-1000"
+
+	rootKey := ed25519.GenPrivKey()
+	root := btypes.Address(rootKey.PubKey().Address())
+	SetQCPRootCA(ctx, rootKey.PubKey())
+
+	creator := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	accountMapper.SetAccount(types.NewQOSAccountWithAddress(creator))
+
+	crt := newQCPCert(rootKey, "qos", chain, ed25519.GenPrivKey().PubKey(), now.Add(-time.Hour))
+	initTx := TxInitQCP{creator, crt}
+	require.Nil(t, initTx.ValidateData(ctx))
+	result, _ := initTx.Exec(ctx)
+	require.True(t, result.IsOK())
+	require.Equal(t, types.CertHash(*crt), GetQCPConfig(ctx, chain).CertHash)
+
+	//信任公钥证书吊销后暂停该链
+	revokeTx := NewRevokeQCPCertsTx(root, []string{types.CertHash(*crt)})
+	require.Nil(t, revokeTx.ValidateData(ctx))
+	result, _ = revokeTx.Exec(ctx)
+	require.True(t, result.IsOK())
+	require.True(t, IsQCPPaused(ctx, chain))
+
+	//更新信任公钥前不能恢复
+	require.NotNil(t, NewResumeQCPTx(chain, creator).ValidateData(ctx))
+
+	ctx = ctx.WithBlockHeader(abci.Header{Time: now.Add(2 * time.Hour)})
+	updateTx := NewUpdateQCPTx(creator, newQCPCert(rootKey, "qos", chain, ed25519.GenPrivKey().PubKey(), now.Add(time.Hour)))
+	require.Nil(t, updateTx.ValidateData(ctx))
+	result, _ = updateTx.Exec(ctx)
+	require.True(t, result.IsOK())
+	require.True(t, IsQCPPaused(ctx, chain))
+
+	resumeTx := NewResumeQCPTx(chain, creator)
+	require.Nil(t, resumeTx.ValidateData(ctx))
+	result, _ = resumeTx.Exec(ctx)
+	require.True(t, result.IsOK())
+	require.False(t, IsQCPPaused(ctx, chain))
+}
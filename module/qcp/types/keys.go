@@ -0,0 +1,45 @@
+package types
+
+import "fmt"
+
+const (
+	//------query-------
+	QCPRoute  = "qcp"
+	QueryList = "list"
+	QueryInfo = "info"
+	QuerySeq  = "seq"
+	QueryTxs  = "txs"
+
+	SeqIn  = "in"
+	SeqOut = "out"
+
+	MaxQueryTxsLimit = 100 // 查询待输出TxQcp每次最多返回数量
+)
+
+// 联盟链列表
+type QCPSummary struct {
+	ChainId     string    `json:"chain_id"`
+	SequenceOut int64     `json:"sequence_out"`
+	SequenceIn  int64     `json:"sequence_in"`
+	Status      QCPStatus `json:"status"`
+}
+
+// 查询联盟链列表
+func BuildQueryQCPListCustomQueryPath() string {
+	return fmt.Sprintf("custom/%s/%s", QCPRoute, QueryList)
+}
+
+// 查询QCP信息
+func BuildQueryQCPInfoCustomQueryPath(chainId string) string {
+	return fmt.Sprintf("custom/%s/%s/%s", QCPRoute, QueryInfo, chainId)
+}
+
+// 查询in/out sequence, inOut为SeqIn或SeqOut
+func BuildQueryQCPSeqCustomQueryPath(inOut, chainId string) string {
+	return fmt.Sprintf("custom/%s/%s/%s/%s", QCPRoute, QuerySeq, inOut, chainId)
+}
+
+// 查询待输出的TxQcp, limit为0时返回MaxQueryTxsLimit条
+func BuildQueryQCPTxsCustomQueryPath(chainId string, limit uint64, asc bool) string {
+	return fmt.Sprintf("custom/%s/%s/%s/%d/%t", QCPRoute, QueryTxs, chainId, limit, asc)
+}
@@ -1,24 +1,70 @@
 package types
 
 import (
+	"time"
+
 	"github.com/QOSGroup/qbase/txs"
+	btypes "github.com/QOSGroup/qbase/types"
 	"github.com/tendermint/tendermint/crypto"
 )
 
+type QCPStatus byte
+
+const (
+	StatusActive QCPStatus = 0x00 // 正常
+	StatusPaused QCPStatus = 0x01 // 暂停, 不接收该链发来的TxQcp, 不可向该链跨链转账
+)
+
+func (status QCPStatus) String() string {
+	switch status {
+	case StatusActive:
+		return "Active"
+	case StatusPaused:
+		return "Paused"
+	default:
+		return ""
+	}
+}
+
+// QCP管理信息, 信任公钥及sequence由qbase QcpMapper保存
+type QCPConfig struct {
+	Creator    btypes.Address `json:"creator"`     //管理账户
+	Status     QCPStatus      `json:"status"`      //状态
+	UpdateTime time.Time      `json:"update_time"` //信任公钥最近更新时间
+	CertHash   string         `json:"cert_hash"`   //设置信任公钥的证书hash, 证书吊销后暂停该链
+}
+
 type QCPInfo struct {
-	ChainId     string        `json:"chain_id""`
-	SequenceOut int64         `json:"sequence_out""`
-	SequenceIn  int64         `json:"sequence_in""`
-	PubKey      crypto.PubKey `json:"pub_key""`
-	OutTxs      []txs.TxQcp   `json:"txs""`
+	ChainId     string         `json:"chain_id"`
+	SequenceOut int64          `json:"sequence_out"`
+	SequenceIn  int64          `json:"sequence_in"`
+	PubKey      crypto.PubKey  `json:"pub_key"`
+	Creator     btypes.Address `json:"creator"`
+	Status      QCPStatus      `json:"status"`
+	UpdateTime  time.Time      `json:"update_time"`
+	CertHash    string         `json:"cert_hash"`
+	OutTxs      []txs.TxQcp    `json:"txs"`
 }
 
-func NewQCPInfo(chainId string, sequenceOut int64, sequenceIn int64, pubKey crypto.PubKey, txs []txs.TxQcp) *QCPInfo {
+func NewQCPInfo(chainId string, sequenceOut int64, sequenceIn int64, pubKey crypto.PubKey, config QCPConfig, txs []txs.TxQcp) *QCPInfo {
 	return &QCPInfo{
 		ChainId:     chainId,
 		SequenceIn:  sequenceIn,
 		SequenceOut: sequenceOut,
 		PubKey:      pubKey,
+		Creator:     config.Creator,
+		Status:      config.Status,
+		UpdateTime:  config.UpdateTime,
+		CertHash:    config.CertHash,
 		OutTxs:      txs,
 	}
 }
+
+func (info QCPInfo) GetConfig() QCPConfig {
+	return QCPConfig{
+		Creator:    info.Creator,
+		Status:     info.Status,
+		UpdateTime: info.UpdateTime,
+		CertHash:   info.CertHash,
+	}
+}
@@ -0,0 +1,39 @@
+package types
+
+import (
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/types"
+)
+
+// 跨链转账记录, 转出的QOS、QSCs托管至目标链返回执行结果
+type CrossChainTransfer struct {
+	ChainId  string         `json:"chain_id"` //目标链
+	Sequence int64          `json:"sequence"` //对应TxQcp的out sequence
+	Sender   btypes.Address `json:"sender"`   //转出账户
+	Receiver btypes.Address `json:"receiver"` //目标链接收账户
+	QOS      btypes.BigInt  `json:"qos"`      //QOS
+	QSCs     types.QSCs     `json:"qscs"`     //QSCs
+}
+
+func NewCrossChainTransfer(chainId string, sequence int64, sender, receiver btypes.Address, qos btypes.BigInt, qscs types.QSCs) CrossChainTransfer {
+	return CrossChainTransfer{
+		ChainId:  chainId,
+		Sequence: sequence,
+		Sender:   sender,
+		Receiver: receiver,
+		QOS:      qos,
+		QSCs:     qscs,
+	}
+}
+
+// 已转出至联盟链的QOS, 锁定在QOS上, 转回时释放
+type LockedQOS struct {
+	ChainId string        `json:"chain_id"`
+	Amount  btypes.BigInt `json:"amount"`
+}
+
+// 已转出至联盟链的QSCs, 联盟链转入的QSCs不能超过该数量
+type LockedQSCs struct {
+	ChainId string     `json:"chain_id"`
+	QSCs    types.QSCs `json:"qscs"`
+}
@@ -7,12 +7,26 @@ import (
 )
 
 func QueryCommands(cdc *amino.Codec) []*cobra.Command {
-	return bctypes.GetCommands(QueryQscCmd(cdc))
+	return bctypes.GetCommands(
+		QueryQscCmd(cdc),
+		QueryFrozenAccountsCmd(cdc),
+		QueryExtrateCmd(cdc),
+		QueryExtratesCmd(cdc),
+		QueryQSCParamsCmd(cdc),
+	)
 }
 
 func TxCommands(cdc *amino.Codec) []*cobra.Command {
 	return bctypes.PostCommands(
 		CreateQSCCmd(cdc),
 		IssueQSCCmd(cdc),
+		BurnQSCCmd(cdc),
+		RetireQSCCmd(cdc),
+		ChangeQSCBankerCmd(cdc),
+		UpdateQSCExtrateCmd(cdc),
+		FreezeQSCAccountCmd(cdc),
+		UnfreezeQSCAccountCmd(cdc),
+		RevokeQSCCertsCmd(cdc),
+		RotateQSCRootCACmd(cdc),
 	)
 }
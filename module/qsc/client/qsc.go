@@ -7,9 +7,10 @@ import (
 	qcliacc "github.com/QOSGroup/qbase/client/account"
 	"github.com/QOSGroup/qbase/client/context"
 	"github.com/QOSGroup/qbase/client/keys"
-	qclitx "github.com/QOSGroup/qbase/client/tx"
+	"github.com/QOSGroup/qbase/store"
 	"github.com/QOSGroup/qbase/txs"
 	btypes "github.com/QOSGroup/qbase/types"
+	distrcli "github.com/QOSGroup/qos/module/distribution/client"
 	"github.com/QOSGroup/qos/module/qsc"
 	qsctypes "github.com/QOSGroup/qos/module/qsc/types"
 	"github.com/QOSGroup/qos/types"
@@ -17,9 +18,12 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/tendermint/go-amino"
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/libs/common"
+	"io/ioutil"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -31,6 +35,16 @@ const (
 	flagAccounts    = "accounts"
 	flagAmount      = "amount"
 	flagDescription = "desc"
+	flagHolder      = "holder"
+	flagNewBanker   = "new-banker"
+	flagIssueLimit  = "issue-limit"
+	flagIssuePeriod = "issue-period"
+	flagCompliance  = "compliance"
+	flagAccount     = "account"
+	flagRoot        = "root"
+	flagCertHashes  = "cert-hashes"
+	flagCrts        = "crts"
+	flagNewRootCA   = "new-root-ca"
 )
 
 func CreateQSCCmd(cdc *amino.Codec) *cobra.Command {
@@ -38,9 +52,12 @@ func CreateQSCCmd(cdc *amino.Codec) *cobra.Command {
 		Use:   "create-qsc",
 		Short: "create qsc",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return qclitx.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
 				//flag args
-				extrate := viper.GetString(flagExtrate)
+				extrate, err := types.NewDecFromStr(viper.GetString(flagExtrate))
+				if err != nil {
+					return nil, err
+				}
 				pathqsc := viper.GetString(flagPathqsc)
 				accountStr := viper.GetString(flagAccounts)
 				description := viper.GetString(flagDescription)
@@ -50,15 +67,27 @@ func CreateQSCCmd(cdc *amino.Codec) *cobra.Command {
 					return nil, err
 				}
 
-				var crt cert.Certificate
-				err = cdc.UnmarshalJSON(common.MustReadFile(pathqsc), &crt)
-				if err != nil {
-					return nil, err
-				}
+				// 未指定证书时按qsc-name创建，creator抵押QOS
+				var crt *cert.Certificate
+				var qscName, name string
+				if len(pathqsc) > 0 {
+					crt = &cert.Certificate{}
+					err = cdc.UnmarshalJSON(common.MustReadFile(pathqsc), crt)
+					if err != nil {
+						return nil, err
+					}
 
-				subj, ok := crt.CSR.Subj.(cert.QSCSubject)
-				if !ok {
-					return nil, errors.New("invalid crt file")
+					subj, ok := crt.CSR.Subj.(cert.QSCSubject)
+					if !ok {
+						return nil, errors.New("invalid crt file")
+					}
+					name = subj.Name
+				} else {
+					qscName = viper.GetString(flagQscname)
+					if len(qscName) == 0 {
+						return nil, fmt.Errorf("one of --%s and --%s is required", flagPathqsc, flagQscname)
+					}
+					name = qscName
 				}
 
 				var acs []*types.QOSAccount
@@ -83,7 +112,7 @@ func CreateQSCCmd(cdc *amino.Codec) *cobra.Command {
 							QOS: btypes.ZeroInt(),
 							QSCs: types.QSCs{
 								{
-									subj.Name,
+									name,
 									btypes.NewInt(amount),
 								},
 							},
@@ -95,9 +124,11 @@ func CreateQSCCmd(cdc *amino.Codec) *cobra.Command {
 				return qsc.TxCreateQSC{
 					creatorAddr,
 					extrate,
-					&crt,
+					crt,
 					description,
 					acs,
+					viper.GetBool(flagCompliance),
+					qscName,
 				}, nil
 
 			})
@@ -105,12 +136,13 @@ func CreateQSCCmd(cdc *amino.Codec) *cobra.Command {
 	}
 
 	cmd.Flags().String(flagCreator, "", "name or address of creator")
-	cmd.Flags().String(flagExtrate, "1", "extrate: qos:qscxxx")
+	cmd.Flags().String(flagExtrate, "1", "extrate: qsc:qos, decimal")
 	cmd.Flags().String(flagPathqsc, "", "path of CA(qsc)")
+	cmd.Flags().String(flagQscname, "", "qsc name, create without CA by depositing QOS, creator will be the banker")
 	cmd.Flags().String(flagDescription, "", "description")
 	cmd.Flags().String(flagAccounts, "", "init accounts, eg: address1,100;address2,100")
+	cmd.Flags().Bool(flagCompliance, false, "enable compliance, banker can freeze qsc of accounts")
 	cmd.MarkFlagRequired(flagCreator)
-	cmd.MarkFlagRequired(flagPathqsc)
 
 	return cmd
 }
@@ -138,7 +170,38 @@ func QueryQscCmd(cdc *amino.Codec) *cobra.Command {
 				return err
 			}
 
-			return cliCtx.PrintResult(info)
+			return cliCtx.PrintResult(qsctypes.NewQSCQueryResult(info))
+		},
+	}
+
+	return cmd
+}
+
+func QueryFrozenAccountsCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "qsc-frozen-accounts [qsc]",
+		Short: "query frozen accounts of qsc",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			result, err := cliCtx.Client.ABCIQuery("store/qsc/subspace", qsc.BuildFrozenKeyPrefix(args[0]))
+			if err != nil {
+				return err
+			}
+
+			addrs := make([]btypes.Address, 0)
+			if valueBz := result.Response.GetValue(); len(valueBz) > 0 {
+				var kvs []store.KVPair
+				cdc.UnmarshalBinaryLengthPrefixed(valueBz, &kvs)
+				for _, kv := range kvs {
+					var addr btypes.Address
+					cdc.UnmarshalBinaryBare(kv.Value, &addr)
+					addrs = append(addrs, addr)
+				}
+			}
+
+			return cliCtx.PrintResult(addrs)
 		},
 	}
 
@@ -150,7 +213,7 @@ func IssueQSCCmd(cdc *amino.Codec) *cobra.Command {
 		Use:   "issue-qsc",
 		Short: "issue qsc",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return qclitx.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
 				amount := viper.GetInt64(flagAmount)
 				qscName := viper.GetString(flagQscname)
 				bankerAddr, err := qcliacc.GetAddrFromFlag(ctx, flagBanker)
@@ -171,3 +234,376 @@ func IssueQSCCmd(cdc *amino.Codec) *cobra.Command {
 
 	return cmd
 }
+
+func BurnQSCCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "burn-qsc",
+		Short: "burn qsc of holder",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+				amount := viper.GetInt64(flagAmount)
+				if amount <= 0 {
+					return nil, errors.New("amount must be positive")
+				}
+				qscName := viper.GetString(flagQscname)
+				holderAddr, err := qcliacc.GetAddrFromFlag(ctx, flagHolder)
+				if err != nil {
+					return nil, err
+				}
+				return qsc.NewBurnQSCTx(qscName, btypes.NewInt(amount), holderAddr), nil
+			})
+		},
+	}
+
+	cmd.Flags().Int64(flagAmount, 0, "coin amount to burn")
+	cmd.Flags().String(flagQscname, "", "qsc name")
+	cmd.Flags().String(flagHolder, "", "address or name of qsc holder")
+	cmd.MarkFlagRequired(flagAmount)
+	cmd.MarkFlagRequired(flagQscname)
+	cmd.MarkFlagRequired(flagHolder)
+
+	return cmd
+}
+
+func ChangeQSCBankerCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "change-qsc-banker",
+		Short: "change banker or issue limit of qsc, authorized by current banker or a new qsc ca",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+				qscName := viper.GetString(flagQscname)
+
+				var banker, newBanker btypes.Address
+				var crt *cert.Certificate
+				var err error
+				if pathqsc := viper.GetString(flagPathqsc); len(pathqsc) > 0 {
+					// 新证书授权，新banker取自证书
+					crt = &cert.Certificate{}
+					err = cdc.UnmarshalJSON(common.MustReadFile(pathqsc), crt)
+					if err != nil {
+						return nil, err
+					}
+					subj, ok := crt.CSR.Subj.(cert.QSCSubject)
+					if !ok || subj.Banker == nil {
+						return nil, errors.New("invalid crt file")
+					}
+					newBanker = btypes.Address(subj.Banker.Address())
+				} else {
+					banker, err = qcliacc.GetAddrFromFlag(ctx, flagBanker)
+					if err != nil {
+						return nil, err
+					}
+					newBanker, err = qcliacc.GetAddrFromFlag(ctx, flagNewBanker)
+					if err != nil {
+						return nil, err
+					}
+				}
+
+				var issueLimit btypes.BigInt
+				var issuePeriod uint64
+				if cmd.Flags().Changed(flagIssueLimit) {
+					limit := viper.GetInt64(flagIssueLimit)
+					if limit < 0 {
+						return nil, errors.New("issue limit must not be negative")
+					}
+					issueLimit = btypes.NewInt(limit)
+					issuePeriod = uint64(viper.GetDuration(flagIssuePeriod) / time.Second)
+				}
+
+				return qsc.NewChangeQSCBankerTx(qscName, banker, newBanker, crt, issueLimit, issuePeriod), nil
+			})
+		},
+	}
+
+	cmd.Flags().String(flagQscname, "", "qsc name")
+	cmd.Flags().String(flagBanker, "", "address or name of current banker")
+	cmd.Flags().String(flagNewBanker, "", "address or name of new banker")
+	cmd.Flags().String(flagPathqsc, "", "path of new CA(qsc) signed by qsc root CA, new banker is read from it")
+	cmd.Flags().Int64(flagIssueLimit, 0, "max amount issued per period, 0 to remove limit")
+	cmd.Flags().Duration(flagIssuePeriod, 24*time.Hour, "issue limit period")
+	cmd.MarkFlagRequired(flagQscname)
+
+	return cmd
+}
+
+func FreezeQSCAccountCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "freeze-qsc-account",
+		Short: "freeze qsc of account, signed by banker",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+				qscName, banker, account, err := getFreezeArgs(ctx)
+				if err != nil {
+					return nil, err
+				}
+				return qsc.NewFreezeQSCAccountTx(qscName, banker, account), nil
+			})
+		},
+	}
+
+	addFreezeFlags(cmd)
+
+	return cmd
+}
+
+func UnfreezeQSCAccountCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unfreeze-qsc-account",
+		Short: "unfreeze qsc of account, signed by banker",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+				qscName, banker, account, err := getFreezeArgs(ctx)
+				if err != nil {
+					return nil, err
+				}
+				return qsc.NewUnfreezeQSCAccountTx(qscName, banker, account), nil
+			})
+		},
+	}
+
+	addFreezeFlags(cmd)
+
+	return cmd
+}
+
+func addFreezeFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flagQscname, "", "qsc name")
+	cmd.Flags().String(flagBanker, "", "address or name of banker")
+	cmd.Flags().String(flagAccount, "", "address or name of account")
+	cmd.MarkFlagRequired(flagQscname)
+	cmd.MarkFlagRequired(flagBanker)
+	cmd.MarkFlagRequired(flagAccount)
+}
+
+func getFreezeArgs(ctx context.CLIContext) (qscName string, banker, account btypes.Address, err error) {
+	qscName = viper.GetString(flagQscname)
+	banker, err = qcliacc.GetAddrFromFlag(ctx, flagBanker)
+	if err != nil {
+		return
+	}
+	account, err = qcliacc.GetAddrFromFlag(ctx, flagAccount)
+	return
+}
+
+func UpdateQSCExtrateCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-qsc-extrate",
+		Short: "update extrate of qsc, signed by banker",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+				qscName := viper.GetString(flagQscname)
+				extrate, err := types.NewDecFromStr(viper.GetString(flagExtrate))
+				if err != nil {
+					return nil, err
+				}
+				bankerAddr, err := qcliacc.GetAddrFromFlag(ctx, flagBanker)
+				if err != nil {
+					return nil, err
+				}
+				return qsc.NewUpdateQSCExtrateTx(qscName, bankerAddr, extrate), nil
+			})
+		},
+	}
+
+	cmd.Flags().String(flagQscname, "", "qsc name")
+	cmd.Flags().String(flagBanker, "", "address or name of banker")
+	cmd.Flags().String(flagExtrate, "", "new extrate: qsc:qos, decimal")
+	cmd.MarkFlagRequired(flagQscname)
+	cmd.MarkFlagRequired(flagBanker)
+	cmd.MarkFlagRequired(flagExtrate)
+
+	return cmd
+}
+
+func RetireQSCCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "retire-qsc",
+		Short: "retire qsc without circulating supply, deposit is refunded to creator",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+				bankerAddr, err := qcliacc.GetAddrFromFlag(ctx, flagBanker)
+				if err != nil {
+					return nil, err
+				}
+
+				return qsc.NewRetireQSCTx(viper.GetString(flagQscname), bankerAddr), nil
+			})
+		},
+	}
+
+	cmd.Flags().String(flagQscname, "", "qsc name")
+	cmd.Flags().String(flagBanker, "", "address or name of banker")
+	cmd.MarkFlagRequired(flagQscname)
+	cmd.MarkFlagRequired(flagBanker)
+
+	return cmd
+}
+
+func QueryQSCParamsCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "qsc-params",
+		Short: "query qsc params",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.Query(qsctypes.BuildQueryParamsCustomQueryPath(), []byte(""))
+			if err != nil {
+				return err
+			}
+
+			var result qsctypes.QSCParams
+			cliCtx.Codec.UnmarshalJSON(res, &result)
+			return cliCtx.PrintResult(result)
+		},
+	}
+
+	return cmd
+}
+
+func QueryExtrateCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "qsc-extrate [qsc] [height]",
+		Short: "query extrate of qsc at height, query current extrate if height is omitted",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var height uint64
+			if len(args) > 1 {
+				h, err := strconv.ParseUint(args[1], 10, 64)
+				if err != nil {
+					return err
+				}
+				height = h
+			}
+
+			res, err := cliCtx.Query(qsctypes.BuildQueryExtrateCustomQueryPath(args[0], height), []byte(""))
+			if err != nil {
+				return err
+			}
+
+			var result qsctypes.ExtrateRecord
+			cliCtx.Codec.UnmarshalJSON(res, &result)
+			return cliCtx.PrintResult(result)
+		},
+	}
+
+	return cmd
+}
+
+func QueryExtratesCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "qsc-extrates [qsc]",
+		Short: "query extrate history of qsc",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.Query(qsctypes.BuildQueryExtratesCustomQueryPath(args[0]), []byte(""))
+			if err != nil {
+				return err
+			}
+
+			var result []qsctypes.ExtrateRecord
+			cliCtx.Codec.UnmarshalJSON(res, &result)
+			return cliCtx.PrintResult(result)
+		},
+	}
+
+	return cmd
+}
+
+func RevokeQSCCertsCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke-qsc-certs",
+		Short: "add qsc certs to crl, signed by root ca",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+				rootAddr, err := qcliacc.GetAddrFromFlag(ctx, flagRoot)
+				if err != nil {
+					return nil, err
+				}
+				hashes, err := ParseCertHashes(cdc, viper.GetString(flagCertHashes), viper.GetString(flagCrts))
+				if err != nil {
+					return nil, err
+				}
+				return qsc.NewRevokeQSCCertsTx(rootAddr, hashes), nil
+			})
+		},
+	}
+
+	cmd.Flags().String(flagRoot, "", "address or name of root ca key")
+	cmd.Flags().String(flagCertHashes, "", "hashes of certs to revoke, separated by comma")
+	cmd.Flags().String(flagCrts, "", "paths of cert files to revoke, separated by comma")
+	cmd.MarkFlagRequired(flagRoot)
+
+	return cmd
+}
+
+func RotateQSCRootCACmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate-qsc-root-ca",
+		Short: "rotate qsc root ca, signed by current root ca",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+				rootAddr, err := qcliacc.GetAddrFromFlag(ctx, flagRoot)
+				if err != nil {
+					return nil, err
+				}
+				bz, err := ioutil.ReadFile(viper.GetString(flagNewRootCA))
+				if err != nil {
+					return nil, err
+				}
+				var pubKey crypto.PubKey
+				err = cdc.UnmarshalJSON(bz, &pubKey)
+				if err != nil {
+					return nil, err
+				}
+				return qsc.NewRotateQSCRootCATx(rootAddr, pubKey), nil
+			})
+		},
+	}
+
+	cmd.Flags().String(flagRoot, "", "address or name of current root ca key")
+	cmd.Flags().String(flagNewRootCA, "", "path of new root ca pubkey file")
+	cmd.MarkFlagRequired(flagRoot)
+	cmd.MarkFlagRequired(flagNewRootCA)
+
+	return cmd
+}
+
+// 解析吊销的证书hash, hashes为逗号分隔的证书hash, crtFiles为逗号分隔的证书文件
+func ParseCertHashes(cdc *amino.Codec, hashes, crtFiles string) ([]string, error) {
+	var result []string
+	for _, hash := range strings.Split(hashes, ",") {
+		if hash = strings.TrimSpace(hash); hash == "" {
+			continue
+		}
+		h, err := types.ParseCertHash(hash)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, h)
+	}
+
+	for _, file := range strings.Split(crtFiles, ",") {
+		if file = strings.TrimSpace(file); file == "" {
+			continue
+		}
+		bz, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var crt cert.Certificate
+		if err := cdc.UnmarshalJSON(bz, &crt); err != nil {
+			return nil, err
+		}
+		result = append(result, types.CertHash(crt))
+	}
+
+	if len(result) == 0 {
+		return nil, errors.New("no cert hash")
+	}
+	return result, nil
+}
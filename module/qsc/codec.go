@@ -18,4 +18,12 @@ func init() {
 func RegisterCodec(cdc *amino.Codec) {
 	cdc.RegisterConcrete(&TxCreateQSC{}, "qos/txs/TxCreateQSC", nil)
 	cdc.RegisterConcrete(&TxIssueQSC{}, "qos/txs/TxIssueQSC", nil)
+	cdc.RegisterConcrete(&TxBurnQSC{}, "qos/txs/TxBurnQSC", nil)
+	cdc.RegisterConcrete(&TxChangeQSCBanker{}, "qos/txs/TxChangeQSCBanker", nil)
+	cdc.RegisterConcrete(&TxUpdateQSCExtrate{}, "qos/txs/TxUpdateQSCExtrate", nil)
+	cdc.RegisterConcrete(&TxFreezeQSCAccount{}, "qos/txs/TxFreezeQSCAccount", nil)
+	cdc.RegisterConcrete(&TxUnfreezeQSCAccount{}, "qos/txs/TxUnfreezeQSCAccount", nil)
+	cdc.RegisterConcrete(&TxRevokeQSCCerts{}, "qos/txs/TxRevokeQSCCerts", nil)
+	cdc.RegisterConcrete(&TxRotateQSCRootCA{}, "qos/txs/TxRotateQSCRootCA", nil)
+	cdc.RegisterConcrete(&TxRetireQSC{}, "qos/txs/TxRetireQSC", nil)
 }
@@ -0,0 +1,162 @@
+package qsc
+
+import (
+	"github.com/QOSGroup/kepler/cert"
+	"github.com/QOSGroup/qbase/context"
+	"github.com/QOSGroup/qbase/txs"
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/types"
+	"github.com/tendermint/tendermint/crypto"
+)
+
+// 证书由当前root CA签发, 区块时间在有效期内, 且未被吊销
+func validateQSCCA(ctx context.Context, qscMapper *QSCMapper, crt cert.Certificate) error {
+	err := types.VerifyCertWithCRL(qscMapper.GetQSCRootCA(), crt, ctx.BlockHeader().Time.UTC(), qscMapper.IsCertRevoked)
+	if err == types.ErrCertRevoked {
+		return ErrCertRevoked(DefaultCodeSpace, "")
+	}
+	if err != nil {
+		return ErrWrongQSCCA(DefaultCodeSpace, err.Error())
+	}
+
+	return nil
+}
+
+// 签名账户为当前root CA公钥对应地址
+func validateQSCRoot(qscMapper *QSCMapper, root btypes.Address) error {
+	if !types.IsRootCA(qscMapper.GetQSCRootCA(), root) {
+		return ErrWrongRootCA(DefaultCodeSpace, "")
+	}
+
+	return nil
+}
+
+// revoke QSC certs, 由root CA签名, banker由吊销证书授权的QSC将被清除banker
+type TxRevokeQSCCerts struct {
+	Root       btypes.Address `json:"root"`        //root CA公钥对应地址
+	CertHashes []string       `json:"cert_hashes"` //吊销的证书hash
+}
+
+func NewRevokeQSCCertsTx(root btypes.Address, certHashes []string) *TxRevokeQSCCerts {
+	return &TxRevokeQSCCerts{
+		Root:       root,
+		CertHashes: certHashes,
+	}
+}
+
+func (tx TxRevokeQSCCerts) ValidateData(ctx context.Context) error {
+	if _, err := types.ValidateCertHashes(tx.CertHashes); err != nil {
+		return ErrInvalidInput(DefaultCodeSpace, err.Error())
+	}
+
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	return validateQSCRoot(qscMapper, tx.Root)
+}
+
+func (tx TxRevokeQSCCerts) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	result = btypes.Result{
+		Code: btypes.CodeOK,
+	}
+
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	hashes, _ := types.ValidateCertHashes(tx.CertHashes)
+	for _, hash := range hashes {
+		qscMapper.RevokeCert(hash)
+	}
+
+	// 清除由吊销证书授权的banker, 需root CA签发新证书重新指定
+	for _, info := range qscMapper.GetQSCs() {
+		if info.BankerCertHash != "" && qscMapper.IsCertRevoked(info.BankerCertHash) {
+			info.Banker = nil
+			info.BankerCertHash = ""
+			info.BankerUpdateTime = ctx.BlockHeader().Time.UTC()
+			qscMapper.SaveQsc(&info)
+		}
+	}
+
+	return
+}
+
+func (tx TxRevokeQSCCerts) GetSigner() []btypes.Address {
+	return []btypes.Address{tx.Root}
+}
+
+func (tx TxRevokeQSCCerts) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx TxRevokeQSCCerts) GasItems() uint64 {
+	return 0
+}
+
+func (tx TxRevokeQSCCerts) GetGasPayer() btypes.Address {
+	return tx.Root
+}
+
+func (tx TxRevokeQSCCerts) GetSignData() (ret []byte) {
+	ret = append(ret, tx.Root...)
+	for _, hash := range tx.CertHashes {
+		ret = append(ret, hash...)
+	}
+
+	return
+}
+
+// rotate QSC root CA, 由当前root CA签名, 轮换后旧root CA签发的证书失效
+type TxRotateQSCRootCA struct {
+	Root      btypes.Address `json:"root"`        //当前root CA公钥对应地址
+	NewRootCA crypto.PubKey  `json:"new_root_ca"` //新root CA公钥
+}
+
+func NewRotateQSCRootCATx(root btypes.Address, newRootCA crypto.PubKey) *TxRotateQSCRootCA {
+	return &TxRotateQSCRootCA{
+		Root:      root,
+		NewRootCA: newRootCA,
+	}
+}
+
+func (tx TxRotateQSCRootCA) ValidateData(ctx context.Context) error {
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	if err := validateQSCRoot(qscMapper, tx.Root); err != nil {
+		return err
+	}
+	if tx.NewRootCA == nil || tx.NewRootCA.Equals(qscMapper.GetQSCRootCA()) {
+		return ErrInvalidInput(DefaultCodeSpace, "invalid new root ca")
+	}
+
+	return nil
+}
+
+func (tx TxRotateQSCRootCA) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	result = btypes.Result{
+		Code: btypes.CodeOK,
+	}
+
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	qscMapper.SetQSCRootCA(tx.NewRootCA)
+
+	return
+}
+
+func (tx TxRotateQSCRootCA) GetSigner() []btypes.Address {
+	return []btypes.Address{tx.Root}
+}
+
+func (tx TxRotateQSCRootCA) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx TxRotateQSCRootCA) GasItems() uint64 {
+	return 0
+}
+
+func (tx TxRotateQSCRootCA) GetGasPayer() btypes.Address {
+	return tx.Root
+}
+
+func (tx TxRotateQSCRootCA) GetSignData() (ret []byte) {
+	ret = append(ret, tx.Root...)
+	ret = append(ret, tx.NewRootCA.Bytes()...)
+
+	return
+}
@@ -16,6 +16,14 @@ const (
 	CodeQSCExists           btypes.CodeType = 306 // QSC已存在
 	CodeQSCNotExists        btypes.CodeType = 307 // QSC不存在
 	CodeBankerNotExists     btypes.CodeType = 308 // Banker账户不存在
+	CodeHolderNotEnough     btypes.CodeType = 309 // 持币账户余额不足
+	CodeIssueLimitExceeded  btypes.CodeType = 310 // 超过周期发行上限
+	CodeComplianceDisabled  btypes.CodeType = 311 // QSC未启用冻结控制
+	CodeAccountFrozen       btypes.CodeType = 312 // 账户QSC已冻结
+	CodeWrongRootCA         btypes.CodeType = 313 // 签名账户非root CA
+	CodeCertRevoked         btypes.CodeType = 314 // 证书已吊销
+	CodeDepositNotEnough    btypes.CodeType = 315 // 创建账户QOS不足以抵押
+	CodeQSCInCirculation    btypes.CodeType = 316 // QSC仍有流通量
 )
 
 func msgOrDefaultMsg(msg string, code btypes.CodeType) string {
@@ -49,6 +57,22 @@ func codeToDefaultMsg(code btypes.CodeType) string {
 		return "qsc not exists"
 	case CodeBankerNotExists:
 		return "banker not exists"
+	case CodeHolderNotEnough:
+		return "holder has no enough qsc"
+	case CodeIssueLimitExceeded:
+		return "issue amount exceeds period limit"
+	case CodeComplianceDisabled:
+		return "qsc compliance disabled"
+	case CodeAccountFrozen:
+		return "account is frozen"
+	case CodeWrongRootCA:
+		return "wrong root ca"
+	case CodeCertRevoked:
+		return "cert revoked"
+	case CodeDepositNotEnough:
+		return "creator has no enough qos to deposit"
+	case CodeQSCInCirculation:
+		return "qsc still in circulation"
 	default:
 		return btypes.CodeToDefaultMsg(code)
 	}
@@ -85,3 +109,35 @@ func ErrQSCNotExists(codeSpace btypes.CodespaceType, msg string) btypes.Error {
 func ErrBankerNotExists(codeSpace btypes.CodespaceType, msg string) btypes.Error {
 	return newError(codeSpace, CodeBankerNotExists, msg)
 }
+
+func ErrHolderNotEnough(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeHolderNotEnough, msg)
+}
+
+func ErrIssueLimitExceeded(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeIssueLimitExceeded, msg)
+}
+
+func ErrComplianceDisabled(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeComplianceDisabled, msg)
+}
+
+func ErrAccountFrozen(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeAccountFrozen, msg)
+}
+
+func ErrWrongRootCA(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeWrongRootCA, msg)
+}
+
+func ErrCertRevoked(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeCertRevoked, msg)
+}
+
+func ErrDepositNotEnough(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeDepositNotEnough, msg)
+}
+
+func ErrQSCInCirculation(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeQSCInCirculation, msg)
+}
@@ -2,13 +2,26 @@ package qsc
 
 import (
 	"github.com/QOSGroup/qbase/context"
+	btypes "github.com/QOSGroup/qbase/types"
 	"github.com/QOSGroup/qos/module/qsc/types"
+	"github.com/QOSGroup/qos/module/supply"
+	supplytypes "github.com/QOSGroup/qos/module/supply/types"
 	"github.com/tendermint/tendermint/crypto"
 )
 
 type GenesisState struct {
-	RootPubKey crypto.PubKey   `json:"ca_root_pub_key"`
-	QSCs       []types.QSCInfo `json:"qscs"`
+	RootPubKey     crypto.PubKey         `json:"ca_root_pub_key"`
+	QSCs           []types.QSCInfo       `json:"qscs"`
+	FrozenAccounts []FrozenAccount       `json:"frozen_accounts"`
+	ExtrateRecords []types.ExtrateRecord `json:"extrate_records"`
+	CRL            []string              `json:"crl"` //吊销证书hash
+	Params         types.QSCParams       `json:"params"`
+}
+
+// 冻结的账户QSC
+type FrozenAccount struct {
+	QSCName string         `json:"qsc_name"`
+	Address btypes.Address `json:"address"`
 }
 
 func NewGenesisState(pubKey crypto.PubKey, qscs []types.QSCInfo) GenesisState {
@@ -18,19 +31,67 @@ func NewGenesisState(pubKey crypto.PubKey, qscs []types.QSCInfo) GenesisState {
 	}
 }
 
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		Params: types.DefaultQSCParams(),
+	}
+}
+
+// 未设置params时使用默认参数
+func ValidateGenesis(data GenesisState) error {
+	if data.Params == (types.QSCParams{}) {
+		return nil
+	}
+	return data.Params.Validate()
+}
+
 func InitGenesis(ctx context.Context, data GenesisState) {
 	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
 	if data.RootPubKey != nil {
 		qscMapper.SetQSCRootCA(data.RootPubKey)
 	}
 
+	deposits := btypes.ZeroInt()
 	for _, qsc := range data.QSCs {
 		qscMapper.SaveQsc(&qsc)
+		deposits = deposits.Add(qsc.Deposit.NilToZero())
+	}
+	supply.GetSupplyMapper(ctx).SetLockedQOS(supplytypes.QSCDeposits, deposits)
+
+	for _, frozen := range data.FrozenAccounts {
+		qscMapper.FreezeAccount(frozen.QSCName, frozen.Address)
+	}
+
+	for _, record := range data.ExtrateRecords {
+		qscMapper.SaveExtrateRecord(record)
+	}
+
+	for _, hash := range data.CRL {
+		qscMapper.RevokeCert(hash)
+	}
+
+	if data.Params != (types.QSCParams{}) {
+		qscMapper.SetParams(data.Params)
 	}
 }
 
 func ExportGenesis(ctx context.Context) GenesisState {
 	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
 
-	return NewGenesisState(qscMapper.GetQSCRootCA(), qscMapper.GetQSCs())
+	qscs := qscMapper.GetQSCs()
+	var frozenAccounts []FrozenAccount
+	var extrateRecords []types.ExtrateRecord
+	for _, qsc := range qscs {
+		for _, addr := range qscMapper.GetFrozenAccounts(qsc.Name) {
+			frozenAccounts = append(frozenAccounts, FrozenAccount{QSCName: qsc.Name, Address: addr})
+		}
+		extrateRecords = append(extrateRecords, qscMapper.GetExtrateRecords(qsc.Name)...)
+	}
+
+	state := NewGenesisState(qscMapper.GetQSCRootCA(), qscs)
+	state.FrozenAccounts = frozenAccounts
+	state.ExtrateRecords = extrateRecords
+	state.CRL = qscMapper.GetCRL()
+	state.Params = qscMapper.GetParams()
+	return state
 }
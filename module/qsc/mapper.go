@@ -2,15 +2,26 @@ package qsc
 
 import (
 	"fmt"
+	"strings"
+
 	"github.com/QOSGroup/qbase/mapper"
+	btypes "github.com/QOSGroup/qbase/types"
 	"github.com/QOSGroup/qos/module/qsc/types"
 	"github.com/tendermint/tendermint/crypto"
 )
 
 const (
-	QSCMapperName = "qsc"
+	QSCMapperName = types.QSCMapperName
 	QSCKey        = "qsc/[%s]"
+	QSCNameKey    = "name/[%s]"
 	QSCRootCAKey  = "rootca"
+	FrozenKey     = "frozen/[%s]/[%s]"
+	FrozenPrefix  = "frozen/[%s]/"
+	ExtrateKey    = "extrate/[%s]/[%020d]"
+	ExtratePrefix = "extrate/[%s]/"
+	CRLKey        = "crl/[%s]"
+	CRLPrefix     = "crl/"
+	ParamsKey     = "params"
 )
 
 type QSCMapper struct {
@@ -27,10 +38,31 @@ func BuildQSCKey(qscName string) []byte {
 	return []byte(fmt.Sprintf(QSCKey, qscName))
 }
 
+// 名称不区分大小写, 用于校验QSC名称唯一
+func BuildQSCNameKey(qscName string) []byte {
+	return []byte(fmt.Sprintf(QSCNameKey, strings.ToLower(qscName)))
+}
+
 func BuildQSCKeyPrefix() []byte {
 	return []byte("qsc/")
 }
 
+func BuildFrozenKey(qscName string, addr btypes.Address) []byte {
+	return []byte(fmt.Sprintf(FrozenKey, qscName, addr))
+}
+
+func BuildFrozenKeyPrefix(qscName string) []byte {
+	return []byte(fmt.Sprintf(FrozenPrefix, qscName))
+}
+
+func BuildExtrateKey(qscName string, height uint64) []byte {
+	return []byte(fmt.Sprintf(ExtrateKey, qscName, height))
+}
+
+func BuildExtrateKeyPrefix(qscName string) []byte {
+	return []byte(fmt.Sprintf(ExtratePrefix, qscName))
+}
+
 func (mapper *QSCMapper) Copy() mapper.IMapper {
 	qscMapper := &QSCMapper{}
 	qscMapper.BaseMapper = mapper.BaseMapper.Copy()
@@ -39,12 +71,19 @@ func (mapper *QSCMapper) Copy() mapper.IMapper {
 
 func (mapper *QSCMapper) SaveQsc(qscInfo *types.QSCInfo) {
 	mapper.Set(BuildQSCKey(qscInfo.Name), qscInfo)
+	mapper.Set(BuildQSCNameKey(qscInfo.Name), qscInfo.Name)
 }
 
 func (mapper *QSCMapper) Exists(qscName string) bool {
 	return nil != mapper.GetQsc(qscName)
 }
 
+// 是否存在仅大小写不同的同名QSC
+func (mapper *QSCMapper) ExistsIgnoreCase(qscName string) bool {
+	var name string
+	return mapper.Get(BuildQSCNameKey(qscName), &name)
+}
+
 func (mapper *QSCMapper) GetQsc(qscName string) (qscinfo *types.QSCInfo) {
 	var info types.QSCInfo
 	exist := mapper.Get(BuildQSCKey(qscName), &info)
@@ -55,6 +94,51 @@ func (mapper *QSCMapper) GetQsc(qscName string) (qscinfo *types.QSCInfo) {
 	return &info
 }
 
+// 删除QSC信息及其冻结账户、汇率变更记录
+func (mapper *QSCMapper) DeleteQsc(qscName string) {
+	for _, addr := range mapper.GetFrozenAccounts(qscName) {
+		mapper.UnfreezeAccount(qscName, addr)
+	}
+	for _, record := range mapper.GetExtrateRecords(qscName) {
+		mapper.Del(BuildExtrateKey(qscName, record.Height))
+	}
+	mapper.Del(BuildQSCKey(qscName))
+	mapper.Del(BuildQSCNameKey(qscName))
+}
+
+func (mapper *QSCMapper) SetParams(params types.QSCParams) {
+	mapper.Set([]byte(ParamsKey), params)
+}
+
+func (mapper *QSCMapper) GetParams() types.QSCParams {
+	params := types.QSCParams{}
+	exists := mapper.Get([]byte(ParamsKey), &params)
+	if !exists {
+		return types.DefaultQSCParams()
+	}
+	return params
+}
+
+// 增加发行总量
+func (mapper *QSCMapper) IncrQSCIssued(qscName string, amount btypes.BigInt) {
+	info := mapper.GetQsc(qscName)
+	if info == nil {
+		panic(fmt.Sprintf("qsc %s not exists", qscName))
+	}
+	info.TotalIssued = info.TotalIssued.NilToZero().Add(amount)
+	mapper.SaveQsc(info)
+}
+
+// 增加销毁总量
+func (mapper *QSCMapper) IncrQSCBurned(qscName string, amount btypes.BigInt) {
+	info := mapper.GetQsc(qscName)
+	if info == nil {
+		panic(fmt.Sprintf("qsc %s not exists", qscName))
+	}
+	info.TotalBurned = info.TotalBurned.NilToZero().Add(amount)
+	mapper.SaveQsc(info)
+}
+
 // 保存CA
 func (mapper *QSCMapper) SetQSCRootCA(pubKey crypto.PubKey) {
 	mapper.BaseMapper.Set([]byte(QSCRootCAKey), pubKey)
@@ -67,6 +151,27 @@ func (mapper *QSCMapper) GetQSCRootCA() crypto.PubKey {
 	return pubKey
 }
 
+// 吊销证书, hash为types.CertHash
+func (mapper *QSCMapper) RevokeCert(hash string) {
+	mapper.BaseMapper.Set([]byte(fmt.Sprintf(CRLKey, hash)), true)
+}
+
+func (mapper *QSCMapper) IsCertRevoked(hash string) bool {
+	var revoked bool
+	mapper.BaseMapper.Get([]byte(fmt.Sprintf(CRLKey, hash)), &revoked)
+	return revoked
+}
+
+// 吊销列表
+func (mapper *QSCMapper) GetCRL() []string {
+	crl := make([]string, 0)
+	mapper.IteratorWithKV([]byte(CRLPrefix), func(key []byte, value []byte) (stop bool) {
+		crl = append(crl, string(key[len(CRLPrefix)+1:len(key)-1]))
+		return false
+	})
+	return crl
+}
+
 func (mapper *QSCMapper) GetQSCs() []types.QSCInfo {
 	qscs := make([]types.QSCInfo, 0)
 	mapper.Iterator(BuildQSCKeyPrefix(), func(bz []byte) (stop bool) {
@@ -78,3 +183,60 @@ func (mapper *QSCMapper) GetQSCs() []types.QSCInfo {
 
 	return qscs
 }
+
+// 冻结账户持有的QSC
+func (mapper *QSCMapper) FreezeAccount(qscName string, addr btypes.Address) {
+	mapper.Set(BuildFrozenKey(qscName, addr), addr)
+}
+
+// 解冻账户持有的QSC
+func (mapper *QSCMapper) UnfreezeAccount(qscName string, addr btypes.Address) {
+	mapper.Del(BuildFrozenKey(qscName, addr))
+}
+
+func (mapper *QSCMapper) IsFrozen(qscName string, addr btypes.Address) bool {
+	var frozen btypes.Address
+	return mapper.Get(BuildFrozenKey(qscName, addr), &frozen)
+}
+
+// 获取QSC冻结账户列表
+func (mapper *QSCMapper) GetFrozenAccounts(qscName string) []btypes.Address {
+	addrs := make([]btypes.Address, 0)
+	mapper.Iterator(BuildFrozenKeyPrefix(qscName), func(bz []byte) (stop bool) {
+		var addr btypes.Address
+		mapper.DecodeObject(bz, &addr)
+		addrs = append(addrs, addr)
+		return false
+	})
+
+	return addrs
+}
+
+// 保存汇率变更记录
+func (mapper *QSCMapper) SaveExtrateRecord(record types.ExtrateRecord) {
+	mapper.Set(BuildExtrateKey(record.QSCName, record.Height), record)
+}
+
+// 获取height时生效的汇率, 即不晚于height的最近一次变更记录
+func (mapper *QSCMapper) GetExtrateAtHeight(qscName string, height uint64) (record types.ExtrateRecord, exists bool) {
+	iter := mapper.GetStore().ReverseIterator(BuildExtrateKeyPrefix(qscName), BuildExtrateKey(qscName, height+1))
+	defer iter.Close()
+	if iter.Valid() {
+		mapper.DecodeObject(iter.Value(), &record)
+		exists = true
+	}
+	return
+}
+
+// 获取汇率变更记录, 按高度升序
+func (mapper *QSCMapper) GetExtrateRecords(qscName string) []types.ExtrateRecord {
+	records := make([]types.ExtrateRecord, 0)
+	mapper.Iterator(BuildExtrateKeyPrefix(qscName), func(bz []byte) (stop bool) {
+		var record types.ExtrateRecord
+		mapper.DecodeObject(bz, &record)
+		records = append(records, record)
+		return false
+	})
+
+	return records
+}
@@ -0,0 +1,100 @@
+package qsc
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strconv"
+
+	"github.com/QOSGroup/qbase/context"
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/module/qsc/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+/*
+
+custom path:
+/custom/qsc/$query path
+
+query path:
+	/extrate/:qscName/:height : 查询height时生效的汇率, height为0时查询当前汇率
+	/extrates/:qscName : 查询汇率变更记录
+	/params : 查询QSC参数
+
+return:
+  json字节数组
+*/
+
+func Query(ctx context.Context, route []string, req abci.RequestQuery) (res []byte, err btypes.Error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = btypes.ErrInternal(string(debug.Stack()))
+			return
+		}
+	}()
+
+	if len(route) == 0 {
+		return nil, btypes.ErrInternal("custom query miss parameters")
+	}
+
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	if route[0] == types.QueryParams {
+		data, e := qscMapper.GetCodec().MarshalJSON(qscMapper.GetParams())
+		if e != nil {
+			return nil, btypes.ErrInternal(e.Error())
+		}
+		return data, nil
+	}
+
+	if len(route) < 2 {
+		return nil, btypes.ErrInternal("custom query miss parameters")
+	}
+
+	if !qscMapper.Exists(route[1]) {
+		return nil, btypes.ErrInternal(fmt.Sprintf("qsc %s not exists", route[1]))
+	}
+
+	var result interface{}
+	var e error
+
+	switch route[0] {
+	case types.QueryExtrate:
+		height := uint64(ctx.BlockHeight())
+		if len(route) > 2 {
+			h, e := strconv.ParseUint(route[2], 10, 64)
+			if e != nil {
+				return nil, btypes.ErrInternal(e.Error())
+			}
+			if h > 0 {
+				height = h
+			}
+		}
+		result, e = queryExtrate(qscMapper, route[1], height)
+	case types.QueryExtrates:
+		result = qscMapper.GetExtrateRecords(route[1])
+	default:
+		e = errors.New("not found match path")
+	}
+
+	if e != nil {
+		return nil, btypes.ErrInternal(e.Error())
+	}
+
+	data, e := qscMapper.GetCodec().MarshalJSON(result)
+	if e != nil {
+		return nil, btypes.ErrInternal(e.Error())
+	}
+
+	return data, nil
+}
+
+func queryExtrate(qscMapper *QSCMapper, qscName string, height uint64) (interface{}, error) {
+	record, exists := qscMapper.GetExtrateAtHeight(qscName, height)
+	if !exists {
+		return nil, fmt.Errorf("no extrate of %s at height %d", qscName, height)
+	}
+
+	return record, nil
+}
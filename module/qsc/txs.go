@@ -9,23 +9,47 @@ import (
 	"github.com/QOSGroup/qbase/txs"
 	btypes "github.com/QOSGroup/qbase/types"
 	qsctypes "github.com/QOSGroup/qos/module/qsc/types"
+	"github.com/QOSGroup/qos/module/supply"
+	supplytypes "github.com/QOSGroup/qos/module/supply/types"
 	"github.com/QOSGroup/qos/types"
-	"github.com/tendermint/tendermint/crypto"
+	"regexp"
 	"strconv"
+	"strings"
 )
 
 const (
 	MaxDescriptionLen = 1000
 	MaxQSCNameLen     = 8
+	ReservedQSCName   = "qos"
 )
 
+// 无证书创建时QSC名称以字母开头，由3~8位字母或数字组成
+var qscNameRegexp = regexp.MustCompile(fmt.Sprintf("^[A-Za-z][A-Za-z0-9]{2,%d}$", MaxQSCNameLen-1))
+
+// 校验无证书创建时的QSC名称
+func validateQSCName(name string) error {
+	if !qscNameRegexp.MatchString(name) {
+		return ErrInvalidInput(DefaultCodeSpace, "qsc name must start with a letter and contain 3 to 8 letters or digits")
+	}
+
+	return nil
+}
+
+// qos为保留名称，不区分大小写
+func isReservedQSCName(name string) bool {
+	return strings.ToLower(name) == ReservedQSCName
+}
+
 // create QSC
+// 提供QSCCA时按证书创建；未提供时按QSCName创建，creator需抵押QOS，creator即banker
 type TxCreateQSC struct {
 	Creator     btypes.Address      `json:"creator"`     //QSC创建账户
-	Extrate     string              `json:"extrate"`     //qcs:qos汇率(amino不支持binary形式的浮点数序列化，精度同qos erc20 [.0000])
+	Extrate     types.Dec           `json:"extrate"`     //qsc:qos汇率
 	QSCCA       *cert.Certificate   `json:"qsc_crt"`     //CA信息
 	Description string              `json:"description"` //描述信息
 	Accounts    []*types.QOSAccount `json:"accounts"`
+	Compliance  bool                `json:"compliance"` //是否启用冻结控制
+	QSCName     string              `json:"qsc_name"`   //币名，无证书创建时使用
 }
 
 func (tx TxCreateQSC) ValidateData(ctx context.Context) error {
@@ -33,39 +57,50 @@ func (tx TxCreateQSC) ValidateData(ctx context.Context) error {
 		return ErrInvalidInput(DefaultCodeSpace, "")
 	}
 
-	if _, err := strconv.ParseFloat(tx.Extrate, 64); err != nil {
-		return ErrInvalidInput(DefaultCodeSpace, "")
-	}
-
-	// CA校验
-	if tx.QSCCA == nil {
-		return ErrInvalidQSCCA(DefaultCodeSpace, "")
-	}
-	subj, ok := tx.QSCCA.CSR.Subj.(cert.QSCSubject)
-	if !ok {
-		return ErrInvalidQSCCA(DefaultCodeSpace, "")
-	}
-	if subj.ChainId != ctx.ChainID() {
-		return ErrInvalidQSCCA(DefaultCodeSpace, "")
+	if tx.Extrate.IsNil() || !tx.Extrate.IsPositive() {
+		return ErrInvalidInput(DefaultCodeSpace, "extrate must be positive")
 	}
 
 	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
-	rootCA := qscMapper.GetQSCRootCA()
-	if !cert.VerityCrt([]crypto.PubKey{rootCA}, *tx.QSCCA) {
-		return ErrWrongQSCCA(DefaultCodeSpace, "")
+	var name string
+	if tx.QSCCA != nil {
+		// CA校验
+		if len(tx.QSCName) != 0 {
+			return ErrInvalidInput(DefaultCodeSpace, "qsc name must be empty when qsc ca is provided")
+		}
+		subj, ok := tx.QSCCA.CSR.Subj.(cert.QSCSubject)
+		if !ok {
+			return ErrInvalidQSCCA(DefaultCodeSpace, "")
+		}
+		if subj.ChainId != ctx.ChainID() {
+			return ErrInvalidQSCCA(DefaultCodeSpace, "")
+		}
+		if err := validateQSCCA(ctx, qscMapper, *tx.QSCCA); err != nil {
+			return err
+		}
+		name = subj.Name
+	} else {
+		// 名称校验
+		if err := validateQSCName(tx.QSCName); err != nil {
+			return err
+		}
+		name = tx.QSCName
+	}
+	if isReservedQSCName(name) {
+		return ErrInvalidInput(DefaultCodeSpace, fmt.Sprintf("qsc name %s is reserved", name))
 	}
 
 	// accounts校验
 	for _, account := range tx.Accounts {
 		if account.QOS.NilToZero().GT(btypes.ZeroInt()) ||
-			len(account.QSCs) != 1 || account.QSCs[0].Name != subj.Name ||
+			len(account.QSCs) != 1 || account.QSCs[0].Name != name ||
 			!account.QSCs[0].Amount.NilToZero().GT(btypes.ZeroInt()) {
 			return ErrInvalidInitAccounts(DefaultCodeSpace, "")
 		}
 	}
 
-	// QSC不存在
-	if qscMapper.Exists(subj.Name) {
+	// QSC不存在, 名称不区分大小写
+	if qscMapper.ExistsIgnoreCase(name) {
 		return ErrQSCExists(DefaultCodeSpace, "")
 	}
 
@@ -76,6 +111,14 @@ func (tx TxCreateQSC) ValidateData(ctx context.Context) error {
 		return ErrCreatorNotExists(DefaultCodeSpace, "")
 	}
 
+	// 无证书创建时creator可支出QOS足够抵押
+	if tx.QSCCA == nil {
+		deposit := btypes.NewInt(int64(qscMapper.GetParams().CreateDeposit))
+		if types.SpendableQOS(creator, ctx.BlockHeader().Time.UTC()).LT(deposit) {
+			return ErrDepositNotEnough(DefaultCodeSpace, fmt.Sprintf("deposit: %s", deposit))
+		}
+	}
+
 	return nil
 }
 
@@ -84,16 +127,49 @@ func (tx TxCreateQSC) Exec(ctx context.Context) (result btypes.Result, crossTxQc
 		Code: btypes.CodeOK,
 	}
 
-	qscInfo := qsctypes.NewQSCInfoWithQSCCA(tx.QSCCA)
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+
+	var qscInfo qsctypes.QSCInfo
+	if tx.QSCCA != nil {
+		qscInfo = qsctypes.NewQSCInfoWithQSCCA(tx.QSCCA)
+		qscInfo.Deposit = btypes.ZeroInt()
+		if qscInfo.Banker != nil {
+			qscInfo.BankerCertHash = types.CertHash(*tx.QSCCA)
+		}
+	} else {
+		// 扣除creator抵押的QOS
+		deposit := btypes.NewInt(int64(qscMapper.GetParams().CreateDeposit))
+		creatorAcc := accountMapper.GetAccount(tx.Creator)
+		creator, _ := types.ToQOSAccount(creatorAcc)
+		creator.MustMinusQOS(deposit)
+		accountMapper.SetAccount(creatorAcc)
+		supply.GetSupplyMapper(ctx).LockQOS(supplytypes.QSCDeposits, deposit)
+
+		qscInfo = qsctypes.QSCInfo{
+			Name:    tx.QSCName,
+			ChainId: ctx.ChainID(),
+			Banker:  tx.Creator,
+			Deposit: deposit,
+		}
+	}
+	qscInfo.Creator = tx.Creator
 	qscInfo.Extrate = tx.Extrate
 	qscInfo.Description = tx.Description
+	qscInfo.TotalIssued = btypes.ZeroInt()
+	qscInfo.TotalBurned = btypes.ZeroInt()
+	qscInfo.BankerUpdateTime = ctx.BlockHeader().Time.UTC()
+	qscInfo.Compliance = tx.Compliance
+	for _, acc := range tx.Accounts {
+		qscInfo.TotalIssued = qscInfo.TotalIssued.Add(acc.QSCs[0].Amount)
+	}
 
 	// 保存QSC
-	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
 	qscMapper.SaveQsc(&qscInfo)
+	qscMapper.SaveExtrateRecord(qsctypes.NewExtrateRecord(qscInfo.Name, uint64(ctx.BlockHeight()), ctx.BlockHeader().Time, tx.Extrate))
+	supply.GetSupplyMapper(ctx).IncrQSC(qscInfo.Name, qscInfo.TotalIssued)
 
 	// 保存账户信息
-	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
 	if qscInfo.Banker != nil {
 		banker := qscInfo.Banker
 		if nil == accountMapper.GetAccount(banker) {
@@ -102,9 +178,9 @@ func (tx TxCreateQSC) Exec(ctx context.Context) (result btypes.Result, crossTxQc
 	}
 	for _, acc := range tx.Accounts {
 		if a := accountMapper.GetAccount(acc.AccountAddress); a != nil {
-			qosAccount := a.(*types.QOSAccount)
+			qosAccount, _ := types.ToQOSAccount(a)
 			qosAccount.MustPlusQSCs(acc.QSCs)
-			accountMapper.SetAccount(qosAccount)
+			accountMapper.SetAccount(a)
 		} else {
 			accountMapper.SetAccount(acc)
 		}
@@ -121,19 +197,29 @@ func (tx TxCreateQSC) CalcGas() btypes.BigInt {
 	return btypes.ZeroInt()
 }
 
+func (tx TxCreateQSC) GasItems() uint64 {
+	return uint64(len(tx.Accounts))
+}
+
 func (tx TxCreateQSC) GetGasPayer() btypes.Address {
 	return tx.Creator
 }
 
 func (tx TxCreateQSC) GetSignData() (ret []byte) {
 	ret = append(ret, tx.Creator...)
-	ret = append(ret, tx.Extrate...)
+	if !tx.Extrate.IsNil() {
+		ret = append(ret, tx.Extrate.String()...)
+	}
 	ret = append(ret, cdc.MustMarshalBinaryBare(tx.QSCCA)...)
 	ret = append(ret, tx.Description...)
 
 	for _, account := range tx.Accounts {
 		ret = append(ret, fmt.Sprint(account)...)
 	}
+	if tx.Compliance {
+		ret = append(ret, strconv.FormatBool(tx.Compliance)...)
+	}
+	ret = append(ret, tx.QSCName...)
 
 	return
 }
@@ -178,6 +264,11 @@ func (tx TxIssueQSC) ValidateData(ctx context.Context) error {
 		return ErrInvalidInput(DefaultCodeSpace, "")
 	}
 
+	// 周期发行上限
+	if remaining, ok := qscInfo.IssueRemaining(ctx.BlockHeader().Time.UTC()); ok && tx.Amount.GT(remaining) {
+		return ErrIssueLimitExceeded(DefaultCodeSpace, fmt.Sprintf("remaining in current period: %s", remaining))
+	}
+
 	return nil
 }
 
@@ -188,9 +279,22 @@ func (tx TxIssueQSC) Exec(ctx context.Context) (result btypes.Result, crossTxQcp
 
 	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
 
-	banker := accountMapper.GetAccount(tx.Banker).(*types.QOSAccount)
+	bankerAcc := accountMapper.GetAccount(tx.Banker)
+	banker, _ := types.ToQOSAccount(bankerAcc)
 	banker.MustPlusQSCs(types.QSCs{btypes.NewBaseCoin(tx.QSCName, tx.Amount)})
-	accountMapper.SetAccount(banker)
+	accountMapper.SetAccount(bankerAcc)
+
+	// 更新周期发行量
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	qscInfo := qscMapper.GetQsc(tx.QSCName)
+	if qscInfo.HasIssueLimit() {
+		qscInfo.IssueInPeriod(ctx.BlockHeader().Time.UTC(), tx.Amount)
+		qscMapper.SaveQsc(qscInfo)
+	}
+
+	// 更新发行总量
+	qscMapper.IncrQSCIssued(tx.QSCName, tx.Amount)
+	supply.GetSupplyMapper(ctx).IncrQSC(tx.QSCName, tx.Amount)
 
 	return
 }
@@ -203,6 +307,10 @@ func (tx TxIssueQSC) CalcGas() btypes.BigInt {
 	return btypes.ZeroInt()
 }
 
+func (tx TxIssueQSC) GasItems() uint64 {
+	return 0
+}
+
 func (tx TxIssueQSC) GetGasPayer() btypes.Address {
 	return tx.Banker
 }
@@ -214,3 +322,585 @@ func (tx TxIssueQSC) GetSignData() (ret []byte) {
 
 	return
 }
+
+// burn QSC
+type TxBurnQSC struct {
+	QSCName string         `json:"qsc_name"` //币名
+	Amount  btypes.BigInt  `json:"amount"`   //金额
+	Holder  btypes.Address `json:"holder"`   //持币账户
+}
+
+func NewBurnQSCTx(qscName string, amount btypes.BigInt, holder btypes.Address) *TxBurnQSC {
+	return &TxBurnQSC{
+		QSCName: qscName,
+		Amount:  amount,
+		Holder:  holder,
+	}
+}
+
+func (tx TxBurnQSC) ValidateData(ctx context.Context) error {
+	// QscName不能为空，且不能超过8个字符
+	if len(tx.QSCName) == 0 || len(tx.QSCName) > MaxQSCNameLen || len(tx.Holder) == 0 {
+		return ErrInvalidInput(DefaultCodeSpace, "")
+	}
+
+	// Amount大于0
+	if !tx.Amount.NilToZero().GT(btypes.ZeroInt()) {
+		return ErrInvalidInput(DefaultCodeSpace, "")
+	}
+
+	// QSC存在
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	if !qscMapper.Exists(tx.QSCName) {
+		return ErrQSCNotExists(DefaultCodeSpace, "")
+	}
+
+	// 持币账户余额充足
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	holderAcc := accountMapper.GetAccount(tx.Holder)
+	if holderAcc == nil {
+		return ErrHolderNotEnough(DefaultCodeSpace, "")
+	}
+	holder, _ := types.ToQOSAccount(holderAcc)
+	burned := types.QSCs{btypes.NewBaseCoin(tx.QSCName, tx.Amount)}
+	if !holder.EnoughOfQSCs(burned) {
+		return ErrHolderNotEnough(DefaultCodeSpace, "")
+	}
+
+	// 冻结账户不可销毁
+	return ValidateNotFrozen(ctx, tx.Holder, burned)
+}
+
+func (tx TxBurnQSC) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	result = btypes.Result{
+		Code: btypes.CodeOK,
+	}
+
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	holderAcc := accountMapper.GetAccount(tx.Holder)
+	holder, _ := types.ToQOSAccount(holderAcc)
+	holder.MustMinusQSCs(types.QSCs{btypes.NewBaseCoin(tx.QSCName, tx.Amount)})
+	accountMapper.SetAccount(holderAcc)
+
+	// 更新销毁总量
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	qscMapper.IncrQSCBurned(tx.QSCName, tx.Amount)
+	supply.GetSupplyMapper(ctx).DecrQSC(tx.QSCName, tx.Amount)
+
+	return
+}
+
+func (tx TxBurnQSC) GetSigner() []btypes.Address {
+	return []btypes.Address{tx.Holder}
+}
+
+func (tx TxBurnQSC) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx TxBurnQSC) GasItems() uint64 {
+	return 0
+}
+
+func (tx TxBurnQSC) GetGasPayer() btypes.Address {
+	return tx.Holder
+}
+
+func (tx TxBurnQSC) GetSignData() (ret []byte) {
+	ret = append(ret, tx.QSCName...)
+	ret = append(ret, tx.Amount.String()...)
+	ret = append(ret, tx.Holder...)
+
+	return
+}
+
+// change QSC banker
+type TxChangeQSCBanker struct {
+	QSCName     string            `json:"qsc_name"`     //币名
+	Banker      btypes.Address    `json:"banker"`       //当前banker地址，使用证书授权时为空
+	NewBanker   btypes.Address    `json:"new_banker"`   //新banker地址
+	QSCCA       *cert.Certificate `json:"qsc_crt"`      //root CA签发的新证书，为空时由当前banker授权
+	IssueLimit  btypes.BigInt     `json:"issue_limit"`  //每周期发行上限，为空时不修改，为0时取消上限
+	IssuePeriod uint64            `json:"issue_period"` //周期时长，单位秒
+}
+
+func NewChangeQSCBankerTx(qscName string, banker, newBanker btypes.Address, qscCA *cert.Certificate, issueLimit btypes.BigInt, issuePeriod uint64) *TxChangeQSCBanker {
+	return &TxChangeQSCBanker{
+		QSCName:     qscName,
+		Banker:      banker,
+		NewBanker:   newBanker,
+		QSCCA:       qscCA,
+		IssueLimit:  issueLimit,
+		IssuePeriod: issuePeriod,
+	}
+}
+
+func (tx TxChangeQSCBanker) ValidateData(ctx context.Context) error {
+	// QscName不能为空，且不能超过8个字符
+	if len(tx.QSCName) == 0 || len(tx.QSCName) > MaxQSCNameLen || len(tx.NewBanker) == 0 {
+		return ErrInvalidInput(DefaultCodeSpace, "")
+	}
+
+	// QSC存在
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	qscInfo := qscMapper.GetQsc(tx.QSCName)
+	if nil == qscInfo {
+		return ErrQSCNotExists(DefaultCodeSpace, "")
+	}
+
+	if tx.QSCCA == nil {
+		// 当前banker授权
+		if qscInfo.Banker == nil {
+			return ErrBankerNotExists(DefaultCodeSpace, "")
+		}
+		if !bytes.Equal(tx.Banker, qscInfo.Banker) {
+			return ErrInvalidInput(DefaultCodeSpace, "banker not match")
+		}
+	} else {
+		// root CA授权
+		if len(tx.Banker) != 0 {
+			return ErrInvalidInput(DefaultCodeSpace, "banker must be empty when using qsc ca")
+		}
+		subj, ok := tx.QSCCA.CSR.Subj.(cert.QSCSubject)
+		if !ok || subj.ChainId != ctx.ChainID() || subj.Name != tx.QSCName || subj.Banker == nil {
+			return ErrInvalidQSCCA(DefaultCodeSpace, "")
+		}
+		if !bytes.Equal(btypes.Address(subj.Banker.Address()), tx.NewBanker) {
+			return ErrInvalidQSCCA(DefaultCodeSpace, "new banker not match qsc ca")
+		}
+		if err := validateQSCCA(ctx, qscMapper, *tx.QSCCA); err != nil {
+			return err
+		}
+		// 证书需在banker最近变更后签发
+		if !tx.QSCCA.CSR.NotBefore.After(qscInfo.BankerUpdateTime) {
+			return ErrWrongQSCCA(DefaultCodeSpace, "qsc ca is issued before last banker update")
+		}
+	}
+
+	if !tx.IssueLimit.IsNil() {
+		if tx.IssueLimit.LT(btypes.ZeroInt()) {
+			return ErrInvalidInput(DefaultCodeSpace, "issue limit must not be negative")
+		}
+		if !tx.IssueLimit.IsZero() && tx.IssuePeriod == 0 {
+			return ErrInvalidInput(DefaultCodeSpace, "issue period must be positive")
+		}
+		// 当前banker仅可收紧发行上限
+		if tx.QSCCA == nil && qscInfo.HasIssueLimit() {
+			if tx.IssueLimit.IsZero() || tx.IssueLimit.GT(qscInfo.IssueLimit) || tx.IssuePeriod < qscInfo.IssuePeriod {
+				return ErrInvalidInput(DefaultCodeSpace, "banker can only tighten issue limit")
+			}
+		}
+	} else if bytes.Equal(tx.NewBanker, qscInfo.Banker) {
+		return ErrInvalidInput(DefaultCodeSpace, "nothing to change")
+	}
+
+	return nil
+}
+
+func (tx TxChangeQSCBanker) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	result = btypes.Result{
+		Code: btypes.CodeOK,
+	}
+
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	qscInfo := qscMapper.GetQsc(tx.QSCName)
+	if !bytes.Equal(tx.NewBanker, qscInfo.Banker) {
+		qscInfo.Banker = tx.NewBanker
+		qscInfo.BankerUpdateTime = ctx.BlockHeader().Time.UTC()
+	}
+	// 当前banker变更时沿用原证书授权, 原证书吊销后新banker一并失效
+	if tx.QSCCA != nil {
+		qscInfo.BankerCertHash = types.CertHash(*tx.QSCCA)
+	}
+	if !tx.IssueLimit.IsNil() {
+		qscInfo.IssueLimit = tx.IssueLimit
+		qscInfo.IssuePeriod = tx.IssuePeriod
+	}
+	qscMapper.SaveQsc(qscInfo)
+
+	// 新banker账户不存在时创建
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	if nil == accountMapper.GetAccount(tx.NewBanker) {
+		accountMapper.SetAccount(accountMapper.NewAccountWithAddress(tx.NewBanker))
+	}
+
+	return
+}
+
+// 当前banker授权时由当前banker签名，证书授权时由新banker签名
+func (tx TxChangeQSCBanker) GetSigner() []btypes.Address {
+	if tx.QSCCA == nil {
+		return []btypes.Address{tx.Banker}
+	}
+	return []btypes.Address{tx.NewBanker}
+}
+
+func (tx TxChangeQSCBanker) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx TxChangeQSCBanker) GasItems() uint64 {
+	return 0
+}
+
+func (tx TxChangeQSCBanker) GetGasPayer() btypes.Address {
+	return tx.GetSigner()[0]
+}
+
+func (tx TxChangeQSCBanker) GetSignData() (ret []byte) {
+	ret = append(ret, tx.QSCName...)
+	ret = append(ret, tx.Banker...)
+	ret = append(ret, tx.NewBanker...)
+	if tx.QSCCA != nil {
+		ret = append(ret, cdc.MustMarshalBinaryBare(tx.QSCCA)...)
+	}
+	if !tx.IssueLimit.IsNil() {
+		ret = append(ret, tx.IssueLimit.String()...)
+		ret = append(ret, strconv.FormatUint(tx.IssuePeriod, 10)...)
+	}
+
+	return
+}
+
+// update QSC extrate
+type TxUpdateQSCExtrate struct {
+	QSCName string         `json:"qsc_name"` //币名
+	Banker  btypes.Address `json:"banker"`   //banker地址
+	Extrate types.Dec      `json:"extrate"`  //新汇率
+}
+
+func NewUpdateQSCExtrateTx(qscName string, banker btypes.Address, extrate types.Dec) *TxUpdateQSCExtrate {
+	return &TxUpdateQSCExtrate{
+		QSCName: qscName,
+		Banker:  banker,
+		Extrate: extrate,
+	}
+}
+
+func (tx TxUpdateQSCExtrate) ValidateData(ctx context.Context) error {
+	// QscName不能为空，且不能超过8个字符
+	if len(tx.QSCName) == 0 || len(tx.QSCName) > MaxQSCNameLen {
+		return ErrInvalidInput(DefaultCodeSpace, "")
+	}
+
+	// 汇率大于0
+	if tx.Extrate.IsNil() || !tx.Extrate.IsPositive() {
+		return ErrInvalidInput(DefaultCodeSpace, "extrate must be positive")
+	}
+
+	// QSC存在
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	qscInfo := qscMapper.GetQsc(tx.QSCName)
+	if nil == qscInfo {
+		return ErrQSCNotExists(DefaultCodeSpace, "")
+	}
+
+	// banker 地址一致
+	if qscInfo.Banker == nil {
+		return ErrBankerNotExists(DefaultCodeSpace, "")
+	}
+	if !bytes.Equal(tx.Banker, qscInfo.Banker) {
+		return ErrInvalidInput(DefaultCodeSpace, "banker not match")
+	}
+
+	return nil
+}
+
+func (tx TxUpdateQSCExtrate) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	result = btypes.Result{
+		Code: btypes.CodeOK,
+	}
+
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	qscInfo := qscMapper.GetQsc(tx.QSCName)
+	qscInfo.Extrate = tx.Extrate
+	qscMapper.SaveQsc(qscInfo)
+
+	// 记录汇率变更
+	qscMapper.SaveExtrateRecord(qsctypes.NewExtrateRecord(tx.QSCName, uint64(ctx.BlockHeight()), ctx.BlockHeader().Time, tx.Extrate))
+
+	return
+}
+
+func (tx TxUpdateQSCExtrate) GetSigner() []btypes.Address {
+	return []btypes.Address{tx.Banker}
+}
+
+func (tx TxUpdateQSCExtrate) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx TxUpdateQSCExtrate) GasItems() uint64 {
+	return 0
+}
+
+func (tx TxUpdateQSCExtrate) GetGasPayer() btypes.Address {
+	return tx.Banker
+}
+
+func (tx TxUpdateQSCExtrate) GetSignData() (ret []byte) {
+	ret = append(ret, tx.QSCName...)
+	ret = append(ret, tx.Banker...)
+	if !tx.Extrate.IsNil() {
+		ret = append(ret, tx.Extrate.String()...)
+	}
+
+	return
+}
+
+// freeze QSC of account
+type TxFreezeQSCAccount struct {
+	QSCName string         `json:"qsc_name"` //币名
+	Banker  btypes.Address `json:"banker"`   //banker地址
+	Account btypes.Address `json:"account"`  //冻结账户
+}
+
+func NewFreezeQSCAccountTx(qscName string, banker, account btypes.Address) *TxFreezeQSCAccount {
+	return &TxFreezeQSCAccount{
+		QSCName: qscName,
+		Banker:  banker,
+		Account: account,
+	}
+}
+
+func (tx TxFreezeQSCAccount) ValidateData(ctx context.Context) error {
+	if err := validateFreezeAccount(ctx, tx.QSCName, tx.Banker, tx.Account); err != nil {
+		return err
+	}
+
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	if qscMapper.IsFrozen(tx.QSCName, tx.Account) {
+		return ErrAccountFrozen(DefaultCodeSpace, "account already frozen")
+	}
+
+	return nil
+}
+
+func (tx TxFreezeQSCAccount) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	result = btypes.Result{
+		Code: btypes.CodeOK,
+	}
+
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	qscMapper.FreezeAccount(tx.QSCName, tx.Account)
+
+	return
+}
+
+func (tx TxFreezeQSCAccount) GetSigner() []btypes.Address {
+	return []btypes.Address{tx.Banker}
+}
+
+func (tx TxFreezeQSCAccount) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx TxFreezeQSCAccount) GasItems() uint64 {
+	return 0
+}
+
+func (tx TxFreezeQSCAccount) GetGasPayer() btypes.Address {
+	return tx.Banker
+}
+
+func (tx TxFreezeQSCAccount) GetSignData() (ret []byte) {
+	ret = append(ret, tx.QSCName...)
+	ret = append(ret, tx.Banker...)
+	ret = append(ret, tx.Account...)
+
+	return
+}
+
+// unfreeze QSC of account
+type TxUnfreezeQSCAccount struct {
+	QSCName string         `json:"qsc_name"` //币名
+	Banker  btypes.Address `json:"banker"`   //banker地址
+	Account btypes.Address `json:"account"`  //解冻账户
+}
+
+func NewUnfreezeQSCAccountTx(qscName string, banker, account btypes.Address) *TxUnfreezeQSCAccount {
+	return &TxUnfreezeQSCAccount{
+		QSCName: qscName,
+		Banker:  banker,
+		Account: account,
+	}
+}
+
+func (tx TxUnfreezeQSCAccount) ValidateData(ctx context.Context) error {
+	if err := validateFreezeAccount(ctx, tx.QSCName, tx.Banker, tx.Account); err != nil {
+		return err
+	}
+
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	if !qscMapper.IsFrozen(tx.QSCName, tx.Account) {
+		return ErrInvalidInput(DefaultCodeSpace, "account not frozen")
+	}
+
+	return nil
+}
+
+func (tx TxUnfreezeQSCAccount) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	result = btypes.Result{
+		Code: btypes.CodeOK,
+	}
+
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	qscMapper.UnfreezeAccount(tx.QSCName, tx.Account)
+
+	return
+}
+
+func (tx TxUnfreezeQSCAccount) GetSigner() []btypes.Address {
+	return []btypes.Address{tx.Banker}
+}
+
+func (tx TxUnfreezeQSCAccount) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx TxUnfreezeQSCAccount) GasItems() uint64 {
+	return 0
+}
+
+func (tx TxUnfreezeQSCAccount) GetGasPayer() btypes.Address {
+	return tx.Banker
+}
+
+func (tx TxUnfreezeQSCAccount) GetSignData() (ret []byte) {
+	ret = append(ret, tx.QSCName...)
+	ret = append(ret, tx.Banker...)
+	ret = append(ret, tx.Account...)
+
+	return
+}
+
+// retire QSC
+// 流通量为0时由banker退役QSC，退还creator创建时抵押的QOS
+type TxRetireQSC struct {
+	QSCName string         `json:"qsc_name"` //币名
+	Banker  btypes.Address `json:"banker"`   //banker地址
+}
+
+func NewRetireQSCTx(qscName string, banker btypes.Address) *TxRetireQSC {
+	return &TxRetireQSC{
+		QSCName: qscName,
+		Banker:  banker,
+	}
+}
+
+func (tx TxRetireQSC) ValidateData(ctx context.Context) error {
+	if len(tx.QSCName) == 0 || len(tx.QSCName) > MaxQSCNameLen || len(tx.Banker) == 0 {
+		return ErrInvalidInput(DefaultCodeSpace, "")
+	}
+
+	// QSC存在
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	qscInfo := qscMapper.GetQsc(tx.QSCName)
+	if nil == qscInfo {
+		return ErrQSCNotExists(DefaultCodeSpace, "")
+	}
+
+	// banker 地址一致
+	if qscInfo.Banker == nil {
+		return ErrBankerNotExists(DefaultCodeSpace, "")
+	}
+	if !bytes.Equal(tx.Banker, qscInfo.Banker) {
+		return ErrInvalidInput(DefaultCodeSpace, "banker not match")
+	}
+
+	// 无流通量
+	if circulating := qscInfo.CirculatingSupply(); !circulating.IsZero() {
+		return ErrQSCInCirculation(DefaultCodeSpace, fmt.Sprintf("circulating supply: %s", circulating))
+	}
+
+	return nil
+}
+
+func (tx TxRetireQSC) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	result = btypes.Result{
+		Code: btypes.CodeOK,
+	}
+
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	qscInfo := qscMapper.GetQsc(tx.QSCName)
+	qscMapper.DeleteQsc(tx.QSCName)
+
+	// 退还抵押
+	deposit := qscInfo.Deposit.NilToZero()
+	if deposit.GT(btypes.ZeroInt()) {
+		accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+		creatorAcc := accountMapper.GetAccount(qscInfo.Creator)
+		if nil == creatorAcc {
+			creatorAcc = accountMapper.NewAccountWithAddress(qscInfo.Creator)
+		}
+		creator, _ := types.ToQOSAccount(creatorAcc)
+		creator.MustPlusQOS(deposit)
+		accountMapper.SetAccount(creatorAcc)
+		supply.GetSupplyMapper(ctx).UnlockQOS(supplytypes.QSCDeposits, deposit)
+	}
+
+	return
+}
+
+func (tx TxRetireQSC) GetSigner() []btypes.Address {
+	return []btypes.Address{tx.Banker}
+}
+
+func (tx TxRetireQSC) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx TxRetireQSC) GasItems() uint64 {
+	return 0
+}
+
+func (tx TxRetireQSC) GetGasPayer() btypes.Address {
+	return tx.Banker
+}
+
+func (tx TxRetireQSC) GetSignData() (ret []byte) {
+	ret = append(ret, tx.QSCName...)
+	ret = append(ret, tx.Banker...)
+
+	return
+}
+
+// 冻结/解冻校验: QSC存在且启用冻结控制，由banker签名
+func validateFreezeAccount(ctx context.Context, qscName string, banker, account btypes.Address) error {
+	if len(qscName) == 0 || len(qscName) > MaxQSCNameLen || len(account) == 0 {
+		return ErrInvalidInput(DefaultCodeSpace, "")
+	}
+
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	qscInfo := qscMapper.GetQsc(qscName)
+	if nil == qscInfo {
+		return ErrQSCNotExists(DefaultCodeSpace, "")
+	}
+	if !qscInfo.Compliance {
+		return ErrComplianceDisabled(DefaultCodeSpace, "")
+	}
+	if qscInfo.Banker == nil {
+		return ErrBankerNotExists(DefaultCodeSpace, "")
+	}
+	if !bytes.Equal(banker, qscInfo.Banker) {
+		return ErrInvalidInput(DefaultCodeSpace, "banker not match")
+	}
+
+	return nil
+}
+
+// 校验账户发送的QSC均未被冻结，QOS不受冻结影响
+func ValidateNotFrozen(ctx context.Context, addr btypes.Address, qscs types.QSCs) error {
+	if len(qscs) == 0 {
+		return nil
+	}
+
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	for _, qsc := range qscs {
+		if qscMapper.IsFrozen(qsc.Name, addr) {
+			return ErrAccountFrozen(DefaultCodeSpace, fmt.Sprintf("%s of %s is frozen", qsc.Name, addr))
+		}
+	}
+
+	return nil
+}
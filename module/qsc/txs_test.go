@@ -0,0 +1,409 @@
+package qsc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/QOSGroup/kepler/cert"
+	bacc "github.com/QOSGroup/qbase/account"
+	"github.com/QOSGroup/qbase/context"
+	bmapper "github.com/QOSGroup/qbase/mapper"
+	"github.com/QOSGroup/qbase/store"
+	btypes "github.com/QOSGroup/qbase/types"
+	qsctypes "github.com/QOSGroup/qos/module/qsc/types"
+	"github.com/QOSGroup/qos/module/supply"
+	supplytypes "github.com/QOSGroup/qos/module/supply/types"
+	"github.com/QOSGroup/qos/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func defaultContext() context.Context {
+	mapperMap := make(map[string]bmapper.IMapper)
+
+	qscMapper := NewQSCMapper()
+	qscMapper.SetCodec(cdc)
+	qscKey := qscMapper.GetStoreKey()
+	mapperMap[QSCMapperName] = qscMapper
+
+	accountMapper := bacc.NewAccountMapper(nil, types.ProtoQOSAccount)
+	accountMapper.SetCodec(cdc)
+	accountKey := accountMapper.GetStoreKey()
+	mapperMap[bacc.AccountMapperName] = accountMapper
+
+	supplyMapper := supply.NewSupplyMapper()
+	supplyMapper.SetCodec(cdc)
+	supplyKey := supplyMapper.GetStoreKey()
+	mapperMap[supplytypes.SupplyMapperName] = supplyMapper
+
+	db := dbm.NewMemDB()
+	cms := store.NewCommitMultiStore(db)
+	cms.MountStoreWithDB(qscKey, store.StoreTypeIAVL, db)
+	cms.MountStoreWithDB(accountKey, store.StoreTypeIAVL, db)
+	cms.MountStoreWithDB(supplyKey, store.StoreTypeIAVL, db)
+	cms.LoadLatestVersion()
+
+	return context.NewContext(cms, abci.Header{}, false, log.NewNopLogger(), mapperMap)
+}
+
+func TestTxBurnQSC(t *testing.T) {
+	ctx := defaultContext()
+
+	banker := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	holder := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	qscMapper.SaveQsc(&qsctypes.QSCInfo{Name: "star", Banker: banker, TotalIssued: btypes.NewInt(100)})
+
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	accountMapper.SetAccount(accountMapper.NewAccountWithAddress(banker))
+	accountMapper.SetAccount(types.NewQOSAccount(holder, btypes.ZeroInt(), types.QSCs{btypes.NewBaseCoin("star", btypes.NewInt(100))}))
+
+	//发行
+	issueTx := TxIssueQSC{QSCName: "star", Amount: btypes.NewInt(50), Banker: banker}
+	require.Nil(t, issueTx.ValidateData(ctx))
+	result, _ := issueTx.Exec(ctx)
+	require.True(t, result.IsOK())
+
+	//余额不足
+	require.NotNil(t, NewBurnQSCTx("star", btypes.NewInt(101), holder).ValidateData(ctx))
+	//QSC不存在
+	require.NotNil(t, NewBurnQSCTx("moon", btypes.NewInt(10), holder).ValidateData(ctx))
+	//数量必须为正
+	require.NotNil(t, NewBurnQSCTx("star", btypes.ZeroInt(), holder).ValidateData(ctx))
+
+	burnTx := NewBurnQSCTx("star", btypes.NewInt(30), holder)
+	require.Nil(t, burnTx.ValidateData(ctx))
+	result, _ = burnTx.Exec(ctx)
+	require.True(t, result.IsOK())
+
+	acc, _ := types.ToQOSAccount(accountMapper.GetAccount(holder))
+	qsc, _ := acc.GetQSC("star")
+	require.True(t, qsc.Amount.Equal(btypes.NewInt(70)))
+
+	info := qscMapper.GetQsc("star")
+	require.True(t, info.TotalIssued.Equal(btypes.NewInt(150)))
+	require.True(t, info.TotalBurned.Equal(btypes.NewInt(30)))
+	require.True(t, info.CirculatingSupply().Equal(btypes.NewInt(120)))
+
+	//代币总量记录发行及销毁数量
+	total := supply.GetSupplyMapper(ctx).GetSupply()
+	require.True(t, total.QSCs.AmountOf("star").Equal(btypes.NewInt(20)))
+}
+
+func newQSCCert(rootKey crypto.PrivKey, chainID, name string, banker crypto.PubKey, notBefore time.Time) *cert.Certificate {
+	csr := cert.CertificateSigningRequest{
+		Subj:      cert.QSCSubject{ChainId: chainID, Name: name, Banker: banker},
+		NotBefore: notBefore,
+		NotAfter:  notBefore.Add(24 * time.Hour),
+		PublicKey: banker,
+	}
+	signature, _ := rootKey.Sign(cert.MustMarshalJson(csr))
+	return &cert.Certificate{
+		CSR:       csr,
+		CA:        cert.Issuer{Subj: cert.CommonSubject{CN: "QSC"}, PublicKey: rootKey.PubKey()},
+		Signature: signature,
+	}
+}
+
+func TestTxChangeQSCBanker(t *testing.T) {
+	now := time.Now().UTC()
+	ctx := defaultContext().WithChainID("qos").WithBlockHeader(abci.Header{Time: now.Add(-2 * time.Hour)})
+
+	rootKey := ed25519.GenPrivKey()
+	banker := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	qscMapper.SetQSCRootCA(rootKey.PubKey())
+	qscMapper.SaveQsc(&qsctypes.QSCInfo{Name: "star", Banker: banker, BankerUpdateTime: now.Add(-2 * time.Hour)})
+
+	//当前banker授权
+	newBanker := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	require.NotNil(t, NewChangeQSCBankerTx("star", newBanker, newBanker, nil, btypes.NewInt(0), 0).ValidateData(ctx))
+	require.NotNil(t, NewChangeQSCBankerTx("star", banker, banker, nil, btypes.BigInt{}, 0).ValidateData(ctx))
+	tx := NewChangeQSCBankerTx("star", banker, newBanker, nil, btypes.NewInt(100), 3600)
+	require.Nil(t, tx.ValidateData(ctx))
+	require.Equal(t, []btypes.Address{banker}, tx.GetSigner())
+	result, _ := tx.Exec(ctx)
+	require.True(t, result.IsOK())
+
+	info := qscMapper.GetQsc("star")
+	require.Equal(t, newBanker, info.Banker)
+	require.True(t, info.IssueLimit.Equal(btypes.NewInt(100)))
+
+	//banker仅可收紧发行上限
+	require.NotNil(t, NewChangeQSCBankerTx("star", newBanker, newBanker, nil, btypes.NewInt(200), 3600).ValidateData(ctx))
+	require.NotNil(t, NewChangeQSCBankerTx("star", newBanker, newBanker, nil, btypes.ZeroInt(), 0).ValidateData(ctx))
+	require.Nil(t, NewChangeQSCBankerTx("star", newBanker, newBanker, nil, btypes.NewInt(50), 7200).ValidateData(ctx))
+
+	//root CA授权，证书需在banker最近变更后签发，区块时间在证书有效期内
+	ctx = ctx.WithBlockHeader(abci.Header{Time: now})
+	caBankerKey := ed25519.GenPrivKey().PubKey()
+	caBanker := btypes.Address(caBankerKey.Address())
+	oldCrt := newQSCCert(rootKey, "qos", "star", caBankerKey, now.Add(-3*time.Hour))
+	require.NotNil(t, NewChangeQSCBankerTx("star", nil, caBanker, oldCrt, btypes.BigInt{}, 0).ValidateData(ctx))
+
+	crt := newQSCCert(rootKey, "qos", "star", caBankerKey, now.Add(-time.Hour))
+	require.NotNil(t, NewChangeQSCBankerTx("star", nil, newBanker, crt, btypes.BigInt{}, 0).ValidateData(ctx))
+	require.NotNil(t, NewChangeQSCBankerTx("star", nil, caBanker, newQSCCert(ed25519.GenPrivKey(), "qos", "star", caBankerKey, now.Add(-time.Hour)), btypes.BigInt{}, 0).ValidateData(ctx))
+
+	tx = NewChangeQSCBankerTx("star", nil, caBanker, crt, btypes.ZeroInt(), 0)
+	require.Nil(t, tx.ValidateData(ctx))
+	require.Equal(t, []btypes.Address{caBanker}, tx.GetSigner())
+	result, _ = tx.Exec(ctx)
+	require.True(t, result.IsOK())
+
+	info = qscMapper.GetQsc("star")
+	require.Equal(t, caBanker, info.Banker)
+	require.False(t, info.HasIssueLimit())
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	require.NotNil(t, accountMapper.GetAccount(caBanker))
+
+	//证书吊销后清除其授权的banker
+	hash := types.CertHash(*crt)
+	require.Equal(t, hash, info.BankerCertHash)
+	revokeTx := NewRevokeQSCCertsTx(btypes.Address(rootKey.PubKey().Address()), []string{hash})
+	require.Nil(t, revokeTx.ValidateData(ctx))
+	result, _ = revokeTx.Exec(ctx)
+	require.True(t, result.IsOK())
+	info = qscMapper.GetQsc("star")
+	require.Empty(t, info.Banker)
+	require.Equal(t, "", info.BankerCertHash)
+	require.NotNil(t, NewChangeQSCBankerTx("star", caBanker, newBanker, nil, btypes.BigInt{}, 0).ValidateData(ctx))
+}
+
+func TestTxIssueQSC_IssueLimit(t *testing.T) {
+	blockTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := defaultContext().WithBlockHeader(abci.Header{Time: blockTime})
+
+	banker := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	qscMapper.SaveQsc(&qsctypes.QSCInfo{Name: "star", Banker: banker, IssueLimit: btypes.NewInt(100), IssuePeriod: 3600})
+
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	accountMapper.SetAccount(accountMapper.NewAccountWithAddress(banker))
+
+	issueTx := TxIssueQSC{QSCName: "star", Amount: btypes.NewInt(60), Banker: banker}
+	require.Nil(t, issueTx.ValidateData(ctx))
+	result, _ := issueTx.Exec(ctx)
+	require.True(t, result.IsOK())
+
+	//超过周期发行上限
+	require.NotNil(t, issueTx.ValidateData(ctx))
+	require.Nil(t, TxIssueQSC{QSCName: "star", Amount: btypes.NewInt(40), Banker: banker}.ValidateData(ctx))
+
+	//新周期
+	ctx = ctx.WithBlockHeader(abci.Header{Time: blockTime.Add(time.Hour)})
+	require.Nil(t, issueTx.ValidateData(ctx))
+	result, _ = issueTx.Exec(ctx)
+	require.True(t, result.IsOK())
+
+	info := qscMapper.GetQsc("star")
+	require.True(t, info.TotalIssued.Equal(btypes.NewInt(120)))
+	require.True(t, info.IssuePeriodIssued.Equal(btypes.NewInt(60)))
+	require.Equal(t, blockTime.Add(time.Hour), info.IssuePeriodStart)
+}
+
+func TestTxFreezeQSCAccount(t *testing.T) {
+	ctx := defaultContext()
+
+	banker := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	holder := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	qscMapper.SaveQsc(&qsctypes.QSCInfo{Name: "star", Banker: banker, Compliance: true})
+	qscMapper.SaveQsc(&qsctypes.QSCInfo{Name: "moon", Banker: banker})
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	accountMapper.SetAccount(types.NewQOSAccount(holder, btypes.ZeroInt(), types.QSCs{btypes.NewBaseCoin("star", btypes.NewInt(100))}))
+
+	//未启用冻结控制
+	require.NotNil(t, NewFreezeQSCAccountTx("moon", banker, holder).ValidateData(ctx))
+	//非banker
+	require.NotNil(t, NewFreezeQSCAccountTx("star", holder, holder).ValidateData(ctx))
+	//未冻结
+	require.NotNil(t, NewUnfreezeQSCAccountTx("star", banker, holder).ValidateData(ctx))
+
+	freezeTx := NewFreezeQSCAccountTx("star", banker, holder)
+	require.Nil(t, freezeTx.ValidateData(ctx))
+	result, _ := freezeTx.Exec(ctx)
+	require.True(t, result.IsOK())
+	require.NotNil(t, freezeTx.ValidateData(ctx))
+
+	require.True(t, qscMapper.IsFrozen("star", holder))
+	require.Equal(t, []btypes.Address{holder}, qscMapper.GetFrozenAccounts("star"))
+	require.NotNil(t, ValidateNotFrozen(ctx, holder, types.QSCs{btypes.NewBaseCoin("star", btypes.NewInt(1))}))
+	require.Nil(t, ValidateNotFrozen(ctx, holder, types.QSCs{btypes.NewBaseCoin("moon", btypes.NewInt(1))}))
+	require.Nil(t, ValidateNotFrozen(ctx, banker, types.QSCs{btypes.NewBaseCoin("star", btypes.NewInt(1))}))
+	//冻结账户不可销毁
+	require.NotNil(t, NewBurnQSCTx("star", btypes.NewInt(10), holder).ValidateData(ctx))
+
+	//导出冻结账户
+	genesis := ExportGenesis(ctx)
+	require.Equal(t, []FrozenAccount{{QSCName: "star", Address: holder}}, genesis.FrozenAccounts)
+
+	unfreezeTx := NewUnfreezeQSCAccountTx("star", banker, holder)
+	require.Nil(t, unfreezeTx.ValidateData(ctx))
+	result, _ = unfreezeTx.Exec(ctx)
+	require.True(t, result.IsOK())
+	require.False(t, qscMapper.IsFrozen("star", holder))
+	require.Nil(t, ValidateNotFrozen(ctx, holder, types.QSCs{btypes.NewBaseCoin("star", btypes.NewInt(1))}))
+	require.Nil(t, NewBurnQSCTx("star", btypes.NewInt(10), holder).ValidateData(ctx))
+}
+
+func TestTxUpdateQSCExtrate(t *testing.T) {
+	ctx := defaultContext().WithBlockHeight(10)
+
+	banker := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	qscMapper.SaveQsc(&qsctypes.QSCInfo{Name: "star", Banker: banker, Extrate: types.OneDec()})
+	qscMapper.SaveExtrateRecord(qsctypes.NewExtrateRecord("star", 10, time.Time{}, types.OneDec()))
+
+	rate, _ := types.NewDecFromStr("2.5")
+
+	//非banker
+	require.NotNil(t, NewUpdateQSCExtrateTx("star", btypes.Address(ed25519.GenPrivKey().PubKey().Address()), rate).ValidateData(ctx))
+	//汇率需大于0
+	require.NotNil(t, NewUpdateQSCExtrateTx("star", banker, types.ZeroDec()).ValidateData(ctx))
+
+	ctx = ctx.WithBlockHeight(20)
+	tx := NewUpdateQSCExtrateTx("star", banker, rate)
+	require.Nil(t, tx.ValidateData(ctx))
+	result, _ := tx.Exec(ctx)
+	require.True(t, result.IsOK())
+	require.True(t, qscMapper.GetQsc("star").Extrate.Equal(rate))
+
+	//历史汇率
+	_, exists := qscMapper.GetExtrateAtHeight("star", 9)
+	require.False(t, exists)
+	record, exists := qscMapper.GetExtrateAtHeight("star", 19)
+	require.True(t, exists)
+	require.True(t, record.Extrate.Equal(types.OneDec()))
+	record, exists = qscMapper.GetExtrateAtHeight("star", 25)
+	require.True(t, exists)
+	require.True(t, record.Extrate.Equal(rate))
+	require.Equal(t, 2, len(qscMapper.GetExtrateRecords("star")))
+}
+
+func TestTxRevokeQSCCerts(t *testing.T) {
+	now := time.Now().UTC()
+	ctx := defaultContext().WithChainID("qos").WithBlockHeader(abci.Header{Time: now})
+
+	rootKey := ed25519.GenPrivKey()
+	root := btypes.Address(rootKey.PubKey().Address())
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	qscMapper.SetQSCRootCA(rootKey.PubKey())
+
+	creator := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	accountMapper.SetAccount(types.NewQOSAccountWithAddress(creator))
+
+	crt := newQSCCert(rootKey, "qos", "star", nil, now.Add(-time.Hour))
+	createTx := TxCreateQSC{Creator: creator, Extrate: types.OneDec(), QSCCA: crt}
+	require.Nil(t, createTx.ValidateData(ctx))
+
+	//按区块时间校验有效期
+	require.NotNil(t, createTx.ValidateData(ctx.WithBlockHeader(abci.Header{Time: now.Add(24 * time.Hour)})))
+	require.NotNil(t, createTx.ValidateData(ctx.WithBlockHeader(abci.Header{Time: now.Add(-2 * time.Hour)})))
+
+	//非root CA签名
+	hash := types.CertHash(*crt)
+	require.NotNil(t, NewRevokeQSCCertsTx(creator, []string{hash}).ValidateData(ctx))
+	//hash格式有误
+	require.NotNil(t, NewRevokeQSCCertsTx(root, []string{"star"}).ValidateData(ctx))
+
+	revokeTx := NewRevokeQSCCertsTx(root, []string{hash})
+	require.Nil(t, revokeTx.ValidateData(ctx))
+	result, _ := revokeTx.Exec(ctx)
+	require.True(t, result.IsOK())
+	require.True(t, qscMapper.IsCertRevoked(hash))
+	require.Equal(t, []string{hash}, qscMapper.GetCRL())
+	require.NotNil(t, createTx.ValidateData(ctx))
+
+	//root CA轮换后旧root CA签发的证书失效
+	newRootKey := ed25519.GenPrivKey()
+	rotateTx := NewRotateQSCRootCATx(root, newRootKey.PubKey())
+	require.NotNil(t, NewRotateQSCRootCATx(creator, newRootKey.PubKey()).ValidateData(ctx))
+	require.Nil(t, rotateTx.ValidateData(ctx))
+	result, _ = rotateTx.Exec(ctx)
+	require.True(t, result.IsOK())
+	require.True(t, newRootKey.PubKey().Equals(qscMapper.GetQSCRootCA()))
+
+	createTx.QSCCA = newQSCCert(rootKey, "qos", "star", nil, now.Add(-time.Hour))
+	require.NotNil(t, createTx.ValidateData(ctx))
+	createTx.QSCCA = newQSCCert(newRootKey, "qos", "star", nil, now.Add(-time.Hour))
+	require.Nil(t, createTx.ValidateData(ctx))
+	require.NotNil(t, rotateTx.ValidateData(ctx))
+}
+
+func TestTxCreateQSCWithDeposit(t *testing.T) {
+	ctx := defaultContext()
+
+	creator := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	holder := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	accountMapper.SetAccount(types.NewQOSAccount(creator, btypes.NewInt(400), nil))
+	qscMapper := ctx.Mapper(QSCMapperName).(*QSCMapper)
+	qscMapper.SetParams(qsctypes.NewQSCParams(500))
+
+	newTx := func(name string) TxCreateQSC {
+		return TxCreateQSC{
+			Creator:  creator,
+			Extrate:  types.OneDec(),
+			QSCName:  name,
+			Accounts: []*types.QOSAccount{types.NewQOSAccount(holder, btypes.ZeroInt(), types.QSCs{btypes.NewBaseCoin(name, btypes.NewInt(100))})},
+		}
+	}
+
+	//名称不符合规则
+	for _, name := range []string{"", "ab", "1star", "st_ar", "starstars"} {
+		require.NotNil(t, newTx(name).ValidateData(ctx), name)
+	}
+	//保留名称
+	require.NotNil(t, newTx("QOS").ValidateData(ctx))
+	//QOS不足以抵押
+	require.NotNil(t, newTx("star").ValidateData(ctx))
+
+	accountMapper.SetAccount(types.NewQOSAccount(creator, btypes.NewInt(1000), nil))
+	tx := newTx("star")
+	require.Nil(t, tx.ValidateData(ctx))
+	result, _ := tx.Exec(ctx)
+	require.True(t, result.IsOK())
+
+	info := qscMapper.GetQsc("star")
+	require.Equal(t, creator, info.Banker)
+	require.Equal(t, creator, info.Creator)
+	require.True(t, info.Deposit.Equal(btypes.NewInt(500)))
+	acc, _ := types.ToQOSAccount(accountMapper.GetAccount(creator))
+	require.True(t, acc.GetQOS().Equal(btypes.NewInt(500)))
+	supplyMapper := supply.GetSupplyMapper(ctx)
+	require.True(t, supplyMapper.GetLockedQOS(supplytypes.QSCDeposits).Equal(btypes.NewInt(500)))
+
+	//QSC已存在, 名称不区分大小写
+	for _, name := range []string{"star", "Star", "STAR"} {
+		require.NotNil(t, newTx(name).ValidateData(ctx), name)
+	}
+
+	//仍有流通量
+	require.NotNil(t, NewRetireQSCTx("star", creator).ValidateData(ctx))
+	burnTx := NewBurnQSCTx("star", btypes.NewInt(100), holder)
+	require.Nil(t, burnTx.ValidateData(ctx))
+	burnTx.Exec(ctx)
+
+	//非banker
+	require.NotNil(t, NewRetireQSCTx("star", holder).ValidateData(ctx))
+
+	retireTx := NewRetireQSCTx("star", creator)
+	require.Nil(t, retireTx.ValidateData(ctx))
+	result, _ = retireTx.Exec(ctx)
+	require.True(t, result.IsOK())
+	require.False(t, qscMapper.Exists("star"))
+	require.Equal(t, 0, len(qscMapper.GetExtrateRecords("star")))
+	acc, _ = types.ToQOSAccount(accountMapper.GetAccount(creator))
+	require.True(t, acc.GetQOS().Equal(btypes.NewInt(1000)))
+	require.True(t, supplyMapper.GetLockedQOS(supplytypes.QSCDeposits).IsZero())
+
+	//退役后可重新创建
+	require.Nil(t, newTx("star").ValidateData(ctx))
+	require.Nil(t, newTx("Star").ValidateData(ctx))
+}
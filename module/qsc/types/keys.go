@@ -0,0 +1,50 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	qtypes "github.com/QOSGroup/qos/types"
+)
+
+const (
+	QSCMapperName = "qsc"
+
+	//------query-------
+	QSCRoute      = "qsc"
+	QueryExtrate  = "extrate"
+	QueryExtrates = "extrates"
+	QueryParams   = "params"
+)
+
+// 汇率变更记录
+type ExtrateRecord struct {
+	QSCName string     `json:"qsc_name"` //币名
+	Height  uint64     `json:"height"`   //生效高度
+	Time    time.Time  `json:"time"`     //生效时间
+	Extrate qtypes.Dec `json:"extrate"`  //qsc:qos汇率
+}
+
+func NewExtrateRecord(qscName string, height uint64, time time.Time, extrate qtypes.Dec) ExtrateRecord {
+	return ExtrateRecord{
+		QSCName: qscName,
+		Height:  height,
+		Time:    time.UTC(),
+		Extrate: extrate,
+	}
+}
+
+// 查询height时生效的汇率, height为0时查询当前汇率
+func BuildQueryExtrateCustomQueryPath(qscName string, height uint64) string {
+	return fmt.Sprintf("custom/%s/%s/%s/%d", QSCRoute, QueryExtrate, qscName, height)
+}
+
+// 查询汇率变更记录
+func BuildQueryExtratesCustomQueryPath(qscName string) string {
+	return fmt.Sprintf("custom/%s/%s/%s", QSCRoute, QueryExtrates, qscName)
+}
+
+// 查询QSC参数
+func BuildQueryParamsCustomQueryPath() string {
+	return fmt.Sprintf("custom/%s/%s", QSCRoute, QueryParams)
+}
@@ -0,0 +1,27 @@
+package types
+
+import (
+	"errors"
+)
+
+type QSCParams struct {
+	CreateDeposit uint64 `json:"create_deposit"` // 无证书创建QSC需抵押的QOS, 退役QSC时退还
+}
+
+func NewQSCParams(createDeposit uint64) QSCParams {
+	return QSCParams{
+		CreateDeposit: createDeposit,
+	}
+}
+
+func DefaultQSCParams() QSCParams {
+	return NewQSCParams(100000)
+}
+
+func (params QSCParams) Validate() error {
+	if params.CreateDeposit == 0 {
+		return errors.New("create_deposit must be positive")
+	}
+
+	return nil
+}
@@ -3,14 +3,89 @@ package types
 import (
 	"github.com/QOSGroup/kepler/cert"
 	btypes "github.com/QOSGroup/qbase/types"
+	qtypes "github.com/QOSGroup/qos/types"
+	"time"
 )
 
 type QSCInfo struct {
-	Name        string         `json:"name"`        //币名
-	ChainId     string         `json:"chain_id"`    //证书可用链
-	Extrate     string         `json:"extrate"`     //qcs:qos汇率(amino不支持binary形式的浮点数序列化，精度同qos erc20 [.0000])
-	Description string         `json:"description"` //描述信息
-	Banker      btypes.Address `json:"banker"`      //Banker PubKey
+	Name              string         `json:"name"`                //币名
+	ChainId           string         `json:"chain_id"`            //证书可用链
+	Extrate           qtypes.Dec     `json:"extrate"`             //qsc:qos汇率
+	Description       string         `json:"description"`         //描述信息
+	Banker            btypes.Address `json:"banker"`              //Banker PubKey
+	TotalIssued       btypes.BigInt  `json:"total_issued"`        //发行总量，包括创建时初始分配
+	TotalBurned       btypes.BigInt  `json:"total_burned"`        //销毁总量
+	BankerUpdateTime  time.Time      `json:"banker_update_time"`  //banker最近变更时间，此前签发的证书不可再用于变更banker
+	IssueLimit        btypes.BigInt  `json:"issue_limit"`         //每周期发行上限，为空时不限制
+	IssuePeriod       uint64         `json:"issue_period"`        //周期时长，单位秒
+	IssuePeriodStart  time.Time      `json:"issue_period_start"`  //当前周期开始时间
+	IssuePeriodIssued btypes.BigInt  `json:"issue_period_issued"` //当前周期已发行数量
+	Compliance        bool           `json:"compliance"`          //是否启用冻结控制，banker可冻结账户持有的QSC
+	Creator           btypes.Address `json:"creator"`             //创建账户
+	Deposit           btypes.BigInt  `json:"deposit"`             //无证书创建时抵押的QOS，退役时退还creator
+	BankerCertHash    string         `json:"banker_cert_hash"`    //授权当前banker的证书hash，证书吊销后清除banker
+}
+
+// 流通量 = 发行总量 - 销毁总量
+func (info QSCInfo) CirculatingSupply() btypes.BigInt {
+	return info.TotalIssued.NilToZero().Sub(info.TotalBurned.NilToZero())
+}
+
+// 是否设置周期发行上限
+func (info QSCInfo) HasIssueLimit() bool {
+	return !info.IssueLimit.IsNil() && !info.IssueLimit.IsZero()
+}
+
+// blockTime是否仍在当前周期内
+func (info QSCInfo) inIssuePeriod(blockTime time.Time) bool {
+	if info.IssuePeriodStart.IsZero() {
+		return false
+	}
+	return blockTime.Before(info.IssuePeriodStart.Add(time.Duration(info.IssuePeriod) * time.Second))
+}
+
+// blockTime时当前周期剩余可发行数量，未设置周期发行上限时返回false
+func (info QSCInfo) IssueRemaining(blockTime time.Time) (btypes.BigInt, bool) {
+	if !info.HasIssueLimit() {
+		return btypes.ZeroInt(), false
+	}
+
+	issued := btypes.ZeroInt()
+	if info.inIssuePeriod(blockTime) {
+		issued = info.IssuePeriodIssued.NilToZero()
+	}
+	remaining := info.IssueLimit.Sub(issued)
+	if remaining.LT(btypes.ZeroInt()) {
+		return btypes.ZeroInt(), true
+	}
+
+	return remaining, true
+}
+
+// 记录blockTime时发行的数量，周期结束后自动开始新周期
+func (info *QSCInfo) IssueInPeriod(blockTime time.Time, amount btypes.BigInt) {
+	if !info.HasIssueLimit() {
+		return
+	}
+
+	if !info.inIssuePeriod(blockTime) {
+		info.IssuePeriodStart = blockTime.UTC()
+		info.IssuePeriodIssued = btypes.ZeroInt()
+	}
+	info.IssuePeriodIssued = info.IssuePeriodIssued.NilToZero().Add(amount.NilToZero())
+}
+
+// 联盟币查询结果
+type QSCQueryResult struct {
+	QSCInfo           QSCInfo       `json:"qsc_info"`
+	CirculatingSupply btypes.BigInt `json:"circulating_supply"` //流通量
+}
+
+func NewQSCQueryResult(info QSCInfo) QSCQueryResult {
+	return QSCQueryResult{
+		QSCInfo:           info,
+		CirculatingSupply: info.CirculatingSupply(),
+	}
 }
 
 func NewQSCInfoWithQSCCA(cer *cert.Certificate) QSCInfo {
@@ -1,6 +1,8 @@
 package stake
 
 import (
+	"time"
+
 	"github.com/QOSGroup/qbase/context"
 	"github.com/QOSGroup/qbase/store"
 	btypes "github.com/QOSGroup/qbase/types"
@@ -8,9 +10,11 @@ import (
 	ecomapper "github.com/QOSGroup/qos/module/eco/mapper"
 	ecotypes "github.com/QOSGroup/qos/module/eco/types"
 	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
 )
 
 //1. 统计validator投票信息, 将不活跃的validator转成Inactive状态
+//2. 处理双签证据, 惩罚作恶的validator
 func BeginBlocker(ctx context.Context, req abci.RequestBeginBlock) {
 
 	validatorMapper := ecomapper.GetValidatorMapper(ctx)
@@ -19,10 +23,19 @@ func BeginBlocker(ctx context.Context, req abci.RequestBeginBlock) {
 	minVotingCounter := uint64(validatorMapper.GetParams().ValidatorVotingStatusLeast)
 
 	for _, signingValidator := range req.LastCommitInfo.Votes {
-		valAddr := btypes.Address(signingValidator.Validator.Address)
+		valAddr := validatorMapper.GetLatestValidatorAddress(btypes.Address(signingValidator.Validator.Address))
 		voted := signingValidator.SignedLastBlock
 		handleValidatorValidatorVoteInfo(ctx, valAddr, voted, votingWindowLen, minVotingCounter)
 	}
+
+	for _, evidence := range req.ByzantineValidators {
+		switch evidence.Type {
+		case tmtypes.ABCIEvidenceTypeDuplicateVote:
+			handleDoubleSign(ctx, evidence)
+		default:
+			ctx.Logger().Error("ignored unknown evidence type", "type", evidence.Type)
+		}
+	}
 }
 
 //1. 将所有Inactive到一定期限的validator删除
@@ -58,12 +71,13 @@ func ReturnAllUnbondTokens(ctx context.Context) {
 			_, deleAddr := ecotypes.GetUnbondingDelegationHeightAddress(k)
 			returnQOSAmount := amount
 
-			eco.IncrAccountQOS(ctx, deleAddr, btypes.NewInt(int64(returnQOSAmount)))
+			eco.ReturnUnbondQOS(ctx, deleAddr, btypes.NewInt(int64(returnQOSAmount)))
 		}
+		e.DelegationMapper.RemoveValidatorUnbondingQOSatHeight(h)
 	}
 }
 
-//unbond的token返还至delegator账户中
+//unbond的token返还至delegator收益提取地址中, 锁仓账户返还至账户本身
 func EndBlockerByReturnUnbondTokens(ctx context.Context) {
 	height := uint64(ctx.BlockHeight())
 	e := eco.GetEco(ctx)
@@ -82,8 +96,10 @@ func EndBlockerByReturnUnbondTokens(ctx context.Context) {
 		_, deleAddr := ecotypes.GetUnbondingDelegationHeightAddress(k)
 		returnQOSAmount := amount
 
-		eco.IncrAccountQOS(ctx, deleAddr, btypes.NewInt(int64(returnQOSAmount)))
+		eco.ReturnUnbondQOS(ctx, deleAddr, btypes.NewInt(int64(returnQOSAmount)))
 	}
+
+	e.DelegationMapper.RemoveValidatorUnbondingQOSatHeight(height)
 }
 
 func CloseExpireInactiveValidator(ctx context.Context, survivalSecs uint32) {
@@ -220,11 +236,19 @@ func handleValidatorValidatorVoteInfo(ctx context.Context, valAddr btypes.Addres
 	if voteInfo.MissedBlocksCounter > maxMissedCounter {
 		log.Info("validator gets inactive", "height", height, "validator", valAddr.String(), "missed counter", voteInfo.MissedBlocksCounter)
 
+		params := validatorMapper.GetParams()
+		slashed := slashValidator(ctx, validator, height, params.SlashFractionDowntime)
+		log.Info("slash downtime validator", "height", height, "validator", valAddr.String(), "slashed", slashed)
+
+		validator, _ = validatorMapper.GetValidator(valAddr)
 		blockValidator(ctx, validator, ecotypes.MissVoteBlock)
+		jailValidator(ctx, valAddr, ctx.BlockHeader().Time.Add(time.Duration(params.ValidatorJailSecs)*time.Second))
 
-		// voteInfo.IndexOffset = 0
-		// voteInfo.MissedBlocksCounter = 0
-		// voteInfoMapper.ClearValidatorVoteInfoInWindow(valAddr)
+		//重置投票窗口
+		voteInfo.StartHeight = height + 1
+		voteInfo.IndexOffset = 0
+		voteInfo.MissedBlocksCounter = 0
+		voteInfoMapper.ClearValidatorVoteInfoInWindow(valAddr)
 	}
 
 	voteInfoMapper.SetValidatorVoteInfo(valAddr, voteInfo)
@@ -237,6 +261,5 @@ func blockValidator(ctx context.Context, validator ecotypes.Validator, code ecot
 	validatorMapper.MakeValidatorInactive(valAddr, uint64(ctx.BlockHeight()), ctx.BlockHeader().Time, code)
 
 	//更新validator对应的delegator的token数量
-	distributionMapper := ecomapper.GetDistributionMapper(ctx)
-	distributionMapper.ModifyDelegatorTokens(validator, validator.Owner, uint64(0), uint64(ctx.BlockHeight()))
+	eco.GetEco(ctx).ModifyDelegatorTokens(validator, validator.Owner, uint64(0), uint64(ctx.BlockHeight()))
 }
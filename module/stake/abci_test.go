@@ -14,11 +14,14 @@ import (
 	btypes "github.com/QOSGroup/qbase/types"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 
 	stakemapper "github.com/QOSGroup/qos/module/eco/mapper"
 	staketypes "github.com/QOSGroup/qos/module/eco/types"
+	"github.com/QOSGroup/qos/module/supply"
+	supplytypes "github.com/QOSGroup/qos/module/supply/types"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 )
 
@@ -132,6 +135,192 @@ func TestVoteInfoMapper(t *testing.T) {
 
 }
 
+func TestHandleDoubleSign(t *testing.T) {
+
+	ctx := defaultContext().WithBlockHeight(100)
+
+	validatorMapper := stakemapper.GetValidatorMapper(ctx)
+	delegationMapper := stakemapper.GetDelegationMapper(ctx)
+	distributionMapper := stakemapper.GetDistributionMapper(ctx)
+
+	params := staketypes.DefaultStakeParams()
+	params.SlashFractionDoubleSign = types.NewFraction(int64(1), int64(10))
+	validatorMapper.SetParams(params)
+
+	owner := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	delegator := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	validator := staketypes.Validator{
+		Name:            "test",
+		Owner:           owner,
+		ValidatorPubKey: ed25519.GenPrivKey().PubKey(),
+		BondTokens:      1000,
+		Status:          staketypes.Active,
+		BondHeight:      1,
+	}
+	valAddr := validator.GetValidatorAddress()
+
+	validatorMapper.CreateValidator(validator)
+	distributionMapper.InitValidatorPeriodSummaryInfo(valAddr)
+	delegationMapper.SetDelegationInfo(staketypes.NewDelegationInfo(owner, valAddr, 600, false))
+	distributionMapper.InitDelegatorIncomeInfo(valAddr, owner, 600, 1)
+	delegationMapper.SetDelegationInfo(staketypes.NewDelegationInfo(delegator, valAddr, 400, false))
+	distributionMapper.InitDelegatorIncomeInfo(valAddr, delegator, 400, 1)
+
+	//infraction之后的unbond会被惩罚, 之前的不受影响
+	delegationMapper.AddDelegatorUnbondingQOSatHeight(110, delegator, 100)
+	delegationMapper.AddValidatorUnbondingQOSatHeight(110, valAddr, delegator, 99, 100)
+	delegationMapper.AddDelegatorUnbondingQOSatHeight(101, delegator, 100)
+	delegationMapper.AddValidatorUnbondingQOSatHeight(101, valAddr, delegator, 91, 100)
+
+	supplyMapper := supply.GetSupplyMapper(ctx)
+	supplyMapper.SetLockedQOS(supplytypes.Bonded, btypes.NewInt(1000))
+	supplyMapper.SetLockedQOS(supplytypes.Unbonding, btypes.NewInt(200))
+
+	BeginBlocker(ctx, abci.RequestBeginBlock{
+		ByzantineValidators: []abci.Evidence{
+			{
+				Type:      tmtypes.ABCIEvidenceTypeDuplicateVote,
+				Validator: abci.Validator{Address: valAddr},
+				Height:    98,
+			},
+		},
+	})
+
+	v, exsits := validatorMapper.GetValidator(valAddr)
+	require.True(t, exsits)
+	require.True(t, v.IsTombstoned())
+	require.Equal(t, uint64(900), v.BondTokens)
+
+	info, _ := delegationMapper.GetDelegationInfo(owner, valAddr)
+	require.Equal(t, uint64(540), info.Amount)
+	info, _ = delegationMapper.GetDelegationInfo(delegator, valAddr)
+	require.Equal(t, uint64(360), info.Amount)
+
+	amount, _ := delegationMapper.GetDelegatorUnbondingQOSatHeight(110, delegator)
+	require.Equal(t, uint64(90), amount)
+	amount, _ = delegationMapper.GetDelegatorUnbondingQOSatHeight(101, delegator)
+	require.Equal(t, uint64(100), amount)
+
+	require.Equal(t, btypes.NewInt(110), distributionMapper.GetCommunityFeePool())
+	require.Equal(t, btypes.NewInt(900), supplyMapper.GetLockedQOS(supplytypes.Bonded))
+	require.Equal(t, btypes.NewInt(190), supplyMapper.GetLockedQOS(supplytypes.Unbonding))
+	require.Equal(t, btypes.NewInt(110), supplyMapper.GetLockedQOS(supplytypes.CommunityPool))
+
+	_, err := validateValidator(ctx, owner, true, staketypes.Inactive, true)
+	require.NotNil(t, err)
+}
+
+func TestHandleValidatorDowntime(t *testing.T) {
+
+	blockTime := time.Now().UTC()
+	ctx := defaultContext().WithBlockHeight(100).WithBlockHeader(abci.Header{Height: 100, Time: blockTime})
+
+	validatorMapper := stakemapper.GetValidatorMapper(ctx)
+	voteInfoMapper := stakemapper.GetVoteInfoMapper(ctx)
+	delegationMapper := stakemapper.GetDelegationMapper(ctx)
+	distributionMapper := stakemapper.GetDistributionMapper(ctx)
+
+	params := staketypes.DefaultStakeParams()
+	params.SlashFractionDowntime = types.NewFraction(int64(1), int64(10))
+	params.ValidatorJailSecs = 300
+	validatorMapper.SetParams(params)
+
+	owner := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	validator := staketypes.Validator{
+		Name:            "test",
+		Owner:           owner,
+		ValidatorPubKey: ed25519.GenPrivKey().PubKey(),
+		BondTokens:      1000,
+		Status:          staketypes.Active,
+		BondHeight:      1,
+	}
+	valAddr := validator.GetValidatorAddress()
+
+	validatorMapper.CreateValidator(validator)
+	distributionMapper.InitValidatorPeriodSummaryInfo(valAddr)
+	delegationMapper.SetDelegationInfo(staketypes.NewDelegationInfo(owner, valAddr, 1000, false))
+	distributionMapper.InitDelegatorIncomeInfo(valAddr, owner, 1000, 1)
+
+	for i := 0; i < 6; i++ {
+		handleValidatorValidatorVoteInfo(ctx, valAddr, false, 10, 5)
+	}
+
+	v, _ := validatorMapper.GetValidator(valAddr)
+	require.False(t, v.IsActive())
+	require.Equal(t, staketypes.MissVoteBlock, v.InactiveCode)
+	require.Equal(t, uint64(900), v.BondTokens)
+	require.True(t, v.IsJailed(blockTime))
+	require.Equal(t, blockTime.Add(300*time.Second).Unix(), v.JailedUntil.Unix())
+
+	voteInfo, _ := voteInfoMapper.GetValidatorVoteInfo(valAddr)
+	require.Equal(t, uint64(0), voteInfo.MissedBlocksCounter)
+	require.Equal(t, uint64(0), voteInfo.IndexOffset)
+
+	require.Equal(t, btypes.NewInt(100), distributionMapper.GetCommunityFeePool())
+
+	_, err := validateValidator(ctx, owner, true, staketypes.Inactive, true)
+	require.NotNil(t, err)
+
+	ctx = ctx.WithBlockHeader(abci.Header{Height: 200, Time: blockTime.Add(301 * time.Second)})
+	_, err = validateValidator(ctx, owner, true, staketypes.Inactive, true)
+	require.Nil(t, err)
+}
+
+func TestVestingAccountDelegation(t *testing.T) {
+	endTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := defaultContext().WithBlockHeight(10).WithBlockHeader(abci.Header{Height: 10, Time: endTime.Add(-time.Hour)})
+
+	validatorMapper := stakemapper.GetValidatorMapper(ctx)
+	distributionMapper := stakemapper.GetDistributionMapper(ctx)
+	params := staketypes.DefaultStakeParams()
+	validatorMapper.SetParams(params)
+	distributionMapper.SetParams(staketypes.DefaultDistributionParams())
+
+	owner := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	validator := staketypes.Validator{
+		Name:            "test",
+		Owner:           owner,
+		ValidatorPubKey: ed25519.GenPrivKey().PubKey(),
+		BondTokens:      1000,
+		Status:          staketypes.Active,
+		BondHeight:      1,
+	}
+	validatorMapper.CreateValidator(validator)
+	distributionMapper.InitValidatorPeriodSummaryInfo(validator.GetValidatorAddress())
+
+	delegator := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	withdrawAddr := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	accountMapper := ctx.Mapper(account.AccountMapperName).(*account.AccountMapper)
+	accountMapper.SetAccount(types.NewDelayedVestingAccount(types.NewQOSAccount(delegator, btypes.NewInt(1000), nil), btypes.NewInt(1000), endTime))
+	accountMapper.SetAccount(accountMapper.NewAccountWithAddress(withdrawAddr))
+	distributionMapper.SetDelegatorWithdrawAddress(delegator, withdrawAddr)
+
+	//锁仓中的QOS可用于委托
+	tx := &TxCreateDelegation{Delegator: delegator, ValidatorOwner: owner, Amount: 800}
+	require.Nil(t, tx.ValidateData(ctx))
+	result, _ := tx.Exec(ctx)
+	require.True(t, result.IsOK())
+
+	acc := accountMapper.GetAccount(delegator).(*types.DelayedVestingAccount)
+	require.Equal(t, int64(200), acc.GetQOS().Int64())
+	require.Equal(t, int64(800), acc.DelegatedVesting.Int64())
+
+	//解除委托的QOS返还锁仓账户本身
+	unbondTx := &TxUnbondDelegation{Delegator: delegator, ValidatorOwner: owner, UnbondAmount: 300}
+	require.Nil(t, unbondTx.ValidateData(ctx))
+	result, _ = unbondTx.Exec(ctx)
+	require.True(t, result.IsOK())
+
+	ctx = ctx.WithBlockHeight(10 + int64(params.DelegatorUnbondReturnHeight))
+	EndBlockerByReturnUnbondTokens(ctx)
+
+	acc = accountMapper.GetAccount(delegator).(*types.DelayedVestingAccount)
+	require.Equal(t, int64(500), acc.GetQOS().Int64())
+	require.Equal(t, int64(500), acc.DelegatedVesting.Int64())
+	require.Equal(t, int64(0), acc.SpendableQOS(endTime.Add(-time.Hour)).Int64())
+	require.Equal(t, int64(0), accountMapper.GetAccount(withdrawAddr).(*types.QOSAccount).GetQOS().Int64())
+}
+
 func defaultContext() context.Context {
 
 	mapperMap := make(map[string]mapper.IMapper)
@@ -150,6 +339,18 @@ func defaultContext() context.Context {
 	signInfoMapper.SetCodec(cdc)
 	mapperMap[staketypes.VoteInfoMapperName] = signInfoMapper
 
+	delegationMapper := stakemapper.NewDelegationMapper()
+	delegationMapper.SetCodec(cdc)
+	mapperMap[staketypes.DelegationMapperName] = delegationMapper
+
+	distributionMapper := stakemapper.NewDistributionMapper()
+	distributionMapper.SetCodec(cdc)
+	mapperMap[staketypes.DistributionMapperName] = distributionMapper
+
+	supplyMapper := supply.NewSupplyMapper()
+	supplyMapper.SetCodec(cdc)
+	mapperMap[supplytypes.SupplyMapperName] = supplyMapper
+
 	db := dbm.NewMemDB()
 	cms := store.NewCommitMultiStore(db)
 
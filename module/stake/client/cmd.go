@@ -11,6 +11,8 @@ func TxValidatorCommands(cdc *amino.Codec) []*cobra.Command {
 		CreateValidatorCmd(cdc),
 		RevokeValidatorCmd(cdc),
 		ActiveValidatorCmd(cdc),
+		ModifyValidatorCmd(cdc),
+		RotateValidatorKeyCmd(cdc),
 	)
 }
 
@@ -3,8 +3,8 @@ package staking
 import (
 	qcliacc "github.com/QOSGroup/qbase/client/account"
 	"github.com/QOSGroup/qbase/client/context"
-	qclitx "github.com/QOSGroup/qbase/client/tx"
 	"github.com/QOSGroup/qbase/txs"
+	distrcli "github.com/QOSGroup/qos/module/distribution/client"
 	"github.com/QOSGroup/qos/module/stake"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -24,7 +24,7 @@ func CreateDelegationCommand(cdc *amino.Codec) *cobra.Command {
 		Use:   "delegate",
 		Short: "delegate QOS to a validator",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return qclitx.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
 
 				tokens := viper.GetInt64(flagBondTokens)
 				if tokens <= 0 {
@@ -68,7 +68,7 @@ func CreateModifyCompoundCommand(cdc *amino.Codec) *cobra.Command {
 		Use:   "modify-compound",
 		Short: "modify compound info in a delegation",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return qclitx.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
 
 				owner, err := qcliacc.GetAddrFromFlag(ctx, flagOwner)
 				if err != nil {
@@ -104,7 +104,7 @@ func CreateUnbondDelegationCommand(cdc *amino.Codec) *cobra.Command {
 		Use:   "unbond",
 		Short: "unbond QOS from a validator",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return qclitx.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
 
 				tokens := viper.GetInt64(flagBondTokens)
 				isUnbondAll := viper.GetBool(flagAll)
@@ -148,7 +148,7 @@ func CreateReDelegationCommand(cdc *amino.Codec) *cobra.Command {
 		Use:   "redelegate",
 		Short: "redelegate QOS from a validator to another",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return qclitx.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
 
 				tokens := viper.GetInt64(flagBondTokens)
 				all := viper.GetBool(flagAll)
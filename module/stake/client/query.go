@@ -29,6 +29,7 @@ const (
 	inactiveRevokeDesc        = "Revoked"
 	inactiveMissVoteBlockDesc = "Kicked"
 	inactiveMaxValidatorDesc  = "Replaced"
+	inactiveDoubleSignDesc    = "Tombstoned"
 )
 
 type validatorDisplayInfo struct {
@@ -38,25 +39,37 @@ type validatorDisplayInfo struct {
 	ValidatorPubKey crypto.PubKey  `json:"validatorPubkey"`
 	BondTokens      uint64         `json:"bondTokens"` //不能超过int64最大值
 	Description     string         `json:"description"`
+	Website         string         `json:"website"`
+	Identity        string         `json:"identity"`
+	SecurityContact string         `json:"securityContact"`
 
 	Status         string    `json:"status"`
 	InactiveDesc   string    `json:"InactiveDesc"`
 	InactiveTime   time.Time `json:"inactiveTime"`
 	InactiveHeight uint64    `json:"inactiveHeight"`
+	JailedUntil    time.Time `json:"jailedUntil"`
+	JailRemaining  string    `json:"jailRemaining"`
 
-	BondHeight uint64 `json:"bondHeight"`
+	BondHeight uint64              `json:"bondHeight"`
+	Commission ecotypes.Commission `json:"commission"`
 }
 
-func toValidatorDisplayInfo(validator ecotypes.Validator) validatorDisplayInfo {
+// blockTime为最新区块时间, 与链上一致按区块时间计算是否处于jail期
+func toValidatorDisplayInfo(validator ecotypes.Validator, blockTime time.Time) validatorDisplayInfo {
 	info := validatorDisplayInfo{
 		Name:            validator.Name,
 		Owner:           validator.Owner,
 		ValidatorPubKey: validator.ValidatorPubKey,
 		BondTokens:      validator.BondTokens,
 		Description:     validator.Description,
+		Website:         validator.Website,
+		Identity:        validator.Identity,
+		SecurityContact: validator.SecurityContact,
 		InactiveTime:    validator.InactiveTime,
 		InactiveHeight:  validator.InactiveHeight,
+		JailedUntil:     validator.JailedUntil,
 		BondHeight:      validator.BondHeight,
+		Commission:      validator.Commission,
 	}
 
 	if validator.Status == ecotypes.Active {
@@ -71,6 +84,12 @@ func toValidatorDisplayInfo(validator ecotypes.Validator) validatorDisplayInfo {
 		info.InactiveDesc = inactiveMissVoteBlockDesc
 	} else if validator.InactiveCode == ecotypes.MaxValidator {
 		info.InactiveDesc = inactiveMaxValidatorDesc
+	} else if validator.InactiveCode == ecotypes.DoubleSign {
+		info.InactiveDesc = inactiveDoubleSignDesc
+	}
+
+	if validator.IsJailed(blockTime) {
+		info.JailRemaining = validator.JailedUntil.Sub(blockTime).Round(time.Second).String()
 	}
 
 	info.ValidatorAddr = strings.ToUpper(hex.EncodeToString(validator.ValidatorPubKey.Address()))
@@ -94,7 +113,12 @@ func queryValidatorInfoCommand(cdc *go_amino.Codec) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			return cliCtx.PrintResult(toValidatorDisplayInfo(validator))
+
+			blockTime, err := getLatestBlockTime(cliCtx)
+			if err != nil {
+				return err
+			}
+			return cliCtx.PrintResult(toValidatorDisplayInfo(validator, blockTime))
 		},
 	}
 
@@ -232,6 +256,11 @@ func queryAllValidatorsCommand(cdc *go_amino.Codec) *cobra.Command {
 				return errors.New("response empty value")
 			}
 
+			blockTime, err := getLatestBlockTime(cliCtx)
+			if err != nil {
+				return err
+			}
+
 			var validators []validatorDisplayInfo
 
 			var vKVPair []store.KVPair
@@ -239,7 +268,7 @@ func queryAllValidatorsCommand(cdc *go_amino.Codec) *cobra.Command {
 			for _, kv := range vKVPair {
 				var validator ecotypes.Validator
 				cdc.UnmarshalBinaryBare(kv.Value, &validator)
-				validators = append(validators, toValidatorDisplayInfo(validator))
+				validators = append(validators, toValidatorDisplayInfo(validator, blockTime))
 			}
 
 			cliCtx.PrintResult(validators)
@@ -352,6 +381,20 @@ func queryVotesInfoByOwner(ctx context.CLIContext, ownerAddress btypes.Address)
 	return voteSummaryDisplay, nil
 }
 
+func getLatestBlockTime(ctx context.CLIContext) (time.Time, error) {
+	node, err := ctx.GetNode()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	status, err := node.Status()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return status.SyncInfo.LatestBlockTime.UTC(), nil
+}
+
 func getStakeConfig(ctx context.CLIContext) (ecotypes.StakeParams, error) {
 	node, err := ctx.GetNode()
 	if err != nil {
@@ -3,8 +3,9 @@ package staking
 import (
 	qcliacc "github.com/QOSGroup/qbase/client/account"
 	"github.com/QOSGroup/qbase/client/context"
-	qclitx "github.com/QOSGroup/qbase/client/tx"
 	"github.com/QOSGroup/qbase/txs"
+	distrcli "github.com/QOSGroup/qos/module/distribution/client"
+	ecotypes "github.com/QOSGroup/qos/module/eco/types"
 	"github.com/QOSGroup/qos/module/stake"
 	"github.com/QOSGroup/qos/types"
 	"github.com/pkg/errors"
@@ -14,6 +15,7 @@ import (
 	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/privval"
 	"path/filepath"
+	"time"
 )
 
 const (
@@ -23,6 +25,14 @@ const (
 	flagDescription = "description"
 	flagCompound    = "compound"
 	flagNodeHome    = "nodeHome"
+
+	flagWebsite         = "website"
+	flagIdentity        = "identity"
+	flagSecurityContact = "security-contact"
+
+	flagCommissionRate          = "commission-rate"
+	flagCommissionMaxRate       = "commission-max-rate"
+	flagCommissionMaxChangeRate = "commission-max-change-rate"
 )
 
 func CreateValidatorCmd(cdc *amino.Codec) *cobra.Command {
@@ -35,10 +45,11 @@ owner is a keystore name or account address.
 example:
 
 	 qoscli tx create-validator --name validatorName --owner ownerName --tokens 100
+	 qoscli tx create-validator --name validatorName --owner ownerName --tokens 100 --commission-rate 0.05 --commission-max-rate 0.2 --commission-max-change-rate 0.01
 
 		`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return qclitx.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
 				name := viper.GetString(flagName)
 				if len(name) == 0 {
 					return nil, errors.New("name is empty")
@@ -56,8 +67,13 @@ example:
 					return nil, err
 				}
 
+				commission, err := getCommissionFromFlags()
+				if err != nil {
+					return nil, err
+				}
+
 				isCompound := viper.GetBool(flagCompound)
-				return stake.NewCreateValidatorTx(name, owner, privValidator.PubKey, tokens, isCompound, desc), nil
+				return stake.NewCreateValidatorTx(name, owner, privValidator.PubKey, tokens, isCompound, desc, commission), nil
 			})
 
 		},
@@ -69,6 +85,9 @@ example:
 	cmd.Flags().Bool(flagCompound, false, "as a self-delegator, whether the income is calculated as compound interest")
 	cmd.Flags().String(flagDescription, "", "description")
 	cmd.Flags().String(flagNodeHome, types.DefaultNodeHome, "path of node's config and data files, default: $HOME/.qosd")
+	cmd.Flags().String(flagCommissionRate, "0.01", "initial commission rate")
+	cmd.Flags().String(flagCommissionMaxRate, "0.2", "maximum commission rate, can not be changed after created")
+	cmd.Flags().String(flagCommissionMaxChangeRate, "0.01", "maximum commission rate change per day, can not be changed after created")
 
 	cmd.MarkFlagRequired(flagName)
 	cmd.MarkFlagRequired(flagOwner)
@@ -77,12 +96,124 @@ example:
 	return cmd
 }
 
+func getCommissionFromFlags() (commission ecotypes.Commission, err error) {
+	rate, err := parseFraction(viper.GetString(flagCommissionRate))
+	if err != nil {
+		return
+	}
+	maxRate, err := parseFraction(viper.GetString(flagCommissionMaxRate))
+	if err != nil {
+		return
+	}
+	maxChangeRate, err := parseFraction(viper.GetString(flagCommissionMaxChangeRate))
+	if err != nil {
+		return
+	}
+
+	commission = ecotypes.NewCommission(rate, maxRate, maxChangeRate, time.Time{})
+	return commission, commission.Validate()
+}
+
+func parseFraction(str string) (types.Fraction, error) {
+	dec, err := types.NewDecFromStr(str)
+	if err != nil {
+		return types.Fraction{}, err
+	}
+	return types.Fraction{Value: dec}, nil
+}
+
+func ModifyValidatorCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "modify-validator",
+		Short: "Modify validator's name, description, website, identity, security contact or commission rate",
+		Long: `
+only the specified fields will be modified.
+commission rate can be changed once every 24 hours, the change must not exceed max change rate and the new rate must not exceed max rate.
+
+example:
+
+	 qoscli tx modify-validator --owner ownerName --name newName --website "https://www.example.com"
+	 qoscli tx modify-validator --owner ownerName --commission-rate 0.02
+
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+				owner, err := qcliacc.GetAddrFromFlag(ctx, flagOwner)
+				if err != nil {
+					return nil, err
+				}
+
+				var rate *types.Fraction
+				if viper.GetString(flagCommissionRate) != "" {
+					r, err := parseFraction(viper.GetString(flagCommissionRate))
+					if err != nil {
+						return nil, err
+					}
+					rate = &r
+				}
+
+				return stake.NewModifyValidatorTx(owner, viper.GetString(flagName), viper.GetString(flagDescription),
+					viper.GetString(flagWebsite), viper.GetString(flagIdentity), viper.GetString(flagSecurityContact), rate), nil
+			})
+
+		},
+	}
+
+	cmd.Flags().String(flagOwner, "", "owner keystore name or address")
+	cmd.Flags().String(flagName, stake.DoNotModify, "new name")
+	cmd.Flags().String(flagDescription, stake.DoNotModify, "new description")
+	cmd.Flags().String(flagWebsite, stake.DoNotModify, "new website")
+	cmd.Flags().String(flagIdentity, stake.DoNotModify, "new identity, e.g. keybase id")
+	cmd.Flags().String(flagSecurityContact, stake.DoNotModify, "new security contact email")
+	cmd.Flags().String(flagCommissionRate, "", "new commission rate")
+
+	cmd.MarkFlagRequired(flagOwner)
+
+	return cmd
+}
+
+func RotateValidatorKeyCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate-validator-key",
+		Short: "Replace validator's consensus pubkey with the one in nodeHome's priv_validator.json",
+		Long: `
+all bonded tokens, delegations, vote info and rewards will be moved to the new pubkey.
+generate a new priv_validator.json first, and switch the node to the new key after the tx is committed.
+
+example:
+
+	 qoscli tx rotate-validator-key --owner ownerName --nodeHome "$HOME/.qosd_new"
+
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+				owner, err := qcliacc.GetAddrFromFlag(ctx, flagOwner)
+				if err != nil {
+					return nil, err
+				}
+
+				privValidator := privval.LoadOrGenFilePV(filepath.Join(viper.GetString(flagNodeHome), cfg.DefaultConfig().PrivValidatorFile()))
+
+				return stake.NewRotateValidatorKeyTx(owner, privValidator.PubKey), nil
+			})
+
+		},
+	}
+
+	cmd.Flags().String(flagOwner, "", "owner keystore name or address")
+	cmd.Flags().String(flagNodeHome, types.DefaultNodeHome, "path of the new priv_validator.json's config directory, default: $HOME/.qosd")
+
+	cmd.MarkFlagRequired(flagOwner)
+
+	return cmd
+}
+
 func RevokeValidatorCmd(cdc *amino.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "revoke-validator",
 		Short: "Revoke validator",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return qclitx.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
 				owner, err := qcliacc.GetAddrFromFlag(ctx, flagOwner)
 				if err != nil {
 					return nil, err
@@ -106,7 +237,7 @@ func ActiveValidatorCmd(cdc *amino.Codec) *cobra.Command {
 		Use:   "active-validator",
 		Short: "Active validator",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return qclitx.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
 				owner, err := qcliacc.GetAddrFromFlag(ctx, flagOwner)
 				if err != nil {
 					return nil, err
@@ -2,12 +2,14 @@ package stake
 
 import (
 	"github.com/QOSGroup/qbase/baseabci"
+	qtypes "github.com/QOSGroup/qos/types"
 	"github.com/tendermint/go-amino"
 )
 
 var cdc = baseabci.MakeQBaseCodec()
 
 func init() {
+	qtypes.RegisterCodec(cdc)
 	RegisterCodec(cdc)
 }
 
@@ -16,6 +18,8 @@ func RegisterCodec(cdc *amino.Codec) {
 	cdc.RegisterConcrete(&TxCreateValidator{}, "qos/txs/TxCreateValidator", nil)
 	cdc.RegisterConcrete(&TxRevokeValidator{}, "qos/txs/TxRevokeValidator", nil)
 	cdc.RegisterConcrete(&TxActiveValidator{}, "qos/txs/TxActiveValidator", nil)
+	cdc.RegisterConcrete(&TxModifyValidator{}, "qos/txs/TxModifyValidator", nil)
+	cdc.RegisterConcrete(&TxRotateValidatorKey{}, "qos/txs/TxRotateValidatorKey", nil)
 
 	//delegation相关
 	cdc.RegisterConcrete(&TxCreateDelegation{}, "qos/txs/TxCreateDelegation", nil)
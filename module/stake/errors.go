@@ -17,6 +17,9 @@ const (
 	CodeValidatorIsActive       btypes.CodeType = 507 // Validator处于激活状态
 	CodeValidatorIsInactive     btypes.CodeType = 508 // Validator处于非激活状态
 	CodeValidatorInactiveIncome btypes.CodeType = 509 // Validator处于非激活状态时收益非法
+	CodeValidatorTombstoned     btypes.CodeType = 510 // Validator因双签被永久禁用
+	CodeValidatorJailed         btypes.CodeType = 511 // Validator因漏签处于禁止激活期
+	CodeInvalidCommission       btypes.CodeType = 512 // 佣金设置有误
 )
 
 func msgOrDefaultMsg(msg string, code btypes.CodeType) string {
@@ -52,6 +55,12 @@ func codeToDefaultMsg(code btypes.CodeType) string {
 		return "validator is inactive"
 	case CodeValidatorInactiveIncome:
 		return "vaidator in inactive and got fees"
+	case CodeValidatorTombstoned:
+		return "validator is tombstoned"
+	case CodeValidatorJailed:
+		return "validator is jailed"
+	case CodeInvalidCommission:
+		return "invalid commission"
 	default:
 		return btypes.CodeToDefaultMsg(code)
 	}
@@ -92,3 +101,15 @@ func ErrValidatorIsInactive(codeSpace btypes.CodespaceType, msg string) btypes.E
 func ErrCodeValidatorInactiveIncome(codeSpace btypes.CodespaceType, msg string) btypes.Error {
 	return newError(codeSpace, CodeValidatorInactiveIncome, msg)
 }
+
+func ErrValidatorTombstoned(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeValidatorTombstoned, msg)
+}
+
+func ErrValidatorJailed(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeValidatorJailed, msg)
+}
+
+func ErrInvalidCommission(codeSpace btypes.CodespaceType, msg string) btypes.Error {
+	return newError(codeSpace, CodeInvalidCommission, msg)
+}
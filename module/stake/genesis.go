@@ -2,11 +2,14 @@ package stake
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/QOSGroup/qbase/context"
 	btypes "github.com/QOSGroup/qbase/types"
 	"github.com/QOSGroup/qos/module/eco/mapper"
 	ecotypes "github.com/QOSGroup/qos/module/eco/types"
+	"github.com/QOSGroup/qos/module/supply"
+	supplytypes "github.com/QOSGroup/qos/module/supply/types"
 	"github.com/QOSGroup/qos/types"
 	"github.com/tendermint/tendermint/crypto"
 )
@@ -18,6 +21,7 @@ type GenesisState struct {
 	ValidatorsVoteInWindow []ValidatorVoteInWindowInfoState `json:"val_votes_in_window"`   //validatorVoteInfoInWindowKey
 	DelegatorsInfo         []DelegationInfoState            `json:"delegators_info"`       //DelegationByDelValKey, DelegationByValDelKey
 	DelegatorsUnbondInfo   []DelegatorUnbondState           `json:"delegator_unbond_info"` //DelegatorUnbondingQOSatHeightKey
+	ValidatorsUnbondInfo   []ValidatorUnbondState           `json:"validator_unbond_info"` //ValidatorUnbondingQOSatHeightKey
 	CurrentValidators      []ecotypes.Validator             `json:"current_validators"`    // currentValidatorsAddressKey
 }
 
@@ -27,6 +31,7 @@ func NewGenesisState(params ecotypes.StakeParams,
 	validatorsVoteInWindow []ValidatorVoteInWindowInfoState,
 	delegatorsInfo []DelegationInfoState,
 	delegatorsUnbondInfo []DelegatorUnbondState,
+	validatorsUnbondInfo []ValidatorUnbondState,
 	currentValidators []ecotypes.Validator) GenesisState {
 	return GenesisState{
 		Params:                 params,
@@ -35,6 +40,7 @@ func NewGenesisState(params ecotypes.StakeParams,
 		ValidatorsVoteInWindow: validatorsVoteInWindow,
 		DelegatorsInfo:         delegatorsInfo,
 		DelegatorsUnbondInfo:   delegatorsUnbondInfo,
+		ValidatorsUnbondInfo:   validatorsUnbondInfo,
 		CurrentValidators:      currentValidators,
 	}
 }
@@ -55,7 +61,25 @@ func InitGenesis(ctx context.Context, data GenesisState) {
 	initValidators(ctx, data.Validators)
 	initParams(ctx, data.Params)
 	initValidatorsVotesInfo(ctx, data.ValidatorsVoteInfo, data.ValidatorsVoteInWindow)
-	initDelegatorsInfo(ctx, data.DelegatorsInfo, data.DelegatorsUnbondInfo)
+	initDelegatorsInfo(ctx, data.DelegatorsInfo, data.DelegatorsUnbondInfo, data.ValidatorsUnbondInfo)
+	initLockedQOS(ctx, data.Validators, data.DelegatorsUnbondInfo)
+}
+
+//绑定及解除绑定待返还的QOS
+func initLockedQOS(ctx context.Context, validators []ecotypes.Validator, delegatorsUnbondInfo []DelegatorUnbondState) {
+	bonded := uint64(0)
+	for _, v := range validators {
+		bonded += v.BondTokens
+	}
+
+	unbonding := uint64(0)
+	for _, info := range delegatorsUnbondInfo {
+		unbonding += info.Amount
+	}
+
+	supplyMapper := supply.GetSupplyMapper(ctx)
+	supplyMapper.SetLockedQOS(supplytypes.Bonded, btypes.NewInt(int64(bonded)))
+	supplyMapper.SetLockedQOS(supplytypes.Unbonding, btypes.NewInt(int64(unbonding)))
 }
 
 func initValidators(ctx context.Context, validators []ecotypes.Validator) {
@@ -68,6 +92,10 @@ func initValidators(ctx context.Context, validators []ecotypes.Validator) {
 		if validatorMapper.ExistsWithOwner(v.Owner) {
 			panic(fmt.Errorf("owner %s already bind a validator", v.Owner))
 		}
+		//未设置佣金的validator使用默认佣金设置
+		if v.Commission.IsNil() {
+			v.Commission = ecotypes.DefaultCommission(time.Time{})
+		}
 		validatorMapper.CreateValidator(v)
 		if !v.IsActive() {
 			validatorMapper.MakeValidatorInactive(v.GetValidatorAddress(), v.InactiveHeight, v.InactiveTime, v.InactiveCode)
@@ -86,7 +114,7 @@ func initValidatorsVotesInfo(ctx context.Context, voteInfos []ValidatorVoteInfoS
 	}
 }
 
-func initDelegatorsInfo(ctx context.Context, delegatorsInfo []DelegationInfoState, delegatorsUnbondInfo []DelegatorUnbondState) {
+func initDelegatorsInfo(ctx context.Context, delegatorsInfo []DelegationInfoState, delegatorsUnbondInfo []DelegatorUnbondState, validatorsUnbondInfo []ValidatorUnbondState) {
 	delegationMapper := mapper.GetDelegationMapper(ctx)
 
 	for _, info := range delegatorsInfo {
@@ -101,6 +129,11 @@ func initDelegatorsInfo(ctx context.Context, delegatorsInfo []DelegationInfoStat
 	for _, info := range delegatorsUnbondInfo {
 		delegationMapper.SetDelegatorUnbondingQOSatHeight(info.Height, info.DeleAddress, info.Amount)
 	}
+
+	for _, info := range validatorsUnbondInfo {
+		delegationMapper.SetValidatorUnbondingQOSatHeight(info.Height, btypes.Address(info.ValidatorPubKey.Address()), info.DeleAddress,
+			ecotypes.NewUnbondingDelegationInfo(info.CreateHeight, info.Amount))
+	}
 }
 
 func initParams(ctx context.Context, params ecotypes.StakeParams) {
@@ -130,6 +163,12 @@ func validateValidators(genesisAccounts []*types.QOSAccount, validators []ecotyp
 		}
 		addrMap[strKey] = true
 
+		if !val.Commission.IsNil() {
+			if err := val.Commission.Validate(); err != nil {
+				return fmt.Errorf("invalid commission of %s: %s", val.Name, err.Error())
+			}
+		}
+
 		var ownerExists bool
 		for _, acc := range genesisAccounts {
 			if acc.AccountAddress.EqualsTo(val.Owner) {
@@ -216,6 +255,22 @@ func ExportGenesis(ctx context.Context, forZeroHeight bool) GenesisState {
 		})
 	})
 
+	var validatorsUnbondInfo []ValidatorUnbondState
+	delegationMapper.IterateValidatorUnbondingAfterHeight(uint64(0), func(height uint64, valAddr, deleAddr btypes.Address, info ecotypes.UnbondingDelegationInfo) {
+
+		//validator已删除时无需保留
+		validator, exsits := validatorMapper.GetValidator(valAddr)
+		if exsits {
+			validatorsUnbondInfo = append(validatorsUnbondInfo, ValidatorUnbondState{
+				ValidatorPubKey: validator.ValidatorPubKey,
+				DeleAddress:     deleAddr,
+				Height:          height,
+				CreateHeight:    info.CreateHeight,
+				Amount:          info.Amount,
+			})
+		}
+	})
+
 	return GenesisState{
 		Params:                 params,
 		Validators:             validators,
@@ -223,6 +278,7 @@ func ExportGenesis(ctx context.Context, forZeroHeight bool) GenesisState {
 		ValidatorsVoteInWindow: validatorsVoteInWindow,
 		DelegatorsInfo:         delegatorsInfo,
 		DelegatorsUnbondInfo:   delegatorsUnbondInfo,
+		ValidatorsUnbondInfo:   validatorsUnbondInfo,
 		CurrentValidators:      currentValidators,
 	}
 }
@@ -250,3 +306,11 @@ type DelegatorUnbondState struct {
 	Height      uint64         `json:"height"`
 	Amount      uint64         `json:"tokens"`
 }
+
+type ValidatorUnbondState struct {
+	ValidatorPubKey crypto.PubKey  `json:"validator_pub_key"`
+	DeleAddress     btypes.Address `json:"delegator_address"`
+	Height          uint64         `json:"height"`
+	CreateHeight    uint64         `json:"create_height"`
+	Amount          uint64         `json:"tokens"`
+}
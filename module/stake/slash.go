@@ -0,0 +1,165 @@
+package stake
+
+import (
+	"time"
+
+	"github.com/QOSGroup/qbase/context"
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/module/eco"
+	ecomapper "github.com/QOSGroup/qos/module/eco/mapper"
+	ecotypes "github.com/QOSGroup/qos/module/eco/types"
+	"github.com/QOSGroup/qos/module/supply"
+	supplytypes "github.com/QOSGroup/qos/module/supply/types"
+	qtypes "github.com/QOSGroup/qos/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+//处理双签证据:
+//1. 按SlashFractionDoubleSign惩罚validator及其delegator绑定的QOS, 包含infraction高度之后发生的unbond
+//2. validator置为Inactive, InactiveCode为DoubleSign, 不可再激活
+//3. 惩罚的QOS进入社区奖励池
+func handleDoubleSign(ctx context.Context, evidence abci.Evidence) {
+	log := ctx.Logger()
+	e := eco.GetEco(ctx)
+
+	valAddr := e.ValidatorMapper.GetLatestValidatorAddress(btypes.Address(evidence.Validator.Address))
+	validator, exsits := e.ValidatorMapper.GetValidator(valAddr)
+	if !exsits {
+		log.Info("double sign", "validator", valAddr.String(), "not exsits,may be closed")
+		return
+	}
+
+	if validator.IsTombstoned() {
+		log.Info("double sign", "validator", valAddr.String(), "already tombstoned")
+		return
+	}
+
+	fraction := e.ValidatorMapper.GetParams().SlashFractionDoubleSign
+	slashed := slashValidator(ctx, validator, uint64(evidence.Height), fraction)
+
+	tombstoneValidator(ctx, valAddr)
+
+	log.Info("slash double sign validator", "height", ctx.BlockHeight(), "validator", valAddr.String(),
+		"infraction height", evidence.Height, "slashed", slashed)
+}
+
+//按比例惩罚validator绑定的QOS, 惩罚数量转入社区奖励池. 返回惩罚总量
+func slashValidator(ctx context.Context, validator ecotypes.Validator, infractionHeight uint64, fraction qtypes.Fraction) uint64 {
+	if fraction.Value.IsNil() || fraction.Value.IsZero() {
+		return 0
+	}
+
+	e := eco.GetEco(ctx)
+	height := uint64(ctx.BlockHeight())
+	valAddr := validator.GetValidatorAddress()
+
+	//1. 惩罚delegations
+	var deleAddrs []btypes.Address
+	e.DelegationMapper.IterateDelegationsValDeleAddr(valAddr, func(_ btypes.Address, deleAddr btypes.Address) {
+		deleAddrs = append(deleAddrs, deleAddr)
+	})
+
+	slashedBond := uint64(0)
+	for _, deleAddr := range deleAddrs {
+		info, exsits := e.DelegationMapper.GetDelegationInfo(deleAddr, valAddr)
+		if !exsits || info.Amount == 0 {
+			continue
+		}
+
+		amount := fraction.MultiInt64(int64(info.Amount)).Int64()
+		if amount <= 0 {
+			continue
+		}
+
+		info.Amount = info.Amount - uint64(amount)
+		e.DelegationMapper.SetDelegationInfo(info)
+		e.ModifyDelegatorTokens(validator, deleAddr, info.Amount, height)
+
+		slashedBond += uint64(amount)
+	}
+
+	//2. 惩罚infraction高度及之后发生的unbond
+	slashedUnbonding := uint64(0)
+	e.DelegationMapper.IterateValidatorUnbondingAfterHeight(height, func(unbondHeight uint64, addr, deleAddr btypes.Address, info ecotypes.UnbondingDelegationInfo) {
+		if !addr.EqualsTo(valAddr) || info.CreateHeight < infractionHeight {
+			return
+		}
+
+		amount := uint64(fraction.MultiInt64(int64(info.Amount)).Int64())
+		if amount == 0 {
+			return
+		}
+
+		totalAmount, _ := e.DelegationMapper.GetDelegatorUnbondingQOSatHeight(unbondHeight, deleAddr)
+		if totalAmount < amount {
+			amount = totalAmount
+		}
+
+		if totalAmount == amount {
+			e.DelegationMapper.RemoveDelegatorUnbondingQOSatHeight(unbondHeight, deleAddr)
+		} else {
+			e.DelegationMapper.SetDelegatorUnbondingQOSatHeight(unbondHeight, deleAddr, totalAmount-amount)
+		}
+
+		info.Amount = info.Amount - amount
+		e.DelegationMapper.SetValidatorUnbondingQOSatHeight(unbondHeight, valAddr, deleAddr, info)
+
+		slashedUnbonding += amount
+	})
+
+	//3. 更新validator的bondTokens
+	if slashedBond > 0 {
+		updatedTokens := validator.BondTokens - slashedBond
+		if validator.IsActive() {
+			e.ValidatorMapper.ChangeValidatorBondTokens(validator, updatedTokens)
+		} else {
+			validator.BondTokens = updatedTokens
+			e.ValidatorMapper.Set(ecotypes.BuildValidatorKey(valAddr), validator)
+		}
+	}
+
+	//4. 惩罚的QOS进入社区奖励池
+	slashed := slashedBond + slashedUnbonding
+	if slashed > 0 {
+		communityFeePool := e.DistributionMapper.GetCommunityFeePool()
+		e.DistributionMapper.SetCommunityFeePool(communityFeePool.Add(btypes.NewInt(int64(slashed))))
+
+		supplyMapper := supply.GetSupplyMapper(ctx)
+		supplyMapper.MoveLockedQOS(supplytypes.Bonded, supplytypes.CommunityPool, btypes.NewInt(int64(slashedBond)))
+		supplyMapper.MoveLockedQOS(supplytypes.Unbonding, supplytypes.CommunityPool, btypes.NewInt(int64(slashedUnbonding)))
+	}
+
+	return slashed
+}
+
+//将validator永久置为Inactive状态
+func tombstoneValidator(ctx context.Context, valAddr btypes.Address) {
+	e := eco.GetEco(ctx)
+
+	validator, exsits := e.ValidatorMapper.GetValidator(valAddr)
+	if !exsits {
+		return
+	}
+
+	if validator.IsActive() {
+		blockValidator(ctx, validator, ecotypes.DoubleSign)
+		return
+	}
+
+	//已处于Inactive状态的validator, 保留原inactive时间, 只修改InactiveCode
+	validator.InactiveCode = ecotypes.DoubleSign
+	e.ValidatorMapper.Set(ecotypes.BuildValidatorKey(valAddr), validator)
+}
+
+//设置validator禁止激活的截止时间
+func jailValidator(ctx context.Context, valAddr btypes.Address, jailedUntil time.Time) {
+	validatorMapper := ecomapper.GetValidatorMapper(ctx)
+
+	validator, exsits := validatorMapper.GetValidator(valAddr)
+	if !exsits {
+		return
+	}
+
+	validator.JailedUntil = jailedUntil.UTC()
+	validatorMapper.Set(ecotypes.BuildValidatorKey(valAddr), validator)
+}
@@ -62,6 +62,10 @@ func (tx *TxCreateDelegation) CalcGas() btypes.BigInt {
 	return btypes.ZeroInt()
 }
 
+func (tx *TxCreateDelegation) GasItems() uint64 {
+	return 0
+}
+
 func (tx *TxCreateDelegation) GetGasPayer() btypes.Address {
 	return btypes.Address(tx.Delegator)
 }
@@ -128,6 +132,10 @@ func (tx *TxModifyCompound) CalcGas() btypes.BigInt {
 	return btypes.ZeroInt()
 }
 
+func (tx *TxModifyCompound) GasItems() uint64 {
+	return 0
+}
+
 func (tx *TxModifyCompound) GetGasPayer() btypes.Address {
 	return btypes.Address(tx.Delegator)
 }
@@ -154,7 +162,7 @@ func (tx *TxUnbondDelegation) ValidateData(ctx context.Context) error {
 		return errors.New("unbond QOS amount is zero")
 	}
 
-	validator, err := validateValidator(ctx, tx.ValidatorOwner, false, staketypes.Active, true)
+	validator, err := validateValidator(ctx, tx.ValidatorOwner, false, staketypes.Active, false)
 	if nil != err {
 		return err
 	}
@@ -197,6 +205,10 @@ func (tx *TxUnbondDelegation) CalcGas() btypes.BigInt {
 	return btypes.ZeroInt()
 }
 
+func (tx *TxUnbondDelegation) GasItems() uint64 {
+	return 0
+}
+
 func (tx *TxUnbondDelegation) GetGasPayer() btypes.Address {
 	return tx.Delegator
 }
@@ -227,7 +239,7 @@ func (tx *TxCreateReDelegation) ValidateData(ctx context.Context) error {
 	}
 
 	//1. 校验fromValidator是否存在
-	validator, err := validateValidator(ctx, tx.FromValidatorOwner, false, 0, true)
+	validator, err := validateValidator(ctx, tx.FromValidatorOwner, false, 0, false)
 	if err != nil {
 		return err
 	}
@@ -280,6 +292,10 @@ func (tx *TxCreateReDelegation) CalcGas() btypes.BigInt {
 	return btypes.ZeroInt()
 }
 
+func (tx *TxCreateReDelegation) GasItems() uint64 {
+	return 0
+}
+
 func (tx *TxCreateReDelegation) GetGasPayer() btypes.Address {
 	return tx.Delegator
 }
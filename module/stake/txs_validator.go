@@ -15,8 +15,14 @@ import (
 )
 
 const (
-	MaxNameLen        = 300
-	MaxDescriptionLen = 1000
+	MaxNameLen            = 300
+	MaxDescriptionLen     = 1000
+	MaxWebsiteLen         = 140
+	MaxIdentityLen        = 64
+	MaxSecurityContactLen = 140
+
+	//TxModifyValidator中不修改的字段取值
+	DoNotModify = "[do-not-modify]"
 )
 
 type TxCreateValidator struct {
@@ -26,11 +32,12 @@ type TxCreateValidator struct {
 	BondTokens  uint64         //绑定Token数量
 	IsCompound  bool           //周期收益是否复投
 	Description string
+	Commission  ecotypes.Commission //佣金设置, UpdateTime无需设置
 }
 
 var _ txs.ITx = (*TxCreateValidator)(nil)
 
-func NewCreateValidatorTx(name string, owner btypes.Address, pubKey crypto.PubKey, bondTokens uint64, isCompound bool, description string) *TxCreateValidator {
+func NewCreateValidatorTx(name string, owner btypes.Address, pubKey crypto.PubKey, bondTokens uint64, isCompound bool, description string, commission ecotypes.Commission) *TxCreateValidator {
 	return &TxCreateValidator{
 		Name:        name,
 		Owner:       owner,
@@ -38,6 +45,7 @@ func NewCreateValidatorTx(name string, owner btypes.Address, pubKey crypto.PubKe
 		BondTokens:  bondTokens,
 		IsCompound:  isCompound,
 		Description: description,
+		Commission:  commission,
 	}
 }
 
@@ -51,6 +59,10 @@ func (tx *TxCreateValidator) ValidateData(ctx context.Context) (err error) {
 		return ErrInvalidInput(DefaultCodeSpace, "")
 	}
 
+	if e := tx.Commission.Validate(); e != nil {
+		return ErrInvalidCommission(DefaultCodeSpace, e.Error())
+	}
+
 	err = validateQOSAccount(ctx, tx.Owner, tx.BondTokens)
 	if nil != err {
 		return err
@@ -69,7 +81,7 @@ func (tx *TxCreateValidator) ValidateData(ctx context.Context) (err error) {
 
 func (tx *TxCreateValidator) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
 
-	err := eco.DecrAccountQOS(ctx, tx.Owner, btypes.NewInt(int64(tx.BondTokens)))
+	err := eco.DelegateAccountQOS(ctx, tx.Owner, btypes.NewInt(int64(tx.BondTokens)))
 	if err != nil {
 		return btypes.Result{Code: btypes.CodeInternal, Codespace: btypes.CodespaceType(err.Error())}, nil
 	}
@@ -83,6 +95,7 @@ func (tx *TxCreateValidator) Exec(ctx context.Context) (result btypes.Result, cr
 		Status:          ecotypes.Active,
 		MinPeriod:       uint64(0),
 		BondHeight:      uint64(ctx.BlockHeight()),
+		Commission:      ecotypes.NewCommission(tx.Commission.Rate, tx.Commission.MaxRate, tx.Commission.MaxChangeRate, ctx.BlockHeader().Time.UTC()),
 	}
 
 	valAddr := validator.GetValidatorAddress()
@@ -112,6 +125,10 @@ func (tx *TxCreateValidator) CalcGas() btypes.BigInt {
 	return btypes.ZeroInt()
 }
 
+func (tx *TxCreateValidator) GasItems() uint64 {
+	return 0
+}
+
 func (tx *TxCreateValidator) GetGasPayer() btypes.Address {
 	return btypes.Address(tx.Owner)
 }
@@ -123,6 +140,9 @@ func (tx *TxCreateValidator) GetSignData() (ret []byte) {
 	ret = append(ret, btypes.Int2Byte(int64(tx.BondTokens))...)
 	ret = append(ret, btypes.Bool2Byte(tx.IsCompound)...)
 	ret = append(ret, tx.Description...)
+	ret = append(ret, tx.Commission.Rate.Value.String()...)
+	ret = append(ret, tx.Commission.MaxRate.Value.String()...)
+	ret = append(ret, tx.Commission.MaxChangeRate.Value.String()...)
 
 	return
 }
@@ -173,6 +193,10 @@ func (tx *TxRevokeValidator) CalcGas() btypes.BigInt {
 	return btypes.ZeroInt()
 }
 
+func (tx *TxRevokeValidator) GasItems() uint64 {
+	return 0
+}
+
 func (tx *TxRevokeValidator) GetGasPayer() btypes.Address {
 	return btypes.Address(tx.Owner)
 }
@@ -228,8 +252,7 @@ func (tx *TxActiveValidator) Exec(ctx context.Context) (result btypes.Result, cr
 	delegationMapper := ecomapper.GetDelegationMapper(ctx)
 	info, _ := delegationMapper.GetDelegationInfo(delegatorAddr, valAddr)
 
-	distributionMapper := ecomapper.GetDistributionMapper(ctx)
-	distributionMapper.ModifyDelegatorTokens(validator, delegatorAddr, info.Amount, uint64(ctx.BlockHeight()))
+	eco.GetEco(ctx).ModifyDelegatorTokens(validator, delegatorAddr, info.Amount, uint64(ctx.BlockHeight()))
 
 	return btypes.Result{Code: btypes.CodeOK}, nil
 }
@@ -242,6 +265,10 @@ func (tx *TxActiveValidator) CalcGas() btypes.BigInt {
 	return btypes.ZeroInt()
 }
 
+func (tx *TxActiveValidator) GasItems() uint64 {
+	return 0
+}
+
 func (tx *TxActiveValidator) GetGasPayer() btypes.Address {
 	return btypes.Address(tx.Owner)
 }
@@ -252,12 +279,217 @@ func (tx *TxActiveValidator) GetSignData() (ret []byte) {
 	return
 }
 
+type TxModifyValidator struct {
+	Owner           btypes.Address //操作者
+	Name            string         //以下字段取值DoNotModify时不修改
+	Description     string
+	Website         string
+	Identity        string
+	SecurityContact string
+	CommissionRate  *types.Fraction //新佣金比例, 为空时不修改
+}
+
+var _ txs.ITx = (*TxModifyValidator)(nil)
+
+func NewModifyValidatorTx(owner btypes.Address, name, description, website, identity, securityContact string, commissionRate *types.Fraction) *TxModifyValidator {
+	return &TxModifyValidator{
+		Owner:           owner,
+		Name:            name,
+		Description:     description,
+		Website:         website,
+		Identity:        identity,
+		SecurityContact: securityContact,
+		CommissionRate:  commissionRate,
+	}
+}
+
+func (tx *TxModifyValidator) ValidateData(ctx context.Context) (err error) {
+	if len(tx.Owner) == 0 {
+		return ErrInvalidInput(DefaultCodeSpace, "")
+	}
+
+	if tx.Name == DoNotModify && tx.Description == DoNotModify && tx.Website == DoNotModify &&
+		tx.Identity == DoNotModify && tx.SecurityContact == DoNotModify && tx.CommissionRate == nil {
+		return ErrInvalidInput(DefaultCodeSpace, "nothing to modify")
+	}
+
+	if tx.Name != DoNotModify && (len(tx.Name) == 0 || len(tx.Name) > MaxNameLen) {
+		return ErrInvalidInput(DefaultCodeSpace, fmt.Sprintf("name must not be empty and not longer than %d", MaxNameLen))
+	}
+	if tx.Description != DoNotModify && len(tx.Description) > MaxDescriptionLen {
+		return ErrInvalidInput(DefaultCodeSpace, fmt.Sprintf("description is longer than %d", MaxDescriptionLen))
+	}
+	if tx.Website != DoNotModify && len(tx.Website) > MaxWebsiteLen {
+		return ErrInvalidInput(DefaultCodeSpace, fmt.Sprintf("website is longer than %d", MaxWebsiteLen))
+	}
+	if tx.Identity != DoNotModify && len(tx.Identity) > MaxIdentityLen {
+		return ErrInvalidInput(DefaultCodeSpace, fmt.Sprintf("identity is longer than %d", MaxIdentityLen))
+	}
+	if tx.SecurityContact != DoNotModify && len(tx.SecurityContact) > MaxSecurityContactLen {
+		return ErrInvalidInput(DefaultCodeSpace, fmt.Sprintf("security contact is longer than %d", MaxSecurityContactLen))
+	}
+
+	//未关闭的validator均可修改名称等信息, 以便inactive或jail期间更新联系方式
+	validator, err := validateValidator(ctx, tx.Owner, false, ecotypes.Active, false)
+	if nil != err {
+		return err
+	}
+
+	//佣金比例只有active状态的validator可修改
+	if tx.CommissionRate != nil {
+		if validator.Status != ecotypes.Active {
+			return ErrValidatorIsInactive(DefaultCodeSpace, "only active validator can modify commission rate")
+		}
+		if e := validator.Commission.ValidateNewRate(*tx.CommissionRate, ctx.BlockHeader().Time); e != nil {
+			return ErrInvalidCommission(DefaultCodeSpace, e.Error())
+		}
+	}
+
+	return nil
+}
+
+func (tx *TxModifyValidator) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	mapper := ecomapper.GetValidatorMapper(ctx)
+	validator, exists := mapper.GetValidatorByOwner(tx.Owner)
+	if !exists {
+		return btypes.Result{Code: btypes.CodeInternal}, nil
+	}
+
+	if tx.Name != DoNotModify {
+		validator.Name = tx.Name
+	}
+	if tx.Description != DoNotModify {
+		validator.Description = tx.Description
+	}
+	if tx.Website != DoNotModify {
+		validator.Website = tx.Website
+	}
+	if tx.Identity != DoNotModify {
+		validator.Identity = tx.Identity
+	}
+	if tx.SecurityContact != DoNotModify {
+		validator.SecurityContact = tx.SecurityContact
+	}
+	if tx.CommissionRate != nil {
+		validator.Commission.Rate = *tx.CommissionRate
+		validator.Commission.UpdateTime = ctx.BlockHeader().Time.UTC()
+	}
+	mapper.UpdateValidator(validator)
+
+	return btypes.Result{Code: btypes.CodeOK}, nil
+}
+
+func (tx *TxModifyValidator) GetSigner() []btypes.Address {
+	return []btypes.Address{tx.Owner}
+}
+
+func (tx *TxModifyValidator) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx *TxModifyValidator) GasItems() uint64 {
+	return 0
+}
+
+func (tx *TxModifyValidator) GetGasPayer() btypes.Address {
+	return btypes.Address(tx.Owner)
+}
+
+func (tx *TxModifyValidator) GetSignData() (ret []byte) {
+	ret = append(ret, tx.Owner...)
+	ret = append(ret, tx.Name...)
+	ret = append(ret, tx.Description...)
+	ret = append(ret, tx.Website...)
+	ret = append(ret, tx.Identity...)
+	ret = append(ret, tx.SecurityContact...)
+	if tx.CommissionRate != nil {
+		ret = append(ret, tx.CommissionRate.Value.String()...)
+	}
+
+	return
+}
+
+//TxRotateValidatorKey 更换validator共识公钥, 用于priv_validator私钥泄露等情况
+type TxRotateValidatorKey struct {
+	Owner     btypes.Address //操作者
+	NewPubKey crypto.PubKey  //新validator公钥
+}
+
+var _ txs.ITx = (*TxRotateValidatorKey)(nil)
+
+func NewRotateValidatorKeyTx(owner btypes.Address, newPubKey crypto.PubKey) *TxRotateValidatorKey {
+	return &TxRotateValidatorKey{
+		Owner:     owner,
+		NewPubKey: newPubKey,
+	}
+}
+
+func (tx *TxRotateValidatorKey) ValidateData(ctx context.Context) (err error) {
+	if len(tx.Owner) == 0 || tx.NewPubKey == nil {
+		return ErrInvalidInput(DefaultCodeSpace, "")
+	}
+
+	validator, err := validateValidator(ctx, tx.Owner, false, ecotypes.Active, false)
+	if nil != err {
+		return err
+	}
+	if validator.IsTombstoned() {
+		return ErrValidatorTombstoned(DefaultCodeSpace, tx.Owner.String()+"'s validator is tombstoned because of double sign.")
+	}
+
+	//新公钥不能被其他validator使用过
+	mapper := ecomapper.GetValidatorMapper(ctx)
+	newAddr := btypes.Address(tx.NewPubKey.Address())
+	if mapper.Exists(newAddr) || mapper.IsRotatedValidatorAddress(newAddr) {
+		return ErrValidatorExists(DefaultCodeSpace, "new pubkey has been used by a validator")
+	}
+
+	return nil
+}
+
+func (tx *TxRotateValidatorKey) Exec(ctx context.Context) (result btypes.Result, crossTxQcp *txs.TxQcp) {
+	e := eco.GetEco(ctx)
+	validator, exists := e.ValidatorMapper.GetValidatorByOwner(tx.Owner)
+	if !exists {
+		return btypes.Result{Code: btypes.CodeInternal}, nil
+	}
+
+	oldAddr := validator.GetValidatorAddress()
+	validator = e.RotateValidatorKey(validator, tx.NewPubKey)
+	ctx.Logger().Info("rotate validator key", "owner", tx.Owner.String(), "old", oldAddr.String(), "new", validator.GetValidatorAddress().String())
+
+	return btypes.Result{Code: btypes.CodeOK}, nil
+}
+
+func (tx *TxRotateValidatorKey) GetSigner() []btypes.Address {
+	return []btypes.Address{tx.Owner}
+}
+
+func (tx *TxRotateValidatorKey) CalcGas() btypes.BigInt {
+	return btypes.ZeroInt()
+}
+
+func (tx *TxRotateValidatorKey) GasItems() uint64 {
+	return 0
+}
+
+func (tx *TxRotateValidatorKey) GetGasPayer() btypes.Address {
+	return btypes.Address(tx.Owner)
+}
+
+func (tx *TxRotateValidatorKey) GetSignData() (ret []byte) {
+	ret = append(ret, tx.Owner...)
+	ret = append(ret, tx.NewPubKey.Bytes()...)
+
+	return
+}
+
 func validateQOSAccount(ctx context.Context, addr btypes.Address, toPay uint64) error {
 	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
 	acc := accountMapper.GetAccount(addr)
 
 	if toPay > 0 {
-		qosAccount := acc.(*types.QOSAccount)
+		qosAccount, _ := types.ToQOSAccount(acc)
 		if !qosAccount.EnoughOfQOS(btypes.NewInt(int64(toPay))) {
 			return ErrOwnerNoEnoughToken(DefaultCodeSpace, "No enough QOS in account: "+addr.String())
 		}
@@ -277,7 +509,13 @@ func validateValidator(ctx context.Context, ownerAddr btypes.Address, checkStatu
 		}
 	}
 	if checkJail {
-		// TODO: block jailed validator
+		if validator.IsTombstoned() {
+			return validator, ErrValidatorTombstoned(DefaultCodeSpace, ownerAddr.String()+"'s validator is tombstoned because of double sign.")
+		}
+		now := ctx.BlockHeader().Time.UTC()
+		if validator.IsJailed(now) {
+			return validator, ErrValidatorJailed(DefaultCodeSpace, fmt.Sprintf("validator is jailed until %s, remaining: %s", validator.JailedUntil, validator.JailedUntil.Sub(now)))
+		}
 	}
 	return validator, nil
 }
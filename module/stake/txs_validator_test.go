@@ -0,0 +1,240 @@
+package stake
+
+import (
+	"testing"
+	"time"
+
+	"github.com/QOSGroup/qbase/account"
+	btypes "github.com/QOSGroup/qbase/types"
+	stakemapper "github.com/QOSGroup/qos/module/eco/mapper"
+	staketypes "github.com/QOSGroup/qos/module/eco/types"
+	"github.com/QOSGroup/qos/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+func TestValidatorCommission(t *testing.T) {
+	blockTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := defaultContext().WithBlockHeight(10).WithBlockHeader(abci.Header{Height: 10, Time: blockTime})
+
+	owner := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	accountMapper := ctx.Mapper(account.AccountMapperName).(*account.AccountMapper)
+	acc := accountMapper.NewAccountWithAddress(owner).(*types.QOSAccount)
+	acc.QOS = btypes.NewInt(1000)
+	accountMapper.SetAccount(acc)
+
+	pubKey := ed25519.GenPrivKey().PubKey()
+
+	//佣金比例超过上限
+	commission := staketypes.NewCommission(types.NewFraction(3, 10), types.NewFraction(2, 10), types.NewFraction(1, 100), time.Time{})
+	tx := NewCreateValidatorTx("test", owner, pubKey, 500, false, "", commission)
+	require.NotNil(t, tx.ValidateData(ctx))
+
+	commission = staketypes.NewCommission(types.NewFraction(1, 100), types.NewFraction(2, 10), types.NewFraction(1, 100), time.Time{})
+	tx = NewCreateValidatorTx("test", owner, pubKey, 500, false, "", commission)
+	require.Nil(t, tx.ValidateData(ctx))
+	result, _ := tx.Exec(ctx)
+	require.True(t, result.IsOK())
+
+	validatorMapper := stakemapper.GetValidatorMapper(ctx)
+	validator, exists := validatorMapper.GetValidatorByOwner(owner)
+	require.True(t, exists)
+	require.True(t, validator.Commission.Rate.Equal(types.NewFraction(1, 100)))
+	require.Equal(t, blockTime, validator.Commission.UpdateTime)
+
+	//24h内不可修改
+	rate := types.NewFraction(2, 100)
+	modifyTx := NewModifyValidatorTx(owner, DoNotModify, DoNotModify, DoNotModify, DoNotModify, DoNotModify, &rate)
+	require.NotNil(t, modifyTx.ValidateData(ctx))
+
+	ctx = ctx.WithBlockHeader(abci.Header{Height: 20, Time: blockTime.Add(staketypes.CommissionUpdateInterval)})
+
+	//变化量超过MaxChangeRate
+	invalidRate := types.NewFraction(3, 100)
+	require.NotNil(t, NewModifyValidatorTx(owner, DoNotModify, DoNotModify, DoNotModify, DoNotModify, DoNotModify, &invalidRate).ValidateData(ctx))
+
+	require.Nil(t, modifyTx.ValidateData(ctx))
+	result, _ = modifyTx.Exec(ctx)
+	require.True(t, result.IsOK())
+
+	validator, _ = validatorMapper.GetValidatorByOwner(owner)
+	require.True(t, validator.Commission.Rate.Equal(rate))
+	require.True(t, validator.Commission.MaxRate.Equal(types.NewFraction(2, 10)))
+	require.Equal(t, blockTime.Add(staketypes.CommissionUpdateInterval), validator.Commission.UpdateTime)
+	require.Equal(t, uint64(500), validator.BondTokens)
+}
+
+func TestModifyValidator(t *testing.T) {
+	ctx := defaultContext()
+
+	owner := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	validator := staketypes.Validator{
+		Name:            "test",
+		Owner:           owner,
+		ValidatorPubKey: ed25519.GenPrivKey().PubKey(),
+		BondTokens:      500,
+		Description:     "desc",
+		Status:          staketypes.Active,
+		BondHeight:      1,
+		Commission:      staketypes.DefaultCommission(time.Time{}),
+	}
+	validatorMapper := stakemapper.GetValidatorMapper(ctx)
+	validatorMapper.CreateValidator(validator)
+
+	//无修改内容
+	tx := NewModifyValidatorTx(owner, DoNotModify, DoNotModify, DoNotModify, DoNotModify, DoNotModify, nil)
+	require.NotNil(t, tx.ValidateData(ctx))
+
+	//名称不能为空
+	tx = NewModifyValidatorTx(owner, "", DoNotModify, DoNotModify, DoNotModify, DoNotModify, nil)
+	require.NotNil(t, tx.ValidateData(ctx))
+
+	//长度校验
+	tx = NewModifyValidatorTx(owner, DoNotModify, DoNotModify, string(make([]byte, MaxWebsiteLen+1)), DoNotModify, DoNotModify, nil)
+	require.NotNil(t, tx.ValidateData(ctx))
+
+	//非owner
+	tx = NewModifyValidatorTx(btypes.Address(ed25519.GenPrivKey().PubKey().Address()), "new", DoNotModify, DoNotModify, DoNotModify, DoNotModify, nil)
+	require.NotNil(t, tx.ValidateData(ctx))
+
+	tx = NewModifyValidatorTx(owner, "new", DoNotModify, "https://www.example.com", "keybase", "sec@example.com", nil)
+	require.Nil(t, tx.ValidateData(ctx))
+	result, _ := tx.Exec(ctx)
+	require.True(t, result.IsOK())
+
+	v, _ := validatorMapper.GetValidatorByOwner(owner)
+	require.Equal(t, "new", v.Name)
+	require.Equal(t, "desc", v.Description)
+	require.Equal(t, "https://www.example.com", v.Website)
+	require.Equal(t, "keybase", v.Identity)
+	require.Equal(t, "sec@example.com", v.SecurityContact)
+	require.Equal(t, validator.Commission.Rate, v.Commission.Rate)
+	require.Equal(t, validator.BondTokens, v.BondTokens)
+
+	//jail期间的inactive validator可修改名称等信息, 不可修改佣金比例
+	ctx = ctx.WithBlockHeader(abci.Header{Height: 20, Time: v.Commission.UpdateTime.Add(staketypes.CommissionUpdateInterval)})
+	v.Status = staketypes.Inactive
+	v.InactiveCode = staketypes.MissVoteBlock
+	v.JailedUntil = ctx.BlockHeader().Time.Add(time.Hour)
+	validatorMapper.UpdateValidator(v)
+
+	tx = NewModifyValidatorTx(owner, DoNotModify, DoNotModify, DoNotModify, DoNotModify, "new-sec@example.com", nil)
+	require.Nil(t, tx.ValidateData(ctx))
+	result, _ = tx.Exec(ctx)
+	require.True(t, result.IsOK())
+	v, _ = validatorMapper.GetValidatorByOwner(owner)
+	require.Equal(t, "new-sec@example.com", v.SecurityContact)
+
+	rate := types.NewFraction(2, 100)
+	tx = NewModifyValidatorTx(owner, DoNotModify, DoNotModify, DoNotModify, DoNotModify, DoNotModify, &rate)
+	require.NotNil(t, tx.ValidateData(ctx))
+
+	v.Status = staketypes.Active
+	validatorMapper.UpdateValidator(v)
+	require.Nil(t, tx.ValidateData(ctx))
+}
+
+func TestRotateValidatorKey(t *testing.T) {
+	ctx := defaultContext().WithBlockHeight(10)
+
+	validatorMapper := stakemapper.GetValidatorMapper(ctx)
+	voteInfoMapper := stakemapper.GetVoteInfoMapper(ctx)
+	delegationMapper := stakemapper.GetDelegationMapper(ctx)
+	distributionMapper := stakemapper.GetDistributionMapper(ctx)
+	validatorMapper.SetParams(staketypes.DefaultStakeParams())
+	distributionMapper.SetParams(staketypes.DefaultDistributionParams())
+
+	owner := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	delegator := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	validator := staketypes.Validator{
+		Name:            "test",
+		Owner:           owner,
+		ValidatorPubKey: ed25519.GenPrivKey().PubKey(),
+		BondTokens:      1000,
+		Status:          staketypes.Active,
+		BondHeight:      1,
+	}
+	oldAddr := validator.GetValidatorAddress()
+
+	validatorMapper.CreateValidator(validator)
+	distributionMapper.InitValidatorPeriodSummaryInfo(oldAddr)
+	delegationMapper.SetDelegationInfo(staketypes.NewDelegationInfo(owner, oldAddr, 600, false))
+	distributionMapper.InitDelegatorIncomeInfo(oldAddr, owner, 600, 1)
+	delegationMapper.SetDelegationInfo(staketypes.NewDelegationInfo(delegator, oldAddr, 400, true))
+	distributionMapper.InitDelegatorIncomeInfo(oldAddr, delegator, 400, 1)
+	delegationMapper.AddValidatorUnbondingQOSatHeight(100, oldAddr, delegator, 5, 50)
+	voteInfoMapper.SetValidatorVoteInfo(oldAddr, staketypes.NewValidatorVoteInfo(1, 5, 1))
+	voteInfoMapper.SetVoteInfoInWindow(oldAddr, 3, false)
+	distributionMapper.SetLastBlockProposer(oldAddr)
+
+	require.Equal(t, 1, len(GetUpdatedValidators(ctx, 10)))
+
+	//新公钥不能与已有validator重复
+	tx := NewRotateValidatorKeyTx(owner, validator.ValidatorPubKey)
+	require.NotNil(t, tx.ValidateData(ctx))
+
+	newPubKey := ed25519.GenPrivKey().PubKey()
+	newAddr := btypes.Address(newPubKey.Address())
+	tx = NewRotateValidatorKeyTx(owner, newPubKey)
+	require.Nil(t, tx.ValidateData(ctx))
+	result, _ := tx.Exec(ctx)
+	require.True(t, result.IsOK())
+
+	//validator
+	require.False(t, validatorMapper.Exists(oldAddr))
+	v, exists := validatorMapper.GetValidatorByOwner(owner)
+	require.True(t, exists)
+	require.Equal(t, newAddr, v.GetValidatorAddress())
+	require.Equal(t, uint64(1000), v.BondTokens)
+	require.Equal(t, newAddr, validatorMapper.GetLatestValidatorAddress(oldAddr))
+	require.True(t, validatorMapper.IsRotatedValidatorAddress(oldAddr))
+
+	//旧公钥不能再次使用
+	require.NotNil(t, NewRotateValidatorKeyTx(owner, validator.ValidatorPubKey).ValidateData(ctx))
+
+	//vote info
+	_, exists = voteInfoMapper.GetValidatorVoteInfo(oldAddr)
+	require.False(t, exists)
+	voteInfo, exists := voteInfoMapper.GetValidatorVoteInfo(newAddr)
+	require.True(t, exists)
+	require.Equal(t, uint64(1), voteInfo.MissedBlocksCounter)
+	require.False(t, voteInfoMapper.GetVoteInfoInWindow(newAddr, 3))
+
+	//delegations
+	_, exists = delegationMapper.GetDelegationInfo(owner, oldAddr)
+	require.False(t, exists)
+	info, exists := delegationMapper.GetDelegationInfo(delegator, newAddr)
+	require.True(t, exists)
+	require.Equal(t, uint64(400), info.Amount)
+	require.True(t, info.IsCompound)
+	require.Equal(t, newAddr, info.ValidatorAddr)
+	unbond, exists := delegationMapper.GetValidatorUnbondingQOSatHeight(100, newAddr, delegator)
+	require.True(t, exists)
+	require.Equal(t, uint64(50), unbond.Amount)
+
+	//distribution
+	_, exists = distributionMapper.GetValidatorCurrentPeriodSummary(oldAddr)
+	require.False(t, exists)
+	_, exists = distributionMapper.GetValidatorCurrentPeriodSummary(newAddr)
+	require.True(t, exists)
+	_, exists = distributionMapper.GetDelegatorEarningStartInfo(newAddr, owner)
+	require.True(t, exists)
+	incomes := 0
+	distributionMapper.IteratorDelegatorsIncomeHeight(func(valAddr btypes.Address, _ btypes.Address, _ uint64) {
+		require.Equal(t, newAddr, valAddr)
+		incomes++
+	})
+	require.Equal(t, 2, incomes)
+	require.Equal(t, newAddr, distributionMapper.GetLastBlockProposer())
+
+	//旧公钥移出, 新公钥加入validator集合
+	updates := GetUpdatedValidators(ctx, 10)
+	require.Equal(t, 2, len(updates))
+	powers := make(map[string]int64)
+	for _, update := range updates {
+		powers[string(update.PubKey.Data)] = update.Power
+	}
+	require.Equal(t, int64(0), powers[string(validator.ToABCIValidatorUpdate(true).PubKey.Data)])
+	require.Equal(t, int64(1000), powers[string(v.ToABCIValidatorUpdate(false).PubKey.Data)])
+}
@@ -0,0 +1,13 @@
+package supply
+
+import (
+	bctypes "github.com/QOSGroup/qbase/client/types"
+	"github.com/spf13/cobra"
+	"github.com/tendermint/go-amino"
+)
+
+func QueryCommands(cdc *amino.Codec) []*cobra.Command {
+	return bctypes.GetCommands(
+		querySupplyCommand(cdc),
+	)
+}
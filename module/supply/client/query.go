@@ -0,0 +1,29 @@
+package supply
+
+import (
+	"github.com/QOSGroup/qbase/client/context"
+	"github.com/QOSGroup/qos/module/supply/types"
+	"github.com/spf13/cobra"
+	"github.com/tendermint/go-amino"
+)
+
+func querySupplyCommand(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "supply",
+		Short: "Query total supply of QOS and QSCs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.Query(types.BuildQuerySupplyCustomQueryPath(), []byte(""))
+			if err != nil {
+				return err
+			}
+
+			var result types.SupplyQueryResult
+			cliCtx.Codec.UnmarshalJSON(res, &result)
+			return cliCtx.PrintResult(result)
+		},
+	}
+
+	return cmd
+}
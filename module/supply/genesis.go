@@ -0,0 +1,52 @@
+package supply
+
+import (
+	bacc "github.com/QOSGroup/qbase/account"
+	"github.com/QOSGroup/qbase/context"
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/module/supply/types"
+	qtypes "github.com/QOSGroup/qos/types"
+)
+
+type GenesisState struct {
+	Supply types.Supply `json:"supply"`
+}
+
+func NewGenesisState(supply types.Supply) GenesisState {
+	return GenesisState{
+		Supply: supply,
+	}
+}
+
+// 需在其他模块InitGenesis之后执行, 各模块InitGenesis时设置其锁定的QOS, 未指定代币总量(或为0)时按创世状态统计
+func InitGenesis(ctx context.Context, data GenesisState) {
+	supplyMapper := GetSupplyMapper(ctx)
+	if data.Supply.TotalQOS.IsNil() || data.Supply.TotalQOS.IsZero() {
+		supplyMapper.SetSupply(calcGenesisSupply(ctx))
+		return
+	}
+	supplyMapper.SetSupply(data.Supply)
+}
+
+func ExportGenesis(ctx context.Context) GenesisState {
+	return NewGenesisState(GetSupplyMapper(ctx).GetSupply())
+}
+
+// 账户持有及各模块锁定的代币之和
+func calcGenesisSupply(ctx context.Context) types.Supply {
+	totalQOS := btypes.ZeroInt()
+	qscs := qtypes.QSCs{}
+
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	accountMapper.IterateAccounts(func(acc bacc.Account) (stop bool) {
+		if qosAcc, ok := qtypes.ToQOSAccount(acc); ok {
+			totalQOS = totalQOS.Add(qosAcc.GetQOS())
+			qscs = qscs.Plus(qosAcc.GetQSCs())
+		}
+		return false
+	})
+
+	totalQOS = totalQOS.Add(GetSupplyMapper(ctx).GetTotalLockedQOS())
+
+	return types.NewSupply(totalQOS, qscs)
+}
@@ -0,0 +1,96 @@
+package supply
+
+import (
+	"testing"
+
+	"github.com/QOSGroup/qbase/account"
+	"github.com/QOSGroup/qbase/baseabci"
+	"github.com/QOSGroup/qbase/context"
+	"github.com/QOSGroup/qbase/mapper"
+	"github.com/QOSGroup/qbase/store"
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/module/supply/types"
+	qtypes "github.com/QOSGroup/qos/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestSupply(t *testing.T) {
+	ctx := defaultContext()
+
+	addr := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	accountMapper := ctx.Mapper(account.AccountMapperName).(*account.AccountMapper)
+	accountMapper.SetAccount(qtypes.NewQOSAccount(addr, btypes.NewInt(1000), qtypes.QSCs{btypes.NewBaseCoin("star", btypes.NewInt(100))}))
+
+	//各模块InitGenesis时设置锁定的QOS
+	supplyMapper := GetSupplyMapper(ctx)
+	supplyMapper.SetLockedQOS(types.Bonded, btypes.NewInt(500))
+	supplyMapper.SetLockedQOS(types.Unbonding, btypes.NewInt(50))
+	supplyMapper.SetLockedQOS(types.CommunityPool, btypes.NewInt(20))
+	supplyMapper.SetLockedQOS(types.PreDistribution, btypes.NewInt(10))
+	supplyMapper.SetLockedQOS(types.Deposits, btypes.NewInt(30))
+	supplyMapper.SetLockedQOS(types.Rewards, btypes.NewInt(5))
+	supplyMapper.SetLockedQOS(types.QCPLocked, btypes.NewInt(40))
+	supplyMapper.SetLockedQOS(types.QCPEscrow, btypes.NewInt(15))
+	supplyMapper.SetLockedQOS(types.QSCDeposits, btypes.NewInt(100))
+
+	//未指定总量时按创世状态统计
+	InitGenesis(ctx, GenesisState{})
+	supply := ExportGenesis(ctx).Supply
+	require.True(t, supply.TotalQOS.Equal(btypes.NewInt(1770)))
+	require.True(t, supply.QSCs.AmountOf("star").Equal(btypes.NewInt(100)))
+
+	//挖矿产生的QOS待分配, 收益发放至账户
+	supplyMapper.IncrQOS(btypes.NewInt(90))
+	supplyMapper.LockQOS(types.PreDistribution, btypes.NewInt(90))
+	supplyMapper.MoveLockedQOS(types.PreDistribution, types.Rewards, btypes.NewInt(100))
+	supplyMapper.UnlockQOS(types.Rewards, btypes.NewInt(60))
+	supplyMapper.IncrQSC("star", btypes.NewInt(50))
+	supplyMapper.DecrQSC("star", btypes.NewInt(20))
+
+	result := querySupply(ctx)
+	require.True(t, result.Total.Equal(btypes.NewInt(1860)))
+	require.True(t, result.Bonded.Equal(btypes.NewInt(500)))
+	require.True(t, result.Unbonding.Equal(btypes.NewInt(50)))
+	require.True(t, result.CommunityPool.Equal(btypes.NewInt(20)))
+	require.True(t, result.PreDistribution.Equal(btypes.NewInt(0)))
+	require.True(t, result.Rewards.Equal(btypes.NewInt(45)))
+	require.True(t, result.Deposits.Equal(btypes.NewInt(30)))
+	require.True(t, result.QCPLocked.Equal(btypes.NewInt(40)))
+	require.True(t, result.QCPEscrow.Equal(btypes.NewInt(15)))
+	require.True(t, result.QSCDeposits.Equal(btypes.NewInt(100)))
+	require.True(t, result.Circulating.Equal(btypes.NewInt(1060)))
+	require.True(t, result.QSCs.AmountOf("star").Equal(btypes.NewInt(130)))
+
+	//指定总量时直接保存
+	InitGenesis(ctx, NewGenesisState(types.NewSupply(btypes.NewInt(2000), nil)))
+	require.True(t, supplyMapper.GetSupply().TotalQOS.Equal(btypes.NewInt(2000)))
+}
+
+func defaultContext() context.Context {
+	cdc := baseabci.MakeQBaseCodec()
+	qtypes.RegisterCodec(cdc)
+
+	mapperMap := make(map[string]mapper.IMapper)
+
+	accountMapper := account.NewAccountMapper(cdc, qtypes.ProtoQOSAccount)
+	mapperMap[account.AccountMapperName] = accountMapper
+
+	supplyMapper := NewSupplyMapper()
+	supplyMapper.SetCodec(cdc)
+	mapperMap[types.SupplyMapperName] = supplyMapper
+
+	db := dbm.NewMemDB()
+	cms := store.NewCommitMultiStore(db)
+
+	for _, v := range mapperMap {
+		cms.MountStoreWithDB(v.GetStoreKey(), store.StoreTypeIAVL, db)
+	}
+	cms.LoadLatestVersion()
+
+	ctx := context.NewContext(cms, abci.Header{}, false, log.NewNopLogger(), mapperMap)
+	return ctx
+}
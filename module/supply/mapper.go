@@ -0,0 +1,102 @@
+package supply
+
+import (
+	"github.com/QOSGroup/qbase/context"
+	"github.com/QOSGroup/qbase/mapper"
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/module/supply/types"
+	qtypes "github.com/QOSGroup/qos/types"
+)
+
+type SupplyMapper struct {
+	*mapper.BaseMapper
+}
+
+func NewSupplyMapper() *SupplyMapper {
+	var supplyMapper = SupplyMapper{}
+	supplyMapper.BaseMapper = mapper.NewBaseMapper(nil, types.SupplyMapperName)
+	return &supplyMapper
+}
+
+func GetSupplyMapper(ctx context.Context) *SupplyMapper {
+	return ctx.Mapper(types.SupplyMapperName).(*SupplyMapper)
+}
+
+func (mapper *SupplyMapper) Copy() mapper.IMapper {
+	supplyMapper := &SupplyMapper{}
+	supplyMapper.BaseMapper = mapper.BaseMapper.Copy()
+	return supplyMapper
+}
+
+func (mapper *SupplyMapper) GetSupply() types.Supply {
+	supply := types.Supply{}
+	exists := mapper.Get(types.BuildSupplyKey(), &supply)
+	if !exists {
+		return types.NewSupply(btypes.ZeroInt(), qtypes.QSCs{})
+	}
+	supply.TotalQOS = supply.TotalQOS.NilToZero()
+	return supply
+}
+
+func (mapper *SupplyMapper) SetSupply(supply types.Supply) {
+	mapper.Set(types.BuildSupplyKey(), supply)
+}
+
+// 产生QOS
+func (mapper *SupplyMapper) IncrQOS(amount btypes.BigInt) {
+	supply := mapper.GetSupply()
+	supply.TotalQOS = supply.TotalQOS.Add(amount)
+	mapper.SetSupply(supply)
+}
+
+// 产生QSC
+func (mapper *SupplyMapper) IncrQSC(qscName string, amount btypes.BigInt) {
+	supply := mapper.GetSupply()
+	supply.QSCs = supply.QSCs.Plus(qtypes.QSCs{btypes.NewBaseCoin(qscName, amount)})
+	mapper.SetSupply(supply)
+}
+
+// 销毁QSC
+func (mapper *SupplyMapper) DecrQSC(qscName string, amount btypes.BigInt) {
+	supply := mapper.GetSupply()
+	supply.QSCs = supply.QSCs.Minus(qtypes.QSCs{btypes.NewBaseCoin(qscName, amount)})
+	mapper.SetSupply(supply)
+}
+
+func (mapper *SupplyMapper) GetLockedQOS(bucket string) btypes.BigInt {
+	var amount btypes.BigInt
+	exists := mapper.Get(types.BuildLockedQOSKey(bucket), &amount)
+	if !exists {
+		return btypes.ZeroInt()
+	}
+	return amount.NilToZero()
+}
+
+func (mapper *SupplyMapper) SetLockedQOS(bucket string, amount btypes.BigInt) {
+	mapper.Set(types.BuildLockedQOSKey(bucket), amount.NilToZero())
+}
+
+// 各模块锁定的QOS之和
+func (mapper *SupplyMapper) GetTotalLockedQOS() btypes.BigInt {
+	total := btypes.ZeroInt()
+	for _, bucket := range types.LockedBuckets {
+		total = total.Add(mapper.GetLockedQOS(bucket))
+	}
+	return total
+}
+
+// 账户QOS转入模块锁定, 或新产生的QOS直接锁定
+func (mapper *SupplyMapper) LockQOS(bucket string, amount btypes.BigInt) {
+	mapper.SetLockedQOS(bucket, mapper.GetLockedQOS(bucket).Add(amount))
+}
+
+// 模块锁定的QOS返还至账户
+func (mapper *SupplyMapper) UnlockQOS(bucket string, amount btypes.BigInt) {
+	mapper.SetLockedQOS(bucket, mapper.GetLockedQOS(bucket).Sub(amount))
+}
+
+// 锁定的QOS在模块间转移, 流通量不变
+func (mapper *SupplyMapper) MoveLockedQOS(from, to string, amount btypes.BigInt) {
+	mapper.UnlockQOS(from, amount)
+	mapper.LockQOS(to, amount)
+}
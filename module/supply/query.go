@@ -0,0 +1,80 @@
+package supply
+
+import (
+	"errors"
+	"runtime/debug"
+
+	"github.com/QOSGroup/qbase/context"
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/module/supply/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+/*
+
+custom path:
+/custom/supply/$query path
+
+query path:
+	/total : 查询QOS总量及分布, 各QSC总量
+
+return:
+  json字节数组
+*/
+
+func Query(ctx context.Context, route []string, req abci.RequestQuery) (res []byte, err btypes.Error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = btypes.ErrInternal(string(debug.Stack()))
+			return
+		}
+	}()
+
+	if len(route) < 1 {
+		return nil, btypes.ErrInternal("custom query miss parameters")
+	}
+
+	supplyMapper := GetSupplyMapper(ctx)
+
+	var result interface{}
+	var e error
+
+	switch route[0] {
+	case types.QueryTotal:
+		result = querySupply(ctx)
+	default:
+		e = errors.New("not found match path")
+	}
+
+	if e != nil {
+		return nil, btypes.ErrInternal(e.Error())
+	}
+
+	data, e := supplyMapper.GetCodec().MarshalJSON(result)
+	if e != nil {
+		return nil, btypes.ErrInternal(e.Error())
+	}
+
+	return data, nil
+}
+
+func querySupply(ctx context.Context) types.SupplyQueryResult {
+	supplyMapper := GetSupplyMapper(ctx)
+	supply := supplyMapper.GetSupply()
+
+	return types.SupplyQueryResult{
+		Total:           supply.TotalQOS,
+		Circulating:     supply.TotalQOS.Sub(supplyMapper.GetTotalLockedQOS()),
+		Bonded:          supplyMapper.GetLockedQOS(types.Bonded),
+		Unbonding:       supplyMapper.GetLockedQOS(types.Unbonding),
+		PreDistribution: supplyMapper.GetLockedQOS(types.PreDistribution),
+		Rewards:         supplyMapper.GetLockedQOS(types.Rewards),
+		CommunityPool:   supplyMapper.GetLockedQOS(types.CommunityPool),
+		Deposits:        supplyMapper.GetLockedQOS(types.Deposits),
+		QCPLocked:       supplyMapper.GetLockedQOS(types.QCPLocked),
+		QCPEscrow:       supplyMapper.GetLockedQOS(types.QCPEscrow),
+		QSCDeposits:     supplyMapper.GetLockedQOS(types.QSCDeposits),
+		QSCs:            supply.QSCs,
+	}
+}
@@ -0,0 +1,71 @@
+package types
+
+import (
+	"fmt"
+
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/types"
+)
+
+const (
+	SupplyMapperName = "supply"
+
+	//------locked QOS-------
+	Bonded          = "bonded"           // 绑定到validator的QOS
+	Unbonding       = "unbonding"        // 解除绑定待返还的QOS
+	PreDistribution = "pre_distribution" // 待分配的挖矿奖励及交易费
+	Rewards         = "rewards"          // 已分配未发放的委托收益
+	CommunityPool   = "community_pool"   // 社区奖励池
+	Deposits        = "deposits"         // 提议抵押
+	QCPLocked       = "qcp_locked"       // 转出至联盟链的QOS
+	QCPEscrow       = "qcp_escrow"       // 跨链转出待确认的QOS
+	QSCDeposits     = "qsc_deposits"     // 创建QSC抵押的QOS
+
+	//------query-------
+	SupplyRoute = "supply"
+	QueryTotal  = "total"
+)
+
+// 代币总量, 仅在代币产生或销毁时更新
+type Supply struct {
+	TotalQOS btypes.BigInt `json:"total_qos"` // QOS总量, 包括创世分配及挖矿产生
+	QSCs     types.QSCs    `json:"qscs"`      // 各QSC总量
+}
+
+func NewSupply(totalQOS btypes.BigInt, qscs types.QSCs) Supply {
+	return Supply{
+		TotalQOS: totalQOS,
+		QSCs:     qscs,
+	}
+}
+
+// 各模块锁定的QOS, 不计入流通量
+var LockedBuckets = []string{Bonded, Unbonding, PreDistribution, Rewards, CommunityPool, Deposits, QCPLocked, QCPEscrow, QSCDeposits}
+
+// 代币分布查询结果
+type SupplyQueryResult struct {
+	Total           btypes.BigInt `json:"total"`            // QOS总量
+	Circulating     btypes.BigInt `json:"circulating"`      // 流通量 = 总量 - 以下锁定部分, 即账户余额
+	Bonded          btypes.BigInt `json:"bonded"`           // 绑定到validator的QOS
+	Unbonding       btypes.BigInt `json:"unbonding"`        // 解除绑定待返还的QOS
+	PreDistribution btypes.BigInt `json:"pre_distribution"` // 待分配的挖矿奖励及交易费
+	Rewards         btypes.BigInt `json:"rewards"`          // 已分配未发放的委托收益
+	CommunityPool   btypes.BigInt `json:"community_pool"`   // 社区奖励池
+	Deposits        btypes.BigInt `json:"deposits"`         // 提议抵押
+	QCPLocked       btypes.BigInt `json:"qcp_locked"`       // 转出至联盟链的QOS
+	QCPEscrow       btypes.BigInt `json:"qcp_escrow"`       // 跨链转出待确认的QOS
+	QSCDeposits     btypes.BigInt `json:"qsc_deposits"`     // 创建QSC抵押的QOS
+	QSCs            types.QSCs    `json:"qscs"`             // 各QSC总量
+}
+
+func BuildSupplyKey() []byte {
+	return []byte("supply")
+}
+
+func BuildLockedQOSKey(bucket string) []byte {
+	return []byte(fmt.Sprintf("locked/%s", bucket))
+}
+
+func BuildQuerySupplyCustomQueryPath() string {
+	return fmt.Sprintf("custom/%s/%s", SupplyRoute, QueryTotal)
+}
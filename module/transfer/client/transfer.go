@@ -4,8 +4,8 @@ import (
 	"fmt"
 	qcliacc "github.com/QOSGroup/qbase/client/account"
 	"github.com/QOSGroup/qbase/client/context"
-	qclitx "github.com/QOSGroup/qbase/client/tx"
 	"github.com/QOSGroup/qbase/txs"
+	distrcli "github.com/QOSGroup/qos/module/distribution/client"
 	"github.com/QOSGroup/qos/module/transfer"
 	transtypes "github.com/QOSGroup/qos/module/transfer/types"
 	"github.com/QOSGroup/qos/types"
@@ -25,15 +25,15 @@ func TransferCmd(cdc *amino.Codec) *cobra.Command {
 		Use:   "transfer",
 		Short: "Transfer QOS and QSCs",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return qclitx.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
+			return distrcli.BroadcastTxAndPrintResult(cdc, func(ctx context.CLIContext) (txs.ITx, error) {
 				sendersStr := viper.GetString(flagSenders)
-				senders, err := parseTransItem(ctx, sendersStr)
+				senders, err := ParseTransItems(ctx, sendersStr)
 				if err != nil {
 					return nil, err
 				}
 
 				receiversStr := viper.GetString(flagReceivers)
-				receivers, err := parseTransItem(ctx, receiversStr)
+				receivers, err := ParseTransItems(ctx, receiversStr)
 				if err != nil {
 					return nil, err
 				}
@@ -54,8 +54,8 @@ func TransferCmd(cdc *amino.Codec) *cobra.Command {
 	return cmd
 }
 
-// Parse flags from string
-func parseTransItem(cliCtx context.CLIContext, str string) (transtypes.TransItems, error) {
+// 解析转账集合, eg: Arya,10qos,100qstar;address1xxx,10qos
+func ParseTransItems(cliCtx context.CLIContext, str string) (transtypes.TransItems, error) {
 	items := make(transtypes.TransItems, 0)
 	tis := strings.Split(str, ";")
 	for _, ti := range tis {
@@ -5,6 +5,7 @@ import (
 	"github.com/QOSGroup/qbase/context"
 	"github.com/QOSGroup/qbase/txs"
 	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/QOSGroup/qos/module/qsc"
 	transfertypes "github.com/QOSGroup/qos/module/transfer/types"
 	"github.com/QOSGroup/qos/types"
 )
@@ -33,10 +34,16 @@ func (tx TxTransfer) ValidateData(ctx context.Context) error {
 		if a == nil {
 			return ErrSenderAccountNotExists(DefaultCodeSpace, "")
 		}
-		acc := a.(*types.QOSAccount)
+		acc, _ := types.ToQOSAccount(a)
 		if !acc.EnoughOf(sender.QOS, sender.QSCs) {
 			return ErrSenderAccountCoinsNotEnough(DefaultCodeSpace, "")
 		}
+		if types.SpendableQOS(a, ctx.BlockHeader().Time.UTC()).LT(sender.QOS.NilToZero()) {
+			return ErrSenderAccountCoinsNotEnough(DefaultCodeSpace, "vesting QOS cannot be transferred")
+		}
+		if err := qsc.ValidateNotFrozen(ctx, sender.Address, sender.QSCs); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -47,20 +54,19 @@ func (tx TxTransfer) Exec(ctx context.Context) (result btypes.Result, crossTxQcp
 	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
 
 	for _, sender := range tx.Senders {
-		acc := accountMapper.GetAccount(sender.Address).(*types.QOSAccount)
+		a := accountMapper.GetAccount(sender.Address)
+		acc, _ := types.ToQOSAccount(a)
 		acc.MustMinus(sender.QOS, sender.QSCs)
-		accountMapper.SetAccount(acc)
+		accountMapper.SetAccount(a)
 	}
 	for _, receiver := range tx.Receivers {
 		a := accountMapper.GetAccount(receiver.Address)
-		var acc *types.QOSAccount
-		if a != nil {
-			acc = a.(*types.QOSAccount)
-		} else {
-			acc = types.NewQOSAccountWithAddress(receiver.Address)
+		if a == nil {
+			a = types.NewQOSAccountWithAddress(receiver.Address)
 		}
+		acc, _ := types.ToQOSAccount(a)
 		acc.MustPlus(receiver.QOS, receiver.QSCs)
-		accountMapper.SetAccount(acc)
+		accountMapper.SetAccount(a)
 	}
 
 	return btypes.Result{Code: btypes.CodeOK}, nil
@@ -76,11 +82,15 @@ func (tx TxTransfer) GetSigner() []btypes.Address {
 	return addrs
 }
 
-// Gas TODO
+// 交易gas由gasHandler按gas参数表计算
 func (tx TxTransfer) CalcGas() btypes.BigInt {
 	return btypes.ZeroInt()
 }
 
+func (tx TxTransfer) GasItems() uint64 {
+	return uint64(len(tx.Receivers))
+}
+
 // Senders[0]
 func (tx TxTransfer) GetGasPayer() btypes.Address {
 	return tx.Senders[0].Address
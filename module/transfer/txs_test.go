@@ -6,6 +6,8 @@ import (
 	bmapper "github.com/QOSGroup/qbase/mapper"
 	"github.com/QOSGroup/qbase/store"
 	btypes "github.com/QOSGroup/qbase/types"
+	ecotypes "github.com/QOSGroup/qos/module/eco/types"
+	"github.com/QOSGroup/qos/module/qsc"
 	transfertypes "github.com/QOSGroup/qos/module/transfer/types"
 	"github.com/QOSGroup/qos/types"
 	"github.com/stretchr/testify/require"
@@ -14,6 +16,7 @@ import (
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 	"testing"
+	"time"
 )
 
 func txTransferTestContext() context.Context {
@@ -23,9 +26,15 @@ func txTransferTestContext() context.Context {
 	acountKey := accountMapper.GetStoreKey()
 	mapperMap[bacc.AccountMapperName] = accountMapper
 
+	qscMapper := qsc.NewQSCMapper()
+	qscMapper.SetCodec(cdc)
+	qscKey := qscMapper.GetStoreKey()
+	mapperMap[qsc.QSCMapperName] = qscMapper
+
 	db := dbm.NewMemDB()
 	cms := store.NewCommitMultiStore(db)
 	cms.MountStoreWithDB(acountKey, store.StoreTypeIAVL, db)
+	cms.MountStoreWithDB(qscKey, store.StoreTypeIAVL, db)
 	cms.LoadLatestVersion()
 	ctx := context.NewContext(cms, abci.Header{}, false, log.NewNopLogger(), mapperMap)
 	return ctx
@@ -71,6 +80,65 @@ func TestTransferTx_ValidateData(t *testing.T) {
 	require.Nil(t, tx.ValidateData(ctx))
 }
 
+func TestTransferTx_VestingAccount(t *testing.T) {
+	endTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := txTransferTestContext().WithBlockHeader(abci.Header{Time: endTime.Add(-time.Hour)})
+
+	addr1 := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	addr2 := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	accountMapper.SetAccount(types.NewDelayedVestingAccount(types.NewQOSAccount(addr1, btypes.NewInt(100), nil), btypes.NewInt(60), endTime))
+
+	// 锁仓中的QOS不可转账
+	tx := TxTransfer{
+		Senders:   transfertypes.TransItems{{Address: addr1, QOS: btypes.NewInt(50)}},
+		Receivers: transfertypes.TransItems{{Address: addr2, QOS: btypes.NewInt(50)}},
+	}
+	require.NotNil(t, tx.ValidateData(ctx))
+
+	tx.Senders[0].QOS = btypes.NewInt(40)
+	tx.Receivers[0].QOS = btypes.NewInt(40)
+	require.Nil(t, tx.ValidateData(ctx))
+	result, _ := tx.Exec(ctx)
+	require.True(t, result.IsOK())
+
+	acc, ok := accountMapper.GetAccount(addr1).(*types.DelayedVestingAccount)
+	require.True(t, ok)
+	require.Equal(t, int64(60), acc.GetQOS().Int64())
+
+	// 锁仓结束
+	ctx = ctx.WithBlockHeader(abci.Header{Time: endTime})
+	tx.Senders[0].QOS = btypes.NewInt(60)
+	tx.Receivers[0].QOS = btypes.NewInt(60)
+	require.Nil(t, tx.ValidateData(ctx))
+}
+
+func TestTransferTx_FrozenQSC(t *testing.T) {
+	ctx := txTransferTestContext()
+
+	addr1 := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	addr2 := btypes.Address(ed25519.GenPrivKey().PubKey().Address())
+	accountMapper := ctx.Mapper(bacc.AccountMapperName).(*bacc.AccountMapper)
+	accountMapper.SetAccount(types.NewQOSAccount(addr1, btypes.NewInt(100), types.QSCs{btypes.NewBaseCoin("star", btypes.NewInt(100)), btypes.NewBaseCoin("moon", btypes.NewInt(100))}))
+
+	qscMapper := ctx.Mapper(qsc.QSCMapperName).(*qsc.QSCMapper)
+	qscMapper.FreezeAccount("star", addr1)
+
+	// 冻结的QSC不可转出
+	tx := TxTransfer{
+		Senders:   transfertypes.TransItems{{Address: addr1, QOS: btypes.NewInt(10), QSCs: types.QSCs{btypes.NewBaseCoin("star", btypes.NewInt(10))}}},
+		Receivers: transfertypes.TransItems{{Address: addr2, QOS: btypes.NewInt(10), QSCs: types.QSCs{btypes.NewBaseCoin("star", btypes.NewInt(10))}}},
+	}
+	require.NotNil(t, tx.ValidateData(ctx))
+
+	// QOS及其他QSC不受影响
+	tx = TxTransfer{
+		Senders:   transfertypes.TransItems{{Address: addr1, QOS: btypes.NewInt(10), QSCs: types.QSCs{btypes.NewBaseCoin("moon", btypes.NewInt(10))}}},
+		Receivers: transfertypes.TransItems{{Address: addr2, QOS: btypes.NewInt(10), QSCs: types.QSCs{btypes.NewBaseCoin("moon", btypes.NewInt(10))}}},
+	}
+	require.Nil(t, tx.ValidateData(ctx))
+}
+
 func TestTransferTx_GetSigner(t *testing.T) {
 	tx := TxTransfer{
 		Senders: transfertypes.TransItems{
@@ -93,7 +161,8 @@ func TestTransferTx_CalcGas(t *testing.T) {
 			{ed25519.GenPrivKey().PubKey().Address().Bytes(), btypes.NewInt(10), nil},
 		},
 	}
-	require.Equal(t, tx.CalcGas(), btypes.NewInt(0))
+	// base 200 + 1个接收账户 100 + 1个签名账户 100 + 签名数据字节数
+	require.Equal(t, uint64(400+len(tx.GetSignData())), ecotypes.DefaultGasSchedule().CalcTxGas(tx))
 }
 
 func TestTransferTx_GetGasPayer(t *testing.T) {
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/QOSGroup/kepler/cert"
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// 证书hash, 吊销列表中以此标识证书
+func CertHash(crt cert.Certificate) string {
+	return strings.ToUpper(hex.EncodeToString(tmhash.Sum(cert.MustMarshalJson(crt))))
+}
+
+// 校验并格式化证书hash
+func ParseCertHash(hash string) (string, error) {
+	bz, err := hex.DecodeString(hash)
+	if err != nil || len(bz) != tmhash.Size {
+		return "", errors.New("invalid cert hash")
+	}
+	return strings.ToUpper(hash), nil
+}
+
+// 校验证书由rootCA签发, 且blockTime在有效期内
+// 不使用cert.VerityCrt, 其有效期按本地时间校验, 各节点结果可能不一致
+func VerifyCert(rootCA crypto.PubKey, crt cert.Certificate, blockTime time.Time) error {
+	if rootCA == nil || crt.CA.PublicKey == nil || !rootCA.Equals(crt.CA.PublicKey) {
+		return errors.New("cert not issued by root ca")
+	}
+	if !rootCA.VerifyBytes(cert.MustMarshalJson(crt.CSR), crt.Signature) {
+		return errors.New("invalid cert signature")
+	}
+	if blockTime.Before(crt.CSR.NotBefore) || !blockTime.Before(crt.CSR.NotAfter) {
+		return errors.New("cert expired or not yet valid")
+	}
+	return nil
+}
+
+// 证书已吊销
+var ErrCertRevoked = errors.New("cert revoked")
+
+// 校验证书由rootCA签发、blockTime在有效期内, 且不在吊销列表中
+func VerifyCertWithCRL(rootCA crypto.PubKey, crt cert.Certificate, blockTime time.Time, isRevoked func(hash string) bool) error {
+	if err := VerifyCert(rootCA, crt, blockTime); err != nil {
+		return err
+	}
+	if isRevoked(CertHash(crt)) {
+		return ErrCertRevoked
+	}
+	return nil
+}
+
+// 校验待吊销的证书hash非空且不重复, 返回格式化后的hash
+func ValidateCertHashes(hashes []string) ([]string, error) {
+	if len(hashes) == 0 {
+		return nil, errors.New("empty cert hashes")
+	}
+	result := make([]string, 0, len(hashes))
+	exists := make(map[string]bool)
+	for _, hash := range hashes {
+		h, err := ParseCertHash(hash)
+		if err != nil {
+			return nil, err
+		}
+		if exists[h] {
+			return nil, errors.New("duplicate cert hash")
+		}
+		exists[h] = true
+		result = append(result, h)
+	}
+	return result, nil
+}
+
+// addr是否为rootCA公钥对应地址
+func IsRootCA(rootCA crypto.PubKey, addr btypes.Address) bool {
+	return rootCA != nil && len(addr) != 0 && bytes.Equal(rootCA.Address(), addr)
+}
@@ -18,6 +18,8 @@ func init() {
 // 为包内定义结构注册codec
 func RegisterCodec(cdc *go_amino.Codec) {
 	cdc.RegisterConcrete(&QOSAccount{}, "qos/types/QOSAccount", nil)
+	cdc.RegisterConcrete(&ContinuousVestingAccount{}, "qos/types/ContinuousVestingAccount", nil)
+	cdc.RegisterConcrete(&DelayedVestingAccount{}, "qos/types/DelayedVestingAccount", nil)
 	cdc.RegisterConcrete(&Fraction{}, "qos/types/Fraction", nil)
 	cdc.RegisterConcrete(&Dec{}, "qos/types/Dec", nil)
 }
@@ -0,0 +1,221 @@
+package types
+
+import (
+	"errors"
+	"time"
+
+	"github.com/QOSGroup/qbase/account"
+	btypes "github.com/QOSGroup/qbase/types"
+)
+
+// 锁仓账户: 锁仓的QOS按时间释放, 未释放的QOS可用于委托, 不可转账或支付gas
+type VestingAccount interface {
+	account.Account
+
+	// 内嵌的QOSAccount, 修改后需保存锁仓账户本身
+	GetQOSAccount() *QOSAccount
+
+	// blockTime时已释放的锁仓QOS
+	GetVestedQOS(blockTime time.Time) btypes.BigInt
+	// blockTime时未释放的锁仓QOS
+	GetVestingQOS(blockTime time.Time) btypes.BigInt
+	// blockTime时可支出的QOS
+	SpendableQOS(blockTime time.Time) btypes.BigInt
+
+	// 记录委托的QOS, 优先记为未释放部分
+	TrackDelegation(blockTime time.Time, amount btypes.BigInt)
+	// 记录解除委托返还的QOS, 优先记为已释放部分
+	TrackUndelegation(amount btypes.BigInt)
+
+	Validate() error
+}
+
+type BaseVestingAccount struct {
+	QOSAccount       `json:"qos_account"`
+	OriginalVesting  btypes.BigInt `json:"original_vesting"`  // 锁仓QOS总量
+	DelegatedFree    btypes.BigInt `json:"delegated_free"`    // 委托中的已释放QOS
+	DelegatedVesting btypes.BigInt `json:"delegated_vesting"` // 委托中的未释放QOS
+	EndTime          time.Time     `json:"end_time"`          // 锁仓结束时间
+}
+
+func newBaseVestingAccount(acc *QOSAccount, originalVesting btypes.BigInt, endTime time.Time) BaseVestingAccount {
+	return BaseVestingAccount{
+		QOSAccount:       *acc,
+		OriginalVesting:  originalVesting,
+		DelegatedFree:    btypes.ZeroInt(),
+		DelegatedVesting: btypes.ZeroInt(),
+		EndTime:          endTime.UTC(),
+	}
+}
+
+func (bva *BaseVestingAccount) GetQOSAccount() *QOSAccount {
+	return &bva.QOSAccount
+}
+
+// 可支出QOS = 余额 - 未委托的未释放QOS
+func (bva *BaseVestingAccount) spendableQOS(vesting btypes.BigInt) btypes.BigInt {
+	locked := vesting.Sub(bva.DelegatedVesting.NilToZero())
+	if locked.LT(btypes.ZeroInt()) {
+		locked = btypes.ZeroInt()
+	}
+
+	spendable := bva.GetQOS().Sub(locked)
+	if spendable.LT(btypes.ZeroInt()) {
+		return btypes.ZeroInt()
+	}
+	return spendable
+}
+
+func (bva *BaseVestingAccount) trackDelegation(vesting, amount btypes.BigInt) {
+	delegatedVesting := bva.DelegatedVesting.NilToZero()
+
+	x := vesting.Sub(delegatedVesting)
+	if x.LT(btypes.ZeroInt()) {
+		x = btypes.ZeroInt()
+	}
+	if x.GT(amount) {
+		x = amount
+	}
+
+	bva.DelegatedVesting = delegatedVesting.Add(x)
+	bva.DelegatedFree = bva.DelegatedFree.NilToZero().Add(amount.Sub(x))
+}
+
+func (bva *BaseVestingAccount) TrackUndelegation(amount btypes.BigInt) {
+	delegatedFree := bva.DelegatedFree.NilToZero()
+	delegatedVesting := bva.DelegatedVesting.NilToZero()
+
+	x := minInt(delegatedFree, amount)
+	y := minInt(delegatedVesting, amount.Sub(x))
+
+	bva.DelegatedFree = delegatedFree.Sub(x)
+	bva.DelegatedVesting = delegatedVesting.Sub(y)
+}
+
+func (bva *BaseVestingAccount) validate() error {
+	if bva.OriginalVesting.IsNil() || !bva.OriginalVesting.GT(btypes.ZeroInt()) {
+		return errors.New("original vesting must gt zero")
+	}
+	if bva.GetQOS().LT(bva.OriginalVesting) {
+		return errors.New("original vesting must lte qos")
+	}
+	if bva.EndTime.IsZero() {
+		return errors.New("vesting end time is empty")
+	}
+	return nil
+}
+
+// 连续释放锁仓账户: StartTime至EndTime间线性释放
+type ContinuousVestingAccount struct {
+	BaseVestingAccount `json:"base_vesting_account"`
+	StartTime          time.Time `json:"start_time"` // 开始释放时间
+}
+
+var _ VestingAccount = (*ContinuousVestingAccount)(nil)
+
+func NewContinuousVestingAccount(acc *QOSAccount, originalVesting btypes.BigInt, startTime, endTime time.Time) *ContinuousVestingAccount {
+	return &ContinuousVestingAccount{
+		BaseVestingAccount: newBaseVestingAccount(acc, originalVesting, endTime),
+		StartTime:          startTime.UTC(),
+	}
+}
+
+func (cva *ContinuousVestingAccount) GetVestedQOS(blockTime time.Time) btypes.BigInt {
+	if !blockTime.After(cva.StartTime) {
+		return btypes.ZeroInt()
+	}
+	if !blockTime.Before(cva.EndTime) {
+		return cva.OriginalVesting
+	}
+
+	x := btypes.NewInt(int64(blockTime.Sub(cva.StartTime).Seconds()))
+	y := btypes.NewInt(int64(cva.EndTime.Sub(cva.StartTime).Seconds()))
+	return cva.OriginalVesting.Mul(x).Div(y)
+}
+
+func (cva *ContinuousVestingAccount) GetVestingQOS(blockTime time.Time) btypes.BigInt {
+	return cva.OriginalVesting.Sub(cva.GetVestedQOS(blockTime))
+}
+
+func (cva *ContinuousVestingAccount) SpendableQOS(blockTime time.Time) btypes.BigInt {
+	return cva.spendableQOS(cva.GetVestingQOS(blockTime))
+}
+
+func (cva *ContinuousVestingAccount) TrackDelegation(blockTime time.Time, amount btypes.BigInt) {
+	cva.trackDelegation(cva.GetVestingQOS(blockTime), amount)
+}
+
+func (cva *ContinuousVestingAccount) Validate() error {
+	if err := cva.validate(); err != nil {
+		return err
+	}
+	if !cva.EndTime.After(cva.StartTime) {
+		return errors.New("vesting end time must after start time")
+	}
+	return nil
+}
+
+// 延迟释放锁仓账户: EndTime时一次性释放
+type DelayedVestingAccount struct {
+	BaseVestingAccount `json:"base_vesting_account"`
+}
+
+var _ VestingAccount = (*DelayedVestingAccount)(nil)
+
+func NewDelayedVestingAccount(acc *QOSAccount, originalVesting btypes.BigInt, endTime time.Time) *DelayedVestingAccount {
+	return &DelayedVestingAccount{
+		BaseVestingAccount: newBaseVestingAccount(acc, originalVesting, endTime),
+	}
+}
+
+func (dva *DelayedVestingAccount) GetVestedQOS(blockTime time.Time) btypes.BigInt {
+	if !blockTime.Before(dva.EndTime) {
+		return dva.OriginalVesting
+	}
+	return btypes.ZeroInt()
+}
+
+func (dva *DelayedVestingAccount) GetVestingQOS(blockTime time.Time) btypes.BigInt {
+	return dva.OriginalVesting.Sub(dva.GetVestedQOS(blockTime))
+}
+
+func (dva *DelayedVestingAccount) SpendableQOS(blockTime time.Time) btypes.BigInt {
+	return dva.spendableQOS(dva.GetVestingQOS(blockTime))
+}
+
+func (dva *DelayedVestingAccount) TrackDelegation(blockTime time.Time, amount btypes.BigInt) {
+	dva.trackDelegation(dva.GetVestingQOS(blockTime), amount)
+}
+
+func (dva *DelayedVestingAccount) Validate() error {
+	return dva.validate()
+}
+
+// 返回账户对应的QOSAccount, 锁仓账户返回其内嵌的QOSAccount, 修改后需保存原账户
+func ToQOSAccount(acc account.Account) (*QOSAccount, bool) {
+	switch a := acc.(type) {
+	case *QOSAccount:
+		return a, true
+	case VestingAccount:
+		return a.GetQOSAccount(), true
+	}
+	return nil, false
+}
+
+// 账户在blockTime可支出的QOS, 非锁仓账户为全部QOS
+func SpendableQOS(acc account.Account, blockTime time.Time) btypes.BigInt {
+	if vacc, ok := acc.(VestingAccount); ok {
+		return vacc.SpendableQOS(blockTime)
+	}
+	if qacc, ok := acc.(*QOSAccount); ok {
+		return qacc.GetQOS()
+	}
+	return btypes.ZeroInt()
+}
+
+func minInt(a, b btypes.BigInt) btypes.BigInt {
+	if a.LT(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,80 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/QOSGroup/qbase/account"
+	btypes "github.com/QOSGroup/qbase/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+func TestContinuousVestingAccount(t *testing.T) {
+	startTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(100 * time.Hour)
+	acc := NewQOSAccount(btypes.Address(ed25519.GenPrivKey().PubKey().Address()), btypes.NewInt(1000), nil)
+	cva := NewContinuousVestingAccount(acc, btypes.NewInt(1000), startTime, endTime)
+	require.Nil(t, cva.Validate())
+
+	cases := []struct {
+		blockTime time.Time
+		vested    btypes.BigInt
+	}{
+		{startTime.Add(-time.Hour), btypes.ZeroInt()},
+		{startTime, btypes.ZeroInt()},
+		{startTime.Add(25 * time.Hour), btypes.NewInt(250)},
+		{endTime, btypes.NewInt(1000)},
+		{endTime.Add(time.Hour), btypes.NewInt(1000)},
+	}
+
+	for tcIndex, tc := range cases {
+		require.True(t, tc.vested.Equal(cva.GetVestedQOS(tc.blockTime)), "tc #%d", tcIndex)
+		require.True(t, btypes.NewInt(1000).Sub(tc.vested).Equal(cva.GetVestingQOS(tc.blockTime)), "tc #%d", tcIndex)
+		require.True(t, tc.vested.Equal(cva.SpendableQOS(tc.blockTime)), "tc #%d", tcIndex)
+	}
+
+	//委托未释放的QOS
+	blockTime := startTime.Add(25 * time.Hour)
+	cva.MustMinusQOS(btypes.NewInt(800))
+	cva.TrackDelegation(blockTime, btypes.NewInt(800))
+	require.True(t, btypes.NewInt(750).Equal(cva.DelegatedVesting))
+	require.True(t, btypes.NewInt(50).Equal(cva.DelegatedFree))
+	require.True(t, btypes.NewInt(200).Equal(cva.SpendableQOS(blockTime)))
+
+	//解除委托优先返还已释放部分
+	cva.MustPlusQOS(btypes.NewInt(100))
+	cva.TrackUndelegation(btypes.NewInt(100))
+	require.True(t, btypes.ZeroInt().Equal(cva.DelegatedFree))
+	require.True(t, btypes.NewInt(700).Equal(cva.DelegatedVesting))
+	require.True(t, btypes.NewInt(250).Equal(cva.SpendableQOS(blockTime)))
+
+	require.NotNil(t, NewContinuousVestingAccount(acc, btypes.NewInt(1000), endTime, startTime).Validate())
+	require.NotNil(t, NewContinuousVestingAccount(acc, btypes.NewInt(1001), startTime, endTime).Validate())
+}
+
+func TestDelayedVestingAccount(t *testing.T) {
+	endTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	acc := NewQOSAccount(btypes.Address(ed25519.GenPrivKey().PubKey().Address()), btypes.NewInt(1000), nil)
+	dva := NewDelayedVestingAccount(acc, btypes.NewInt(600), endTime)
+	require.Nil(t, dva.Validate())
+
+	require.True(t, btypes.NewInt(400).Equal(dva.SpendableQOS(endTime.Add(-time.Second))))
+	require.True(t, btypes.NewInt(1000).Equal(dva.SpendableQOS(endTime)))
+
+	//amino编解码
+	bz, err := cdc.MarshalBinaryBare(account.Account(dva))
+	require.Nil(t, err)
+	var decoded account.Account
+	require.Nil(t, cdc.UnmarshalBinaryBare(bz, &decoded))
+	vacc, ok := decoded.(*DelayedVestingAccount)
+	require.True(t, ok)
+	require.Equal(t, dva.OriginalVesting, vacc.OriginalVesting)
+	require.Equal(t, dva.EndTime, vacc.EndTime)
+	require.Equal(t, acc.AccountAddress, vacc.GetAddress())
+
+	qosAcc, ok := ToQOSAccount(decoded)
+	require.True(t, ok)
+	require.True(t, btypes.NewInt(1000).Equal(qosAcc.GetQOS()))
+	require.True(t, btypes.NewInt(400).Equal(SpendableQOS(decoded, endTime.Add(-time.Second))))
+}